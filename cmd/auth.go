@@ -8,6 +8,8 @@ import (
 
 	"github.com/erickhilda/atlit/internal/bitbucket"
 	"github.com/erickhilda/atlit/internal/config"
+	"github.com/erickhilda/atlit/internal/github"
+	"github.com/erickhilda/atlit/internal/gitlab"
 	"github.com/erickhilda/atlit/internal/jira"
 	"github.com/spf13/cobra"
 	"golang.org/x/term"
@@ -31,13 +33,37 @@ var authBitbucketCmd = &cobra.Command{
 	Long: `Prompts for a Bitbucket Cloud API token and stores it.
 
 Create the token at https://id.atlassian.com/manage-profile/security/api-tokens
-with scopes: read:pullrequest:bitbucket and read:repository:bitbucket.`,
+with scopes: read:pullrequest:bitbucket and read:repository:bitbucket. Add
+write:pullrequest:bitbucket to use 'atlit pr comment', 'approve' and 'merge'.`,
 	RunE: runAuthBitbucket,
 }
 
+var authGitHubCmd = &cobra.Command{
+	Use:   "github",
+	Short: "Set and verify your GitHub token",
+	Long: `Prompts for a GitHub personal access token and stores it for 'atlit pr'.
+
+Create a fine-grained token with read access to pull requests and contents (or
+a classic token with the repo scope). The token is verified against
+github_host when set, otherwise github.com. Without a stored token, GITHUB_TOKEN
+is used.`,
+	RunE: runAuthGitHub,
+}
+
+var authGitLabCmd = &cobra.Command{
+	Use:   "gitlab",
+	Short: "Set and verify your GitLab token",
+	Long: `Prompts for a GitLab personal access token (scope: read_api) and stores it
+for 'atlit pr'. The token is verified against gitlab_host when set, otherwise
+gitlab.com. Without a stored token, GITLAB_TOKEN is used.`,
+	RunE: runAuthGitLab,
+}
+
 func init() {
 	authCmd.AddCommand(authTestCmd)
 	authCmd.AddCommand(authBitbucketCmd)
+	authCmd.AddCommand(authGitHubCmd)
+	authCmd.AddCommand(authGitLabCmd)
 	rootCmd.AddCommand(authCmd)
 }
 
@@ -101,3 +127,68 @@ func runAuthBitbucket(cmd *cobra.Command, args []string) error {
 	fmt.Printf("Verified access to workspace %q.\n", cfg.BitbucketWorkspace)
 	return nil
 }
+
+func runAuthGitHub(cmd *cobra.Command, args []string) error {
+	cfg, err := config.Load()
+	if err != nil {
+		return err
+	}
+	token, err := promptToken("GitHub token: ")
+	if err != nil {
+		return err
+	}
+	storage, err := config.SetGitHubToken(cfg.Email, token)
+	if err != nil {
+		return fmt.Errorf("storing token: %w", err)
+	}
+	fmt.Printf("GitHub token stored (via %s).\n", storage)
+
+	host := firstNonEmpty(cfg.GitHubHost, github.PublicHost)
+	login, err := github.NewClient(host, token).CurrentUser()
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Warning: could not verify the token against %s: %v\n", host, err)
+		return nil
+	}
+	fmt.Printf("Verified as %s on %s.\n", login, host)
+	return nil
+}
+
+func runAuthGitLab(cmd *cobra.Command, args []string) error {
+	cfg, err := config.Load()
+	if err != nil {
+		return err
+	}
+	token, err := promptToken("GitLab token: ")
+	if err != nil {
+		return err
+	}
+	storage, err := config.SetGitLabToken(cfg.Email, token)
+	if err != nil {
+		return fmt.Errorf("storing token: %w", err)
+	}
+	fmt.Printf("GitLab token stored (via %s).\n", storage)
+
+	host := firstNonEmpty(cfg.GitLabHost, gitlab.PublicHost)
+	user, err := gitlab.NewClient(host, token).CurrentUser()
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Warning: could not verify the token against %s: %v\n", host, err)
+		return nil
+	}
+	fmt.Printf("Verified as %s on %s.\n", user, host)
+	return nil
+}
+
+// promptToken reads a token from the terminal without echoing it.
+func promptToken(prompt string) (string, error) {
+	fmt.Print(prompt)
+	tokenBytes, err := term.ReadPassword(int(syscall.Stdin))
+	fmt.Println()
+	if err != nil {
+		return "", fmt.Errorf("reading token: %w", err)
+	}
+	token := strings.TrimSpace(string(tokenBytes))
+	if token == "" {
+		return "", fmt.Errorf("token is required")
+	}
+	return token, nil
+}
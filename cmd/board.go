@@ -0,0 +1,200 @@
+package cmd
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/erickhilda/atlit/internal/config"
+	"github.com/erickhilda/atlit/internal/jira"
+	"github.com/spf13/cobra"
+)
+
+var boardCmd = &cobra.Command{
+	Use:   "board",
+	Short: "Show a Jira board's columns",
+	Long: `Shows a Jira Software board (name, type, project) and its columns with the
+workflow statuses mapped to each, in board order. Nothing is written to disk.
+
+The board defaults to 'board_id' from config; --board overrides it. Run
+'atlit board list' to find a board's id.`,
+	Args: cobra.NoArgs,
+	RunE: runBoard,
+}
+
+var boardListCmd = &cobra.Command{
+	Use:   "list",
+	Short: "List Jira boards",
+	Long: `Lists the Jira Software boards you can see as a table on stdout, scoped to
+default_project unless overridden. Use the id with 'atlit config set board_id <id>'
+or the --board flag of 'atlit board' and 'atlit sprint'.`,
+	Args: cobra.NoArgs,
+	RunE: runBoardList,
+}
+
+func init() {
+	boardCmd.Flags().Int("board", 0, "Board id (overrides board_id from config)")
+
+	boardListCmd.Flags().String("project", "", "Restrict to boards for this project key (overrides default_project)")
+	boardListCmd.Flags().Bool("all-projects", false, "Do not restrict to a project")
+	boardCmd.AddCommand(boardListCmd)
+
+	rootCmd.AddCommand(boardCmd)
+}
+
+func runBoard(cmd *cobra.Command, _ []string) error {
+	boardFlag, _ := cmd.Flags().GetInt("board")
+
+	cfg, err := config.Load()
+	if err != nil {
+		return err
+	}
+	boardID, err := resolveBoardID(boardFlag, cfg)
+	if err != nil {
+		return err
+	}
+
+	token, err := config.GetToken(cfg)
+	if err != nil {
+		return fmt.Errorf("retrieving token: %w", err)
+	}
+	client := jira.NewClient(cfg.Instance, cfg.Email, token)
+
+	board, err := client.GetBoard(boardID)
+	if err != nil {
+		return wrapBoardError(err, boardID)
+	}
+	columns, err := client.GetBoardColumns(boardID)
+	if err != nil {
+		return wrapBoardError(err, boardID)
+	}
+
+	// Column statuses are bare ids; resolve them to names. A failure here only
+	// degrades the output to ids, so it is not fatal.
+	statusNames := map[string]string{}
+	if statuses, serr := client.GetStatuses(); serr == nil {
+		for _, s := range statuses {
+			statusNames[s.ID] = s.Name
+		}
+	}
+
+	printBoard(board, columns, statusNames)
+	return nil
+}
+
+func runBoardList(cmd *cobra.Command, _ []string) error {
+	project, _ := cmd.Flags().GetString("project")
+	allProjects, _ := cmd.Flags().GetBool("all-projects")
+	project = strings.TrimSpace(project)
+	if project != "" && allProjects {
+		return errors.New("--project and --all-projects are mutually exclusive")
+	}
+
+	cfg, err := config.Load()
+	if err != nil {
+		return err
+	}
+	if project == "" && !allProjects {
+		project = cfg.DefaultProject
+	}
+
+	token, err := config.GetToken(cfg)
+	if err != nil {
+		return fmt.Errorf("retrieving token: %w", err)
+	}
+	client := jira.NewClient(cfg.Instance, cfg.Email, token)
+
+	boards, err := client.ListBoards(project)
+	if err != nil {
+		if errors.Is(err, jira.ErrUnauthorized) {
+			return fmt.Errorf("authentication failed: %w", err)
+		}
+		return fmt.Errorf("listing boards: %w", err)
+	}
+
+	if len(boards) == 0 {
+		if project != "" {
+			fmt.Printf("No boards for project %s.\n", project)
+		} else {
+			fmt.Println("No boards found.")
+		}
+		return nil
+	}
+
+	fmt.Printf("%-8s %-40s %-8s %s\n", "ID", "NAME", "TYPE", "PROJECT")
+	for _, b := range boards {
+		proj := b.Location.ProjectKey
+		if proj == "" {
+			proj = "-"
+		}
+		fmt.Printf("%-8d %-40s %-8s %s\n", b.ID, truncate(b.Name, 40), b.Type, proj)
+	}
+	fmt.Printf("\n%d board(s). Set a default with 'atlit config set board_id <ID>'.\n", len(boards))
+	return nil
+}
+
+// resolveBoardID picks the board to use: the --board flag when set, otherwise
+// board_id from config.
+func resolveBoardID(flag int, cfg *config.Config) (int, error) {
+	if flag < 0 {
+		return 0, fmt.Errorf("invalid --board %d", flag)
+	}
+	if flag > 0 {
+		return flag, nil
+	}
+	if cfg.BoardID > 0 {
+		return cfg.BoardID, nil
+	}
+	return 0, errors.New("no board: pass --board <id> or set 'atlit config set board_id <id>' (find ids with 'atlit board list')")
+}
+
+// printBoard renders the board header and its column -> statuses table.
+func printBoard(board *jira.Board, columns []jira.BoardColumn, statusNames map[string]string) {
+	fmt.Printf("Board %d: %s (%s", board.ID, board.Name, board.Type)
+	if board.Location.ProjectKey != "" {
+		fmt.Printf(", project %s", board.Location.ProjectKey)
+	}
+	fmt.Println(")")
+
+	if len(columns) == 0 {
+		fmt.Println("\nNo columns configured.")
+		return
+	}
+	fmt.Printf("\n%-24s %s\n", "COLUMN", "STATUSES")
+	for _, col := range columns {
+		fmt.Printf("%-24s %s\n", truncate(col.Name, 24), columnStatuses(col, statusNames))
+	}
+}
+
+// columnStatuses joins a column's status names, falling back to the raw id for
+// any status missing from statusNames and to "-" for an unmapped column.
+func columnStatuses(col jira.BoardColumn, statusNames map[string]string) string {
+	var names []string
+	for _, s := range col.Statuses {
+		if name := statusNames[s.ID]; name != "" {
+			names = append(names, name)
+		} else {
+			names = append(names, s.ID)
+		}
+	}
+	if len(names) == 0 {
+		return "-"
+	}
+	return strings.Join(names, ", ")
+}
+
+// wrapBoardError maps Agile API errors for a board to user-facing messages.
+func wrapBoardError(err error, boardID int) error {
+	var apiErr *jira.APIError
+	switch {
+	case errors.Is(err, jira.ErrUnauthorized):
+		return fmt.Errorf("authentication failed: %w", err)
+	case errors.Is(err, jira.ErrNotFound):
+		return fmt.Errorf("board %d not found or no access", boardID)
+	case errors.As(err, &apiErr) && apiErr.StatusCode == 400:
+		// The sprint endpoints answer 400 for boards without sprints.
+		return fmt.Errorf("board %d does not support sprints (kanban board?)", boardID)
+	default:
+		return err
+	}
+}
@@ -0,0 +1,34 @@
+package cmd
+
+import (
+	"testing"
+
+	"github.com/erickhilda/atlit/internal/config"
+	"github.com/erickhilda/atlit/internal/jira"
+)
+
+func TestResolveBoardID(t *testing.T) {
+	if id, err := resolveBoardID(7, &config.Config{BoardID: 42}); err != nil || id != 7 {
+		t.Errorf("flag wins: got %d err=%v", id, err)
+	}
+	if id, err := resolveBoardID(0, &config.Config{BoardID: 42}); err != nil || id != 42 {
+		t.Errorf("config fallback: got %d err=%v", id, err)
+	}
+	if _, err := resolveBoardID(0, &config.Config{}); err == nil {
+		t.Error("expected error when no board is configured")
+	}
+	if _, err := resolveBoardID(-1, &config.Config{BoardID: 42}); err == nil {
+		t.Error("expected error for negative --board")
+	}
+}
+
+func TestColumnStatuses(t *testing.T) {
+	col := jira.BoardColumn{Name: "To Do", Statuses: []jira.BoardColumnStatus{{ID: "1"}, {ID: "999"}}}
+	names := map[string]string{"1": "Open"}
+	if got := columnStatuses(col, names); got != "Open, 999" {
+		t.Errorf("got %q, want %q", got, "Open, 999")
+	}
+	if got := columnStatuses(jira.BoardColumn{Name: "Empty"}, names); got != "-" {
+		t.Errorf("empty column: got %q", got)
+	}
+}
@@ -24,16 +24,22 @@ var configSetCmd = &cobra.Command{
 	Use:   "set <key> <value>",
 	Short: "Update a configuration setting",
 	Long: `Valid keys: instance, email, default_project, tickets_dir, fetch_comments,
-fetch_pull_requests, token, bitbucket_workspace, prs_dir, bitbucket_token, pages_dir
+fetch_pull_requests, fetch_branches, fetch_commits, fetch_builds, fetch_deployments,
+token, bitbucket_workspace, prs_dir, bitbucket_token, github_host, github_token,
+gitlab_host, gitlab_token, pages_dir, board_id
 
 Examples:
   atlit config set instance https://myorg.atlassian.net
   atlit config set default_project PROJ
   atlit config set fetch_comments false
+  atlit config set fetch_commits false
   atlit config set token <new-api-token>
   atlit config set bitbucket_workspace acme
   atlit config set bitbucket_token <new-bitbucket-api-token>
-  atlit config set pages_dir ~/notes/confluence`,
+  atlit config set github_host github.example.com
+  atlit config set gitlab_token <gitlab-personal-access-token>
+  atlit config set pages_dir ~/notes/confluence
+  atlit config set board_id 42`,
 	Args: cobra.ExactArgs(2),
 	RunE: runConfigSet,
 }
@@ -60,6 +66,14 @@ func runConfigShow(cmd *cobra.Command, args []string) error {
 	if t, err := config.GetBitbucketToken(cfg); err == nil && t != "" {
 		bbToken = maskToken(t)
 	}
+	ghToken := "(not stored)"
+	if t, err := config.GetGitHubToken(cfg); err == nil && t != "" {
+		ghToken = maskToken(t)
+	}
+	glToken := "(not stored)"
+	if t, err := config.GetGitLabToken(cfg); err == nil && t != "" {
+		glToken = maskToken(t)
+	}
 
 	fmt.Printf("instance:            %s\n", cfg.Instance)
 	fmt.Printf("email:               %s\n", cfg.Email)
@@ -68,11 +82,21 @@ func runConfigShow(cmd *cobra.Command, args []string) error {
 	fmt.Printf("token_storage:       %s\n", cfg.TokenStorage)
 	fmt.Printf("fetch_comments:      %t\n", cfg.ShouldFetchComments())
 	fmt.Printf("fetch_pull_requests: %t\n", cfg.ShouldFetchPullRequests())
+	fmt.Printf("fetch_branches:      %t\n", cfg.ShouldFetchBranches())
+	fmt.Printf("fetch_commits:       %t\n", cfg.ShouldFetchCommits())
+	fmt.Printf("fetch_builds:        %t\n", cfg.ShouldFetchBuilds())
+	fmt.Printf("fetch_deployments:   %t\n", cfg.ShouldFetchDeployments())
 	fmt.Printf("token:               %s\n", token)
 	fmt.Printf("bitbucket_workspace: %s\n", cfg.BitbucketWorkspace)
 	fmt.Printf("prs_dir:             %s\n", cfg.PRsDirOrDefault())
 	fmt.Printf("bitbucket_token:     %s\n", bbToken)
+	fmt.Printf("github_host:         %s\n", cfg.GitHubHost)
+	fmt.Printf("github_token:        %s\n", ghToken)
+	fmt.Printf("gitlab_host:         %s\n", cfg.GitLabHost)
+	fmt.Printf("gitlab_token:        %s\n", glToken)
 	fmt.Printf("pages_dir:           %s\n", cfg.PagesDirOrDefault())
+	fmt.Printf("board_id:            %s\n", formatBoardID(cfg.BoardID))
+	fmt.Printf("queries:             %d saved (see 'atlit search queries')\n", len(cfg.Queries))
 	return nil
 }
 
@@ -86,6 +110,12 @@ func runConfigSet(cmd *cobra.Command, args []string) error {
 	if key == "bitbucket_token" {
 		return setBitbucketToken(value)
 	}
+	if key == "github_token" {
+		return setCodeHostToken(key, "GitHub", value, config.SetGitHubToken)
+	}
+	if key == "gitlab_token" {
+		return setCodeHostToken(key, "GitLab", value, config.SetGitLabToken)
+	}
 
 	cfg, err := config.Load()
 	if err != nil {
@@ -116,14 +146,45 @@ func runConfigSet(cmd *cobra.Command, args []string) error {
 			return fmt.Errorf("fetch_pull_requests must be true or false, got %q", value)
 		}
 		cfg.FetchPullRequests = &b
+	case "fetch_branches", "fetch_commits", "fetch_builds", "fetch_deployments":
+		b, err := strconv.ParseBool(value)
+		if err != nil {
+			return fmt.Errorf("%s must be true or false, got %q", key, value)
+		}
+		switch key {
+		case "fetch_branches":
+			cfg.FetchBranches = &b
+		case "fetch_commits":
+			cfg.FetchCommits = &b
+		case "fetch_builds":
+			cfg.FetchBuilds = &b
+		default:
+			cfg.FetchDeployments = &b
+		}
 	case "bitbucket_workspace":
 		cfg.BitbucketWorkspace = value
 	case "prs_dir":
 		cfg.PRsDir = value
+	case "github_host", "gitlab_host":
+		host, err := normalizeHost(value)
+		if err != nil {
+			return fmt.Errorf("%s: %w", key, err)
+		}
+		if key == "github_host" {
+			cfg.GitHubHost = host
+		} else {
+			cfg.GitLabHost = host
+		}
 	case "pages_dir":
 		cfg.PagesDir = value
+	case "board_id":
+		id, err := strconv.Atoi(value)
+		if err != nil || id < 0 {
+			return fmt.Errorf("board_id must be a board number (0 to unset), got %q", value)
+		}
+		cfg.BoardID = id
 	default:
-		return fmt.Errorf("unknown key %q; valid keys: instance, email, default_project, tickets_dir, fetch_comments, fetch_pull_requests, token, bitbucket_workspace, prs_dir, bitbucket_token, pages_dir", key)
+		return fmt.Errorf("unknown key %q; valid keys: instance, email, default_project, tickets_dir, fetch_comments, fetch_pull_requests, fetch_branches, fetch_commits, fetch_builds, fetch_deployments, token, bitbucket_workspace, prs_dir, bitbucket_token, github_host, github_token, gitlab_host, gitlab_token, pages_dir, board_id", key)
 	}
 
 	if err := config.Save(cfg); err != nil {
@@ -163,6 +224,40 @@ func setBitbucketToken(value string) error {
 	return nil
 }
 
+// setCodeHostToken stores a GitHub or GitLab token with set.
+func setCodeHostToken(key, label, value string, set func(email, token string) (config.TokenStorage, error)) error {
+	cfg, err := config.Load()
+	if err != nil {
+		return err
+	}
+	storage, err := set(cfg.Email, value)
+	if err != nil {
+		return fmt.Errorf("storing %s token: %w", label, err)
+	}
+	fmt.Printf("%s updated (stored via %s)\n", key, storage)
+	return nil
+}
+
+// normalizeHost accepts a bare host or a URL and returns the lower-cased host,
+// so "https://GitHub.example.com/" and "github.example.com" are equivalent.
+func normalizeHost(value string) (string, error) {
+	host := strings.ToLower(strings.TrimSpace(value))
+	host = strings.TrimPrefix(strings.TrimPrefix(host, "https://"), "http://")
+	host = strings.TrimRight(host, "/")
+	if strings.ContainsAny(host, "/ ") {
+		return "", fmt.Errorf("want a host name like github.example.com, got %q", value)
+	}
+	return host, nil
+}
+
+// formatBoardID renders an unset (zero) board id as empty, like other unset keys.
+func formatBoardID(id int) string {
+	if id == 0 {
+		return ""
+	}
+	return strconv.Itoa(id)
+}
+
 func maskToken(token string) string {
 	if len(token) <= 4 {
 		return "****"
@@ -0,0 +1,389 @@
+package cmd
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/erickhilda/atlit/internal/config"
+	"github.com/erickhilda/atlit/internal/jira"
+	"github.com/erickhilda/atlit/internal/store"
+	"github.com/spf13/cobra"
+)
+
+// draftSummaryPlaceholder is the H1 of a fresh template; creating a draft that
+// still carries it is refused.
+const draftSummaryPlaceholder = "Summary goes here"
+
+// draftTemplates are the section skeletons offered by `atlit new --template`.
+var draftTemplates = map[string]struct {
+	issueType string
+	sections  []string
+}{
+	"bug":   {issueType: "Bug", sections: []string{"Description", "Steps to Reproduce", "Expected Behavior", "Actual Behavior"}},
+	"story": {issueType: "Story", sections: []string{"Description", "Acceptance Criteria", "Technical Requirements", "Release Notes"}},
+	"task":  {issueType: "Task", sections: []string{"Description", "Technical Requirements"}},
+}
+
+var createCmd = &cobra.Command{
+	Use:   "create <file.md>",
+	Short: "Create a Jira issue from a local markdown draft",
+	Long: `Creates a Jira issue from a markdown draft (see 'atlit new'):
+
+  - the first "# " heading is the summary
+  - the metadata table supplies Project, Type, Priority, Assignee, Labels and Parent
+  - everything after the table becomes the description (a "## My Notes"
+    section stays local)
+
+--project and --type override the table; the project falls back to
+default_project. On success the draft is moved to tickets_dir as <KEY>.md,
+its heading becomes "# <KEY>: <summary>" and it is stamped with an atlit:meta
+header, so 'atlit push' and 'atlit pull' work on it straight away.
+
+  atlit create --project PROJ --type Story story.md
+  atlit create bug-draft.md --dry-run`,
+	Args: cobra.ExactArgs(1),
+	RunE: runCreate,
+}
+
+var newCmd = &cobra.Command{
+	Use:   "new [file.md]",
+	Short: "Scaffold a local issue draft for 'atlit create'",
+	Long: `Writes a markdown draft from a template (bug, story or task) for you to fill
+in and then create with 'atlit create <file.md>'. The file defaults to
+new-<template>.md in the current directory; an existing file is not
+overwritten unless --force is given.
+
+  atlit new --template bug
+  atlit new --template story --project FOO login-story.md`,
+	Args: cobra.MaximumNArgs(1),
+	RunE: runNew,
+}
+
+func init() {
+	createCmd.Flags().String("project", "", "Project key (overrides the draft's Project row and default_project)")
+	createCmd.Flags().String("type", "", "Issue type name, e.g. Story or Bug (overrides the draft's Type row)")
+	createCmd.Flags().Bool("dry-run", false, "Print what would be sent without creating the issue")
+	rootCmd.AddCommand(createCmd)
+
+	newCmd.Flags().String("template", "task", "Draft template: bug, story or task")
+	newCmd.Flags().String("project", "", "Project key to prefill (defaults to default_project)")
+	newCmd.Flags().Bool("force", false, "Overwrite an existing file")
+	rootCmd.AddCommand(newCmd)
+}
+
+// issueDraft is a parsed local draft.
+type issueDraft struct {
+	Summary   string
+	Fields    map[string]string // lower-cased field name -> value, empty values dropped
+	Ignored   []string          // table rows that do not map to a create field
+	Body      string            // markdown sent as the description
+	Remainder string            // the draft after its H1, kept as the local file body
+}
+
+// draftFields are the metadata-table rows `atlit create` understands.
+var draftFields = map[string]string{
+	"project":    "project",
+	"type":       "type",
+	"issue type": "type",
+	"priority":   "priority",
+	"assignee":   "assignee",
+	"labels":     "labels",
+	"parent":     "parent",
+}
+
+func runCreate(cmd *cobra.Command, args []string) error {
+	path := args[0]
+	projectFlag, _ := cmd.Flags().GetString("project")
+	typeFlag, _ := cmd.Flags().GetString("type")
+	dryRun, _ := cmd.Flags().GetBool("dry-run")
+
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return fmt.Errorf("reading draft: %w", err)
+	}
+	draft, err := parseDraft(string(data))
+	if err != nil {
+		return fmt.Errorf("%s: %w", path, err)
+	}
+	for _, name := range draft.Ignored {
+		fmt.Fprintf(os.Stderr, "warning: ignoring unsupported field %q\n", name)
+	}
+
+	cfg, err := config.Load()
+	if err != nil {
+		return err
+	}
+
+	ni := &jira.NewIssue{
+		ProjectKey:  strings.ToUpper(firstNonEmpty(strings.TrimSpace(projectFlag), draft.Fields["project"], cfg.DefaultProject)),
+		IssueType:   firstNonEmpty(strings.TrimSpace(typeFlag), draft.Fields["type"]),
+		Summary:     draft.Summary,
+		Description: jira.MarkdownToADF(draft.Body),
+		Priority:    draft.Fields["priority"],
+		Labels:      draftLabels(draft.Fields["labels"]),
+		ParentKey:   strings.ToUpper(draft.Fields["parent"]),
+	}
+	if ni.ProjectKey == "" {
+		return errors.New("no project: add a Project row, pass --project, or set default_project")
+	}
+	if ni.IssueType == "" {
+		return errors.New("no issue type: add a Type row or pass --type")
+	}
+	assignee := draft.Fields["assignee"]
+
+	if dryRun {
+		fmt.Printf("Would create %s in %s: %s\n", ni.IssueType, ni.ProjectKey, ni.Summary)
+		if ni.Priority != "" {
+			fmt.Printf("  priority: %s\n", ni.Priority)
+		}
+		if assignee != "" {
+			fmt.Printf("  assignee: %s\n", assignee)
+		}
+		if len(ni.Labels) > 0 {
+			fmt.Printf("  labels:   %s\n", strings.Join(ni.Labels, ", "))
+		}
+		if ni.ParentKey != "" {
+			fmt.Printf("  parent:   %s\n", ni.ParentKey)
+		}
+		fmt.Println()
+		out, _ := json.MarshalIndent(ni.Description, "", "  ")
+		fmt.Println(string(out))
+		return nil
+	}
+
+	token, err := config.GetToken(cfg)
+	if err != nil {
+		return fmt.Errorf("retrieving token: %w", err)
+	}
+	client := jira.NewClient(cfg.Instance, cfg.Email, token)
+
+	if assignee != "" {
+		if ni.AssigneeID, err = resolveDraftAssignee(client, assignee); err != nil {
+			return err
+		}
+	}
+
+	created, err := client.CreateIssue(ni)
+	if err != nil {
+		if errors.Is(err, jira.ErrUnauthorized) {
+			return fmt.Errorf("authentication failed: check 'atlit auth test'")
+		}
+		if errors.Is(err, jira.ErrNotFound) {
+			return fmt.Errorf("project %s not found or no access", ni.ProjectKey)
+		}
+		return fmt.Errorf("creating issue: %w", err)
+	}
+	fmt.Printf("Created %s: %s\n", created.Key, ni.Summary)
+	fmt.Printf("  %s/browse/%s\n", strings.TrimRight(cfg.Instance, "/"), created.Key)
+
+	// The issue exists now; a failure below only affects the local copy.
+	content := store.StampMeta(stampedDraft(created.Key, draft), created.Key, time.Now())
+	if err := store.Save(cfg.TicketsDir, created.Key, content); err != nil {
+		return fmt.Errorf("%s was created but saving it locally failed (run 'atlit pull %s'): %w", created.Key, created.Key, err)
+	}
+	saved, _ := store.TicketPath(cfg.TicketsDir, created.Key)
+	if !samePath(path, saved) {
+		if err := os.Remove(path); err != nil {
+			fmt.Fprintf(os.Stderr, "warning: could not remove draft %s: %v\n", path, err)
+		}
+	}
+	fmt.Printf("Saved to %s\n", saved)
+	return nil
+}
+
+func runNew(cmd *cobra.Command, args []string) error {
+	name, _ := cmd.Flags().GetString("template")
+	project, _ := cmd.Flags().GetString("project")
+	force, _ := cmd.Flags().GetBool("force")
+
+	name = strings.ToLower(strings.TrimSpace(name))
+	if _, ok := draftTemplates[name]; !ok {
+		return fmt.Errorf("unknown template %q; valid templates: %s", name, strings.Join(draftTemplateNames(), ", "))
+	}
+
+	path := "new-" + name + ".md"
+	if len(args) == 1 {
+		path = args[0]
+	}
+	if !force {
+		if _, err := os.Stat(path); err == nil {
+			return fmt.Errorf("%s already exists; pass --force to overwrite", path)
+		}
+	}
+
+	project = strings.TrimSpace(project)
+	if project == "" {
+		if cfg, err := config.Load(); err == nil {
+			project = cfg.DefaultProject
+		}
+	}
+
+	if err := os.WriteFile(path, []byte(renderDraftTemplate(name, strings.ToUpper(project))), 0644); err != nil {
+		return fmt.Errorf("writing draft: %w", err)
+	}
+	fmt.Printf("Wrote %s. Fill it in, then run 'atlit create %s'.\n", path, path)
+	return nil
+}
+
+// renderDraftTemplate builds the markdown skeleton for template name.
+func renderDraftTemplate(name, project string) string {
+	tpl := draftTemplates[name]
+	var b strings.Builder
+	fmt.Fprintf(&b, "# %s\n\n", draftSummaryPlaceholder)
+	b.WriteString("| Field | Value |\n")
+	b.WriteString("|-------|-------|\n")
+	writeDraftRow(&b, "Project", project)
+	writeDraftRow(&b, "Type", tpl.issueType)
+	writeDraftRow(&b, "Priority", "")
+	writeDraftRow(&b, "Assignee", "")
+	writeDraftRow(&b, "Labels", "")
+	writeDraftRow(&b, "Parent", "")
+	b.WriteString("\n")
+	for _, s := range tpl.sections {
+		fmt.Fprintf(&b, "## %s\n\n", s)
+	}
+	b.WriteString("## My Notes\n\n")
+	return b.String()
+}
+
+func writeDraftRow(b *strings.Builder, field, value string) {
+	fmt.Fprintf(b, "| %s | %s |\n", field, value)
+}
+
+func draftTemplateNames() []string {
+	names := make([]string, 0, len(draftTemplates))
+	for n := range draftTemplates {
+		names = append(names, n)
+	}
+	sort.Strings(names)
+	return names
+}
+
+// parseDraft splits a draft into its summary (first H1), metadata-table
+// fields, and description body. The table is optional; when present it must
+// come before the first "## " section.
+func parseDraft(content string) (*issueDraft, error) {
+	// A stamped file already has a key; creating it again would duplicate it.
+	if meta := store.ParseMeta(content); meta != nil {
+		return nil, fmt.Errorf("already created as %s; edit it and use 'atlit push' instead", meta.Ticket)
+	}
+
+	lines := strings.Split(strings.ReplaceAll(content, "\r\n", "\n"), "\n")
+	h1 := -1
+	for i, l := range lines {
+		if strings.HasPrefix(l, "# ") {
+			h1 = i
+			break
+		}
+		if strings.HasPrefix(l, "## ") {
+			break
+		}
+	}
+	if h1 < 0 {
+		return nil, errors.New(`no "# " heading to use as the summary`)
+	}
+	draft := &issueDraft{
+		Summary: strings.TrimSpace(strings.TrimPrefix(lines[h1], "# ")),
+		Fields:  map[string]string{},
+	}
+	if draft.Summary == "" || draft.Summary == draftSummaryPlaceholder {
+		return nil, errors.New("fill in the summary (the \"# \" heading) first")
+	}
+
+	// Skip blank lines, then consume the table if one follows the heading.
+	i := h1 + 1
+	for i < len(lines) && strings.TrimSpace(lines[i]) == "" {
+		i++
+	}
+	for ; i < len(lines) && strings.HasPrefix(strings.TrimSpace(lines[i]), "|"); i++ {
+		field, value, ok := parseTableRow(lines[i])
+		if !ok {
+			continue
+		}
+		key, known := draftFields[strings.ToLower(field)]
+		if !known {
+			if value != "" {
+				draft.Ignored = append(draft.Ignored, field)
+			}
+			continue
+		}
+		if value != "" {
+			draft.Fields[key] = value
+		}
+	}
+
+	draft.Remainder = strings.Join(lines[h1+1:], "\n")
+	body := strings.Join(lines[i:], "\n")
+	body = store.RemoveSection(body, "## My Notes")
+	draft.Body = strings.TrimSpace(body)
+	return draft, nil
+}
+
+// parseTableRow returns the first two cells of a markdown table row. Header
+// ("Field") and separator ("---") rows report ok=false; "-" means empty.
+func parseTableRow(line string) (field, value string, ok bool) {
+	cells := strings.Split(strings.Trim(strings.TrimSpace(line), "|"), "|")
+	if len(cells) < 2 {
+		return "", "", false
+	}
+	field = strings.TrimSpace(cells[0])
+	value = strings.TrimSpace(cells[1])
+	if field == "" || strings.EqualFold(field, "field") || strings.Trim(field, "-: ") == "" {
+		return "", "", false
+	}
+	if value == "-" {
+		value = ""
+	}
+	return field, value, true
+}
+
+// draftLabels splits a Labels cell on commas or whitespace; Jira labels
+// cannot contain spaces.
+func draftLabels(cell string) []string {
+	return strings.FieldsFunc(cell, func(r rune) bool { return r == ',' || r == ' ' })
+}
+
+// resolveDraftAssignee maps an Assignee cell to an accountId; "me" is the
+// authenticated user.
+func resolveDraftAssignee(client *jira.Client, name string) (string, error) {
+	if strings.EqualFold(name, "me") || strings.EqualFold(name, "@me") {
+		me, err := client.Myself()
+		if err != nil {
+			if errors.Is(err, jira.ErrUnauthorized) {
+				return "", fmt.Errorf("authentication failed: %w", err)
+			}
+			return "", fmt.Errorf("looking up current user: %w", err)
+		}
+		return me.AccountID, nil
+	}
+	return resolveAssignee(client, strings.TrimPrefix(name, "@"))
+}
+
+// stampedDraft rewrites the draft's heading to the pulled-file form
+// "# KEY: summary", keeping the rest of the draft as written.
+func stampedDraft(key string, draft *issueDraft) string {
+	return fmt.Sprintf("# %s: %s\n", key, draft.Summary) + draft.Remainder
+}
+
+// samePath reports whether a and b name the same file.
+func samePath(a, b string) bool {
+	absA, errA := filepath.Abs(a)
+	absB, errB := filepath.Abs(b)
+	return errA == nil && errB == nil && absA == absB
+}
+
+// firstNonEmpty returns the first non-empty value.
+func firstNonEmpty(values ...string) string {
+	for _, v := range values {
+		if v != "" {
+			return v
+		}
+	}
+	return ""
+}
@@ -0,0 +1,117 @@
+package cmd
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestParseDraft(t *testing.T) {
+	content := `# Login fails on Safari
+
+| Field | Value |
+|-------|-------|
+| Project | foo |
+| Type | Bug |
+| Priority | High |
+| Assignee | - |
+| Labels | web, safari |
+| Status | Open |
+
+## Description
+
+Users cannot log in.
+
+## My Notes
+
+local only
+`
+	d, err := parseDraft(content)
+	if err != nil {
+		t.Fatalf("parseDraft: %v", err)
+	}
+	if d.Summary != "Login fails on Safari" {
+		t.Errorf("Summary = %q", d.Summary)
+	}
+	want := map[string]string{"project": "foo", "type": "Bug", "priority": "High", "labels": "web, safari"}
+	if len(d.Fields) != len(want) {
+		t.Errorf("Fields = %v, want %v", d.Fields, want)
+	}
+	for k, v := range want {
+		if d.Fields[k] != v {
+			t.Errorf("Fields[%s] = %q, want %q", k, d.Fields[k], v)
+		}
+	}
+	if len(d.Ignored) != 1 || d.Ignored[0] != "Status" {
+		t.Errorf("Ignored = %v, want [Status]", d.Ignored)
+	}
+	if d.Body != "## Description\n\nUsers cannot log in." {
+		t.Errorf("Body = %q", d.Body)
+	}
+	if !strings.Contains(d.Remainder, "local only") {
+		t.Error("Remainder should keep My Notes for the local file")
+	}
+}
+
+func TestParseDraftNoTable(t *testing.T) {
+	d, err := parseDraft("# Just a title\n\nSome text.\n")
+	if err != nil {
+		t.Fatalf("parseDraft: %v", err)
+	}
+	if len(d.Fields) != 0 || d.Body != "Some text." {
+		t.Errorf("Fields = %v, Body = %q", d.Fields, d.Body)
+	}
+}
+
+func TestParseDraftErrors(t *testing.T) {
+	tests := []struct {
+		name    string
+		content string
+		want    string
+	}{
+		{"no heading", "## Description\n\ntext\n", "heading"},
+		{"placeholder", renderDraftTemplate("bug", "FOO"), "summary"},
+		{"already created", "<!-- atlit:meta ticket=FOO-1 fetched=2026-01-01T00:00:00Z -->\n# FOO-1: x\n", "FOO-1"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := parseDraft(tt.content)
+			if err == nil || !strings.Contains(err.Error(), tt.want) {
+				t.Errorf("err = %v, want containing %q", err, tt.want)
+			}
+		})
+	}
+}
+
+func TestRenderDraftTemplateRoundTrip(t *testing.T) {
+	content := strings.Replace(renderDraftTemplate("story", "FOO"), draftSummaryPlaceholder, "New checkout", 1)
+	d, err := parseDraft(content)
+	if err != nil {
+		t.Fatalf("parseDraft: %v", err)
+	}
+	if d.Fields["project"] != "FOO" || d.Fields["type"] != "Story" {
+		t.Errorf("Fields = %v", d.Fields)
+	}
+	if len(d.Ignored) != 0 {
+		t.Errorf("Ignored = %v, want none", d.Ignored)
+	}
+	if !strings.HasPrefix(d.Body, "## Description") || strings.Contains(d.Body, "My Notes") {
+		t.Errorf("Body = %q", d.Body)
+	}
+}
+
+func TestStampedDraft(t *testing.T) {
+	d, err := parseDraft("# Title\n\nBody\n")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got := stampedDraft("FOO-9", d); got != "# FOO-9: Title\n\nBody\n" {
+		t.Errorf("stampedDraft = %q", got)
+	}
+}
+
+func TestDraftLabels(t *testing.T) {
+	got := draftLabels("web, safari  ios")
+	if strings.Join(got, "|") != "web|safari|ios" {
+		t.Errorf("draftLabels = %v", got)
+	}
+}
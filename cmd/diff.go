@@ -81,12 +81,17 @@ func runDiff(cmd *cobra.Command, args []string) error {
 		return nil
 	}
 
-	// Generate unified diff.
+	return printUnifiedDiff(localContent, remoteContent, ticketKey+" (local)", ticketKey+" (remote)", colorFlag)
+}
+
+// printUnifiedDiff prints a unified diff of a and b, colored per the --color
+// flag value.
+func printUnifiedDiff(a, b, fromFile, toFile, colorFlag string) error {
 	diff := difflib.UnifiedDiff{
-		A:        difflib.SplitLines(localContent),
-		B:        difflib.SplitLines(remoteContent),
-		FromFile: ticketKey + " (local)",
-		ToFile:   ticketKey + " (remote)",
+		A:        difflib.SplitLines(a),
+		B:        difflib.SplitLines(b),
+		FromFile: fromFile,
+		ToFile:   toFile,
 		Context:  3,
 	}
 	text, err := difflib.GetUnifiedDiffString(diff)
@@ -0,0 +1,164 @@
+package cmd
+
+import (
+	"errors"
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/erickhilda/atlit/internal/config"
+	"github.com/erickhilda/atlit/internal/jira"
+	"github.com/erickhilda/atlit/internal/renderer"
+	"github.com/spf13/cobra"
+)
+
+// epicChildFields are the issue fields requested for an epic's children;
+// story-point field ids are appended at runtime once discovered.
+var epicChildFields = []string{"summary", "status", "assignee", "issuetype"}
+
+var epicCmd = &cobra.Command{
+	Use:   "epic <EPIC-KEY>",
+	Short: "List an epic's child issues with progress",
+	Long: `Lists the child issues of an epic (any issue whose parent it is) with type,
+status, assignee and story points, ordered To Do -> In Progress -> Done, and a
+progress line counting done issues and points. Nothing is written to disk.
+
+  atlit epic PROJ-5`,
+	Args: cobra.ExactArgs(1),
+	RunE: runEpic,
+}
+
+func init() {
+	rootCmd.AddCommand(epicCmd)
+}
+
+func runEpic(_ *cobra.Command, args []string) error {
+	key := strings.ToUpper(strings.TrimSpace(args[0]))
+
+	cfg, err := config.Load()
+	if err != nil {
+		return err
+	}
+	token, err := config.GetToken(cfg)
+	if err != nil {
+		return fmt.Errorf("retrieving token: %w", err)
+	}
+	client := jira.NewClient(cfg.Instance, cfg.Email, token)
+
+	epic, err := client.GetIssueWithFields(key, "summary,status,issuetype")
+	if err != nil {
+		return wrapIssueError(err, key)
+	}
+
+	// As in `atlit sprint`, points need the site's custom field ids; without
+	// them the view simply omits points.
+	fields := epicChildFields
+	if names, nerr := client.GetFieldNames(); nerr == nil {
+		fields = append(append([]string{}, epicChildFields...), jira.StoryPointsFieldIDs(names)...)
+	}
+
+	// Jira Cloud links epic children through "parent" in both company- and
+	// team-managed projects.
+	result, err := client.SearchIssues("parent = "+quoteJQL(key)+" ORDER BY rank ASC", fields, 0)
+	if err != nil {
+		if errors.Is(err, jira.ErrUnauthorized) {
+			return fmt.Errorf("authentication failed: %w", err)
+		}
+		return fmt.Errorf("listing children of %s: %w", key, err)
+	}
+
+	printEpic(epic, result.Issues)
+	return nil
+}
+
+// wrapIssueError maps a single-issue fetch error to a user-facing message.
+func wrapIssueError(err error, key string) error {
+	switch {
+	case errors.Is(err, jira.ErrNotFound):
+		return fmt.Errorf("ticket %s not found", key)
+	case errors.Is(err, jira.ErrUnauthorized):
+		return fmt.Errorf("authentication failed: %w", err)
+	default:
+		return fmt.Errorf("fetching %s: %w", key, err)
+	}
+}
+
+// epicProgress summarises how much of an epic is done.
+type epicProgress struct {
+	Done, Total           int
+	DonePoints, AllPoints float64
+}
+
+// computeEpicProgress counts done-category children and their points.
+func computeEpicProgress(children []jira.Issue) epicProgress {
+	var p epicProgress
+	p.Total = len(children)
+	for _, is := range children {
+		if statusCategoryRank(is.Fields.Status) == 2 {
+			p.Done++
+			if is.StoryPoints != nil {
+				p.DonePoints += *is.StoryPoints
+			}
+		}
+	}
+	p.AllPoints = sumPoints(children)
+	return p
+}
+
+// String renders "3/8 done (37%), 5/21 pts", leaving points out when no child
+// is estimated.
+func (p epicProgress) String() string {
+	pct := 0
+	if p.Total > 0 {
+		pct = p.Done * 100 / p.Total
+	}
+	s := fmt.Sprintf("%d/%d done (%d%%)", p.Done, p.Total, pct)
+	if p.AllPoints > 0 {
+		s += fmt.Sprintf(", %s/%s pts", renderer.FormatPoints(p.DonePoints), renderer.FormatPoints(p.AllPoints))
+	}
+	return s
+}
+
+// printEpic renders the epic header, its children table and the progress line.
+func printEpic(epic *jira.Issue, children []jira.Issue) {
+	status := "-"
+	if epic.Fields.Status != nil {
+		status = epic.Fields.Status.Name
+	}
+	fmt.Printf("%s: %s (%s)\n", epic.Key, epic.Fields.Summary, status)
+	if epic.Fields.IssueType != nil && !strings.EqualFold(epic.Fields.IssueType.Name, "epic") {
+		fmt.Printf("Note: %s is a %s, listing its child issues.\n", epic.Key, epic.Fields.IssueType.Name)
+	}
+
+	if len(children) == 0 {
+		fmt.Println("\nNo child issues.")
+		return
+	}
+
+	// Stable sort keeps rank order within each status category.
+	sorted := append([]jira.Issue{}, children...)
+	sort.SliceStable(sorted, func(i, j int) bool {
+		return statusCategoryRank(sorted[i].Fields.Status) < statusCategoryRank(sorted[j].Fields.Status)
+	})
+
+	fmt.Printf("\n%-16s %-10s %-16s %-16s %-6s %s\n", "KEY", "TYPE", "STATUS", "ASSIGNEE", "POINTS", "SUMMARY")
+	for _, is := range sorted {
+		typ, st, assignee, points := "-", "-", "Unassigned", "-"
+		if is.Fields.IssueType != nil {
+			typ = is.Fields.IssueType.Name
+		}
+		if is.Fields.Status != nil {
+			st = is.Fields.Status.Name
+		}
+		if is.Fields.Assignee != nil && is.Fields.Assignee.DisplayName != "" {
+			assignee = is.Fields.Assignee.DisplayName
+		}
+		if is.StoryPoints != nil {
+			points = renderer.FormatPoints(*is.StoryPoints)
+		}
+		fmt.Printf("%-16s %-10s %-16s %-16s %-6s %s\n",
+			is.Key, truncate(typ, 10), truncate(st, 16), truncate(assignee, 16), points, is.Fields.Summary)
+	}
+
+	fmt.Printf("\nProgress: %s\n", computeEpicProgress(children))
+}
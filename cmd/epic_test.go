@@ -0,0 +1,34 @@
+package cmd
+
+import (
+	"testing"
+
+	"github.com/erickhilda/atlit/internal/jira"
+)
+
+func TestEpicProgress(t *testing.T) {
+	pts := func(f float64) *float64 { return &f }
+	child := func(cat string, p *float64) jira.Issue {
+		is := jira.Issue{StoryPoints: p}
+		is.Fields.Status = &jira.Status{StatusCategory: &jira.StatusCategory{Key: cat}}
+		return is
+	}
+	children := []jira.Issue{
+		child("done", pts(3)),
+		child("done", nil),
+		child("indeterminate", pts(5)),
+		child("new", pts(2)),
+	}
+
+	p := computeEpicProgress(children)
+	if p.Done != 2 || p.Total != 4 || p.DonePoints != 3 || p.AllPoints != 10 {
+		t.Errorf("progress = %+v", p)
+	}
+	if got, want := p.String(), "2/4 done (50%), 3/10 pts"; got != want {
+		t.Errorf("String() = %q, want %q", got, want)
+	}
+
+	if got, want := computeEpicProgress([]jira.Issue{child("new", nil)}).String(), "0/1 done (0%)"; got != want {
+		t.Errorf("unestimated String() = %q, want %q", got, want)
+	}
+}
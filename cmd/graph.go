@@ -0,0 +1,300 @@
+package cmd
+
+import (
+	"fmt"
+	"os"
+	"strings"
+
+	"github.com/erickhilda/atlit/internal/config"
+	"github.com/erickhilda/atlit/internal/jira"
+	"github.com/spf13/cobra"
+)
+
+// graphIssueFields are the fields needed to expand one node of the graph.
+const graphIssueFields = "summary,status,issuetype,issuelinks,parent,subtasks"
+
+// parentEdgeLabel labels parent -> child edges (epics, subtasks).
+const parentEdgeLabel = "parent of"
+
+var graphCmd = &cobra.Command{
+	Use:   "graph <TICKET-KEY>",
+	Short: "Render a ticket's issue links as a Mermaid or Graphviz diagram",
+	Long: `Walks the issue links (blocks, relates, ...) and parent/child relations
+outward from a ticket and prints the result as a Mermaid flowchart or Graphviz
+dot graph on stdout, ready to paste into Confluence, a PR description or a
+'dot -Tsvg' pipe. Done issues are drawn greyed out.
+
+--depth is how many hops to follow from the ticket (default 1: its direct
+neighbours).
+
+  atlit graph PROJ-5
+  atlit graph PROJ-5 --depth 2 --format dot | dot -Tsvg > deps.svg`,
+	Args: cobra.ExactArgs(1),
+	RunE: runGraph,
+}
+
+func init() {
+	graphCmd.Flags().Int("depth", 1, "Number of link hops to follow from the ticket")
+	graphCmd.Flags().String("format", "mermaid", "Output format: mermaid or dot")
+	rootCmd.AddCommand(graphCmd)
+}
+
+// graphNode is one issue in the graph.
+type graphNode struct {
+	Key     string
+	Summary string
+	Status  string
+	Done    bool
+}
+
+// graphEdge is a directed relation, always stored in the outward direction
+// (e.g. "A blocks B", never "B is blocked by A").
+type graphEdge struct {
+	From, To, Label string
+}
+
+// issueGraph is the traversal result; order keeps nodes in discovery order so
+// output is stable.
+type issueGraph struct {
+	Root  string
+	Nodes map[string]*graphNode
+	order []string
+	Edges []graphEdge
+	seen  map[string]bool
+}
+
+func newIssueGraph(root string) *issueGraph {
+	return &issueGraph{Root: root, Nodes: map[string]*graphNode{}, seen: map[string]bool{}}
+}
+
+// addNode records a node, filling in details a previous sighting lacked.
+func (g *issueGraph) addNode(key, summary string, status *jira.Status) {
+	n, ok := g.Nodes[key]
+	if !ok {
+		n = &graphNode{Key: key}
+		g.Nodes[key] = n
+		g.order = append(g.order, key)
+	}
+	if n.Summary == "" {
+		n.Summary = summary
+	}
+	if status != nil && n.Status == "" {
+		n.Status = status.Name
+		n.Done = statusCategoryRank(status) == 2
+	}
+}
+
+// addEdge records an edge once. Symmetric link types ("relates to") are
+// deduplicated regardless of direction.
+func (g *issueGraph) addEdge(from, to, label string, symmetric bool) {
+	if g.seen[from+"\x00"+to+"\x00"+label] || (symmetric && g.seen[to+"\x00"+from+"\x00"+label]) {
+		return
+	}
+	g.seen[from+"\x00"+to+"\x00"+label] = true
+	g.Edges = append(g.Edges, graphEdge{From: from, To: to, Label: label})
+}
+
+// graphFetcher loads what the traversal needs from Jira: an issue with its
+// links, and the children of an epic (which are not listed on the epic).
+type graphFetcher struct {
+	issue    func(key string) (*jira.Issue, error)
+	children func(key string) ([]jira.Issue, error)
+}
+
+// buildIssueGraph walks breadth-first from root, drawing nodes up to depth
+// hops away. Nodes at the last hop are drawn but not expanded. A neighbour that cannot be
+// fetched is kept as a leaf and reported in warnings; only a failure to load
+// the root is fatal.
+func buildIssueGraph(root string, depth int, f graphFetcher) (*issueGraph, []string, error) {
+	g := newIssueGraph(root)
+	var warnings []string
+	expanded := map[string]bool{}
+	frontier := []string{root}
+
+	for hop := 0; hop < depth && len(frontier) > 0; hop++ {
+		var next []string
+		for _, key := range frontier {
+			if expanded[key] {
+				continue
+			}
+			expanded[key] = true
+
+			is, err := f.issue(key)
+			if err != nil {
+				if key == root {
+					return nil, nil, err
+				}
+				warnings = append(warnings, fmt.Sprintf("%s: %v", key, err))
+				continue
+			}
+			g.addNode(is.Key, is.Fields.Summary, is.Fields.Status)
+
+			neighbours := graphNeighbours(g, is)
+			if is.Fields.IssueType != nil && strings.EqualFold(is.Fields.IssueType.Name, "epic") && f.children != nil {
+				kids, cerr := f.children(is.Key)
+				if cerr != nil {
+					warnings = append(warnings, fmt.Sprintf("%s children: %v", is.Key, cerr))
+				}
+				for _, c := range kids {
+					g.addNode(c.Key, c.Fields.Summary, c.Fields.Status)
+					g.addEdge(is.Key, c.Key, parentEdgeLabel, false)
+					neighbours = append(neighbours, c.Key)
+				}
+			}
+
+			next = append(next, neighbours...)
+		}
+		frontier = next
+	}
+	return g, warnings, nil
+}
+
+// graphNeighbours adds is's links, parent and subtasks to g and returns the
+// neighbouring keys.
+func graphNeighbours(g *issueGraph, is *jira.Issue) []string {
+	var keys []string
+	for _, link := range is.Fields.IssueLinks {
+		if link.Type == nil {
+			continue
+		}
+		symmetric := link.Type.Inward == link.Type.Outward
+		if o := link.OutwardIssue; o != nil {
+			g.addNode(o.Key, o.Fields.Summary, o.Fields.Status)
+			g.addEdge(is.Key, o.Key, link.Type.Outward, symmetric)
+			keys = append(keys, o.Key)
+		}
+		if in := link.InwardIssue; in != nil {
+			// "is blocked by X" on this issue means "X blocks this issue".
+			g.addNode(in.Key, in.Fields.Summary, in.Fields.Status)
+			g.addEdge(in.Key, is.Key, link.Type.Outward, symmetric)
+			keys = append(keys, in.Key)
+		}
+	}
+	if p := is.Fields.Parent; p != nil {
+		g.addNode(p.Key, p.Fields.Summary, p.Fields.Status)
+		g.addEdge(p.Key, is.Key, parentEdgeLabel, false)
+		keys = append(keys, p.Key)
+	}
+	for _, st := range is.Fields.Subtasks {
+		g.addNode(st.Key, st.Fields.Summary, st.Fields.Status)
+		g.addEdge(is.Key, st.Key, parentEdgeLabel, false)
+		keys = append(keys, st.Key)
+	}
+	return keys
+}
+
+func runGraph(cmd *cobra.Command, args []string) error {
+	key := strings.ToUpper(strings.TrimSpace(args[0]))
+	depth, _ := cmd.Flags().GetInt("depth")
+	format, _ := cmd.Flags().GetString("format")
+
+	if depth < 1 {
+		return fmt.Errorf("invalid --depth %d: must be at least 1", depth)
+	}
+	if format != "mermaid" && format != "dot" {
+		return fmt.Errorf("invalid --format %q: use mermaid or dot", format)
+	}
+
+	cfg, err := config.Load()
+	if err != nil {
+		return err
+	}
+	token, err := config.GetToken(cfg)
+	if err != nil {
+		return fmt.Errorf("retrieving token: %w", err)
+	}
+	client := jira.NewClient(cfg.Instance, cfg.Email, token)
+
+	g, warnings, err := buildIssueGraph(key, depth, graphFetcher{
+		issue: func(k string) (*jira.Issue, error) {
+			return client.GetIssueWithFields(k, graphIssueFields)
+		},
+		children: func(k string) ([]jira.Issue, error) {
+			res, err := client.SearchIssues("parent = "+quoteJQL(k)+" ORDER BY rank ASC", []string{"summary", "status"}, 0)
+			if err != nil {
+				return nil, err
+			}
+			return res.Issues, nil
+		},
+	})
+	if err != nil {
+		return wrapIssueError(err, key)
+	}
+	for _, w := range warnings {
+		fmt.Fprintf(os.Stderr, "warning: %s\n", w)
+	}
+
+	if format == "dot" {
+		fmt.Print(renderDot(g))
+	} else {
+		fmt.Print(renderMermaid(g))
+	}
+	return nil
+}
+
+// nodeLabel is the text drawn inside a node: key, summary and status. Text is
+// escaped with esc; sep (a format-specific line break) is inserted as is.
+func nodeLabel(n *graphNode, sep string, esc *strings.Replacer) string {
+	label := n.Key
+	if n.Summary != "" {
+		label += ": " + truncate(n.Summary, 40)
+	}
+	label = esc.Replace(label)
+	if n.Status != "" {
+		label += sep + esc.Replace("("+n.Status+")")
+	}
+	return label
+}
+
+// mermaidID turns an issue key into a Mermaid-safe node id.
+func mermaidID(key string) string {
+	return strings.NewReplacer("-", "_", " ", "_").Replace(key)
+}
+
+// renderMermaid renders g as a left-to-right Mermaid flowchart.
+func renderMermaid(g *issueGraph) string {
+	esc := strings.NewReplacer(`"`, "#quot;")
+	var b strings.Builder
+	b.WriteString("flowchart LR\n")
+	for _, key := range g.order {
+		n := g.Nodes[key]
+		fmt.Fprintf(&b, "  %s[\"%s\"]\n", mermaidID(key), nodeLabel(n, "<br/>", esc))
+	}
+	for _, e := range g.Edges {
+		fmt.Fprintf(&b, "  %s -->|%s| %s\n", mermaidID(e.From), esc.Replace(e.Label), mermaidID(e.To))
+	}
+	b.WriteString("  classDef done fill:#eeeeee,color:#777777\n")
+	for _, key := range g.order {
+		if g.Nodes[key].Done {
+			fmt.Fprintf(&b, "  class %s done\n", mermaidID(key))
+		}
+	}
+	fmt.Fprintf(&b, "  style %s stroke-width:3px\n", mermaidID(g.Root))
+	return b.String()
+}
+
+// renderDot renders g as a Graphviz digraph.
+func renderDot(g *issueGraph) string {
+	esc := strings.NewReplacer(`\`, `\\`, `"`, `\"`)
+	var b strings.Builder
+	fmt.Fprintf(&b, "digraph \"%s\" {\n", esc.Replace(g.Root))
+	b.WriteString("  rankdir=LR;\n")
+	b.WriteString("  node [shape=box];\n")
+	for _, key := range g.order {
+		n := g.Nodes[key]
+		var attrs []string
+		attrs = append(attrs, fmt.Sprintf("label=\"%s\"", nodeLabel(n, `\n`, esc)))
+		if key == g.Root {
+			attrs = append(attrs, "penwidth=3")
+		}
+		if n.Done {
+			attrs = append(attrs, `style=filled`, `fillcolor="#eeeeee"`, `fontcolor="#777777"`)
+		}
+		fmt.Fprintf(&b, "  \"%s\" [%s];\n", esc.Replace(key), strings.Join(attrs, ", "))
+	}
+	for _, e := range g.Edges {
+		fmt.Fprintf(&b, "  \"%s\" -> \"%s\" [label=\"%s\"];\n", esc.Replace(e.From), esc.Replace(e.To), esc.Replace(e.Label))
+	}
+	b.WriteString("}\n")
+	return b.String()
+}
@@ -0,0 +1,179 @@
+package cmd
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/erickhilda/atlit/internal/jira"
+)
+
+var blocksType = &jira.IssueLinkType{Name: "Blocks", Inward: "is blocked by", Outward: "blocks"}
+var relatesType = &jira.IssueLinkType{Name: "Relates", Inward: "relates to", Outward: "relates to"}
+
+func linked(key string, done bool) *jira.LinkedIssue {
+	cat := "new"
+	if done {
+		cat = "done"
+	}
+	li := &jira.LinkedIssue{Key: key}
+	li.Fields.Summary = "Summary " + key
+	li.Fields.Status = &jira.Status{Name: "S", StatusCategory: &jira.StatusCategory{Key: cat}}
+	return li
+}
+
+// fakeGraph returns a fetcher over a fixed set of issues:
+//
+//	A blocks B, C blocks A (seen on A as inward), A relates to D,
+//	B relates to A (the same symmetric link seen from B), B blocks E,
+//	EPIC is A's parent and also has child F.
+func fakeGraph() (graphFetcher, map[string]int) {
+	calls := map[string]int{}
+	issues := map[string]*jira.Issue{}
+	mk := func(key, typ string) *jira.Issue {
+		is := &jira.Issue{Key: key}
+		is.Fields.Summary = "Summary " + key
+		is.Fields.IssueType = &jira.IssueType{Name: typ}
+		issues[key] = is
+		return is
+	}
+	a := mk("A-1", "Story")
+	a.Fields.IssueLinks = []jira.IssueLink{
+		{Type: blocksType, OutwardIssue: linked("B-1", false)},
+		{Type: blocksType, InwardIssue: linked("C-1", true)},
+		{Type: relatesType, OutwardIssue: linked("D-1", false)},
+	}
+	a.Fields.Parent = &jira.ParentIssue{Key: "EPIC-1"}
+	b := mk("B-1", "Task")
+	b.Fields.IssueLinks = []jira.IssueLink{
+		{Type: relatesType, OutwardIssue: linked("D-1", false)},
+		{Type: blocksType, InwardIssue: linked("A-1", false)},
+		{Type: blocksType, OutwardIssue: linked("E-1", false)},
+	}
+	mk("C-1", "Bug")
+	mk("D-1", "Task").Fields.IssueLinks = []jira.IssueLink{{Type: relatesType, OutwardIssue: linked("A-1", false)}}
+	mk("EPIC-1", "Epic")
+
+	return graphFetcher{
+		issue: func(key string) (*jira.Issue, error) {
+			calls[key]++
+			is, ok := issues[key]
+			if !ok {
+				return nil, jira.ErrNotFound
+			}
+			return is, nil
+		},
+		children: func(key string) ([]jira.Issue, error) {
+			f := jira.Issue{Key: "F-1"}
+			f.Fields.Summary = "Summary F-1"
+			return []jira.Issue{*issues["A-1"], f}, nil
+		},
+	}, calls
+}
+
+func edgeList(g *issueGraph) string {
+	var out []string
+	for _, e := range g.Edges {
+		out = append(out, e.From+" "+e.Label+" "+e.To)
+	}
+	return strings.Join(out, "; ")
+}
+
+func TestBuildIssueGraphDepth1(t *testing.T) {
+	f, calls := fakeGraph()
+	g, warnings, err := buildIssueGraph("A-1", 1, f)
+	if err != nil {
+		t.Fatalf("buildIssueGraph: %v", err)
+	}
+	if len(warnings) != 0 {
+		t.Errorf("warnings = %v", warnings)
+	}
+	want := "A-1 blocks B-1; C-1 blocks A-1; A-1 relates to D-1; EPIC-1 parent of A-1"
+	if got := edgeList(g); got != want {
+		t.Errorf("edges = %q, want %q", got, want)
+	}
+	if len(calls) != 1 {
+		t.Errorf("fetched %v, want only the root", calls)
+	}
+	if !g.Nodes["C-1"].Done || g.Nodes["B-1"].Done {
+		t.Error("done flags not taken from linked status categories")
+	}
+}
+
+func TestBuildIssueGraphDepth2(t *testing.T) {
+	f, calls := fakeGraph()
+	g, warnings, err := buildIssueGraph("A-1", 2, f)
+	if err != nil {
+		t.Fatalf("buildIssueGraph: %v", err)
+	}
+	got := edgeList(g)
+	for _, want := range []string{"B-1 blocks E-1", "B-1 relates to D-1", "EPIC-1 parent of F-1"} {
+		if !strings.Contains(got, want) {
+			t.Errorf("edges missing %q: %s", want, got)
+		}
+	}
+	// The symmetric A/D link and the A->B link seen from both ends appear once.
+	for _, e := range []string{"relates to A-1", "A-1 blocks B-1"} {
+		if c := strings.Count(got, e); c > 1 {
+			t.Errorf("%q drawn %d times: %s", e, c, got)
+		}
+	}
+	if strings.Contains(got, "D-1 relates to A-1") {
+		t.Errorf("symmetric link duplicated in reverse: %s", got)
+	}
+	if calls["E-1"] != 0 {
+		t.Error("nodes at the last hop should not be expanded")
+	}
+	if calls["A-1"] != 1 {
+		t.Errorf("A-1 fetched %d times, want 1", calls["A-1"])
+	}
+	// E-1 was never fetched, so no warning; nothing else was missing.
+	if len(warnings) != 0 {
+		t.Errorf("warnings = %v", warnings)
+	}
+}
+
+func TestBuildIssueGraphRootError(t *testing.T) {
+	f, _ := fakeGraph()
+	if _, _, err := buildIssueGraph("NOPE-1", 1, f); err != jira.ErrNotFound {
+		t.Errorf("err = %v, want ErrNotFound", err)
+	}
+}
+
+func TestRenderMermaid(t *testing.T) {
+	g := newIssueGraph("A-1")
+	g.addNode("A-1", `Say "hi"`, &jira.Status{Name: "To Do"})
+	g.addNode("B-1", "", &jira.Status{Name: "Done", StatusCategory: &jira.StatusCategory{Key: "done"}})
+	g.addEdge("A-1", "B-1", "blocks", false)
+
+	got := renderMermaid(g)
+	for _, want := range []string{
+		"flowchart LR\n",
+		`  A_1["A-1: Say #quot;hi#quot;<br/>(To Do)"]`,
+		"  A_1 -->|blocks| B_1\n",
+		"  class B_1 done\n",
+		"  style A_1 stroke-width:3px\n",
+	} {
+		if !strings.Contains(got, want) {
+			t.Errorf("mermaid missing %q:\n%s", want, got)
+		}
+	}
+}
+
+func TestRenderDot(t *testing.T) {
+	g := newIssueGraph("A-1")
+	g.addNode("A-1", `Say "hi"`, &jira.Status{Name: "To Do"})
+	g.addNode("B-1", "", nil)
+	g.addEdge("A-1", "B-1", "blocks", false)
+
+	got := renderDot(g)
+	for _, want := range []string{
+		`digraph "A-1" {`,
+		`  "A-1" [label="A-1: Say \"hi\"\n(To Do)", penwidth=3];`,
+		`  "B-1" [label="B-1"];`,
+		`  "A-1" -> "B-1" [label="blocks"];`,
+	} {
+		if !strings.Contains(got, want) {
+			t.Errorf("dot missing %q:\n%s", want, got)
+		}
+	}
+}
@@ -0,0 +1,125 @@
+package cmd
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/erickhilda/atlit/internal/config"
+	"github.com/erickhilda/atlit/internal/jira"
+	"github.com/spf13/cobra"
+)
+
+// minWorklogSeconds is the smallest entry Jira accepts.
+const minWorklogSeconds = 60
+
+var logCmd = &cobra.Command{
+	Use:   "log <TICKET-KEY> <DURATION>",
+	Short: "Log time worked on a Jira ticket",
+	Long: `Adds a worklog entry to a Jira ticket. DURATION uses hours and minutes,
+e.g. 1h30m, "1h 30m", 45m or 1.5h (at least 1m). The work is recorded as ending
+now unless --started says when it began.
+
+  atlit log PROJ-1 1h30m -m "pairing on the importer"
+  atlit log PROJ-1 45m --started "2026-03-02 14:00"
+
+See also 'atlit timer' to measure the duration for you.`,
+	Args: cobra.ExactArgs(2),
+	RunE: runLog,
+}
+
+func init() {
+	logCmd.Flags().StringP("message", "m", "", "Worklog comment")
+	logCmd.Flags().String("started", "", `When the work began: "2006-01-02 15:04" (local time) or RFC 3339 (default: now minus DURATION)`)
+	rootCmd.AddCommand(logCmd)
+}
+
+func runLog(cmd *cobra.Command, args []string) error {
+	key := strings.ToUpper(strings.TrimSpace(args[0]))
+	message, _ := cmd.Flags().GetString("message")
+	startedFlag, _ := cmd.Flags().GetString("started")
+
+	dur, err := parseWorkDuration(args[1])
+	if err != nil {
+		return err
+	}
+	started := time.Now().Add(-dur)
+	if s := strings.TrimSpace(startedFlag); s != "" {
+		if started, err = parseStarted(s); err != nil {
+			return err
+		}
+	}
+
+	cfg, err := config.Load()
+	if err != nil {
+		return err
+	}
+	return logWork(cfg, key, started, dur, strings.TrimSpace(message))
+}
+
+// logWork posts a worklog entry and reports it.
+func logWork(cfg *config.Config, key string, started time.Time, dur time.Duration, message string) error {
+	token, err := config.GetToken(cfg)
+	if err != nil {
+		return fmt.Errorf("retrieving token: %w", err)
+	}
+	client := jira.NewClient(cfg.Instance, cfg.Email, token)
+
+	if _, err := client.AddWorklog(key, started, int(dur/time.Second), message); err != nil {
+		if errors.Is(err, jira.ErrNotFound) {
+			return fmt.Errorf("ticket %s not found", key)
+		}
+		if errors.Is(err, jira.ErrUnauthorized) {
+			return fmt.Errorf("authentication failed: %w", err)
+		}
+		return fmt.Errorf("logging work: %w", err)
+	}
+	fmt.Printf("Logged %s on %s. Run 'atlit pull %s' to refresh the local Work Log.\n", formatWorkDuration(dur), key, key)
+	return nil
+}
+
+// parseWorkDuration parses an hours/minutes duration such as "1h30m",
+// "1h 30m", "45m" or "1.5h". Units other than h and m are rejected so that a
+// stray "1d" is not silently read with the wrong day length. The result must
+// be at least one minute and is truncated to whole minutes.
+func parseWorkDuration(s string) (time.Duration, error) {
+	compact := strings.ToLower(strings.Join(strings.Fields(s), ""))
+	if compact == "" || strings.ContainsAny(compact, "dwsµnu") || strings.HasPrefix(compact, "-") {
+		return 0, fmt.Errorf("invalid duration %q: use hours and minutes, e.g. 1h30m or 45m", s)
+	}
+	d, err := time.ParseDuration(compact)
+	if err != nil {
+		return 0, fmt.Errorf("invalid duration %q: use hours and minutes, e.g. 1h30m or 45m", s)
+	}
+	d = d.Truncate(time.Minute)
+	if d < minWorklogSeconds*time.Second {
+		return 0, fmt.Errorf("duration %q is too short: Jira needs at least 1m", s)
+	}
+	return d, nil
+}
+
+// formatWorkDuration renders d in Jira's style: "1h 30m", "2h", "45m".
+func formatWorkDuration(d time.Duration) string {
+	d = d.Truncate(time.Minute)
+	h, m := int(d/time.Hour), int(d%time.Hour/time.Minute)
+	switch {
+	case h > 0 && m > 0:
+		return fmt.Sprintf("%dh %dm", h, m)
+	case h > 0:
+		return fmt.Sprintf("%dh", h)
+	default:
+		return fmt.Sprintf("%dm", m)
+	}
+}
+
+// parseStarted accepts "2006-01-02 15:04" in local time or RFC 3339.
+func parseStarted(s string) (time.Time, error) {
+	if t, err := time.ParseInLocation("2006-01-02 15:04", s, time.Local); err == nil {
+		return t, nil
+	}
+	if t, err := time.Parse(time.RFC3339, s); err == nil {
+		return t, nil
+	}
+	return time.Time{}, fmt.Errorf(`invalid --started %q: use "2006-01-02 15:04" or RFC 3339`, s)
+}
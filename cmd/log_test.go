@@ -0,0 +1,85 @@
+package cmd
+
+import (
+	"testing"
+	"time"
+)
+
+func TestParseWorkDuration(t *testing.T) {
+	tests := []struct {
+		in      string
+		want    time.Duration
+		wantErr bool
+	}{
+		{"1h30m", 90 * time.Minute, false},
+		{"1h 30m", 90 * time.Minute, false},
+		{"45m", 45 * time.Minute, false},
+		{"1.5h", 90 * time.Minute, false},
+		{"2H", 2 * time.Hour, false},
+		{"90m30s", 0, true},
+		{"1d", 0, true},
+		{"30s", 0, true},
+		{"0m", 0, true},
+		{"-1h", 0, true},
+		{"soon", 0, true},
+		{"", 0, true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.in, func(t *testing.T) {
+			got, err := parseWorkDuration(tt.in)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("err = %v, wantErr %v", err, tt.wantErr)
+			}
+			if got != tt.want {
+				t.Errorf("got %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestFormatWorkDuration(t *testing.T) {
+	tests := map[time.Duration]string{
+		90 * time.Minute:                "1h 30m",
+		2 * time.Hour:                   "2h",
+		45*time.Minute + 20*time.Second: "45m",
+		0:                               "0m",
+	}
+	for d, want := range tests {
+		if got := formatWorkDuration(d); got != want {
+			t.Errorf("formatWorkDuration(%v) = %q, want %q", d, got, want)
+		}
+	}
+}
+
+func TestParseStarted(t *testing.T) {
+	got, err := parseStarted("2026-03-02 14:00")
+	if err != nil {
+		t.Fatalf("parseStarted: %v", err)
+	}
+	if want := time.Date(2026, 3, 2, 14, 0, 0, 0, time.Local); !got.Equal(want) {
+		t.Errorf("got %v, want %v", got, want)
+	}
+	if _, err := parseStarted("2026-03-02T14:00:00+07:00"); err != nil {
+		t.Errorf("RFC 3339: %v", err)
+	}
+	if _, err := parseStarted("yesterday"); err == nil {
+		t.Error("expected error")
+	}
+}
+
+func TestTimerElapsed(t *testing.T) {
+	start := time.Date(2026, 1, 1, 9, 0, 0, 0, time.UTC)
+	tests := []struct {
+		after time.Duration
+		want  time.Duration
+	}{
+		{10 * time.Second, time.Minute},
+		{30 * time.Minute, 30 * time.Minute},
+		{30*time.Minute + time.Second, 31 * time.Minute},
+	}
+	for _, tt := range tests {
+		if got := timerElapsed(start, start.Add(tt.after)); got != tt.want {
+			t.Errorf("timerElapsed(+%v) = %v, want %v", tt.after, got, tt.want)
+		}
+	}
+}
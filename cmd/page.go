@@ -9,6 +9,7 @@ import (
 
 	"github.com/erickhilda/atlit/internal/config"
 	"github.com/erickhilda/atlit/internal/confluence"
+	"github.com/erickhilda/atlit/internal/jira"
 	"github.com/erickhilda/atlit/internal/renderer"
 	"github.com/erickhilda/atlit/internal/store"
 	"github.com/spf13/cobra"
@@ -18,6 +19,9 @@ import (
 // both view (/pages/<id>/<slug>) and edit (/pages/edit-v2/<id>) forms.
 var pageURLRe = regexp.MustCompile(`pages/(?:edit-v2/)?(\d+)`)
 
+// pageCommentsRe matches the heading of a saved page's Comments section.
+var pageCommentsRe = regexp.MustCompile(`^## Comments \(\d+\)$`)
+
 // spaceKeyRe extracts the space key from a webui link (/spaces/<KEY>/...).
 var spaceKeyRe = regexp.MustCompile(`/spaces/([^/]+)`)
 
@@ -31,6 +35,12 @@ Reference forms:
   atlit page 12345                                                     numeric page ID
   atlit page https://acme.atlassian.net/wiki/spaces/ENG/pages/12345/Title   full page URL
 
+With --recursive the page's children (down to --depth levels) are saved too,
+each in a directory named after its parent's file, with an index.md listing
+the hierarchy:
+
+  atlit page 12345 --recursive --depth 2
+
 Uses your existing Jira API token (same Atlassian account) -- no separate auth is
 needed as long as the token has Confluence access (unscoped API tokens do).`,
 	Args: cobra.ExactArgs(1),
@@ -39,11 +49,21 @@ needed as long as the token has Confluence access (unscoped API tokens do).`,
 
 func init() {
 	pageCmd.Flags().Bool("dry-run", false, "Show what would change without saving")
+	pageCmd.Flags().Bool("recursive", false, "Also save the page's descendants into a directory tree with an index.md")
+	pageCmd.Flags().Int("depth", 0, "With --recursive, how many levels of children to save (0 = all)")
 	rootCmd.AddCommand(pageCmd)
 }
 
 func runPage(cmd *cobra.Command, args []string) error {
 	dryRun, _ := cmd.Flags().GetBool("dry-run")
+	recursive, _ := cmd.Flags().GetBool("recursive")
+	depth, _ := cmd.Flags().GetInt("depth")
+	if depth < 0 {
+		return fmt.Errorf("invalid --depth %d", depth)
+	}
+	if depth > 0 && !recursive {
+		return errors.New("--depth needs --recursive")
+	}
 
 	cfg, err := config.Load()
 	if err != nil {
@@ -60,45 +80,199 @@ func runPage(cmd *cobra.Command, args []string) error {
 		return fmt.Errorf("retrieving token: %w", err)
 	}
 
-	client := confluence.NewClient(cfg.Instance, cfg.Email, token)
-	page, err := client.GetPage(id)
+	fetcher := newPageFetcher(cfg, token)
+	doc, err := fetcher.fetch(id)
 	if err != nil {
-		return wrapConfluenceError(err, id)
+		return err
+	}
+
+	pagesDir := cfg.PagesDirOrDefault()
+	if recursive {
+		return savePageTree(fetcher, doc, pagesDir, depth, dryRun)
 	}
 
-	spaceKey := spaceKeyFromWebUI(page.Links.WebUI)
-	if spaceKey == "" {
-		spaceKey = page.SpaceID
+	if dryRun {
+		return showDryRunDir(pagesDir, doc.Key, withPageNotes(pagesDir, doc))
+	}
+	path, err := savePageDoc(pagesDir, doc)
+	if err != nil {
+		return err
+	}
+	fmt.Printf("Saved Confluence page %s to %s\n", doc.Page.ID, path)
+	return nil
+}
+
+// pageDoc is a fetched Confluence page rendered to markdown, with the file
+// key it is saved under. CommentsFetched is false when comments were skipped
+// (fetch_comments off) or could not be fetched.
+type pageDoc struct {
+	Page            *confluence.Page
+	SpaceKey        string
+	Key             string
+	Content         string
+	CommentsFetched bool
+}
+
+// pageFetcher fetches pages and renders them with what the page object leaves
+// as ids: the space, ancestors and account names. Spaces and names are cached,
+// so a tree or search pull looks each one up once.
+type pageFetcher struct {
+	client *confluence.Client
+	cfg    *config.Config
+	names  *jira.UserCache
+	spaces map[string]*confluence.Space // by space id; nil when the lookup failed
+}
+
+// newPageFetcher builds the Confluence client and the Jira-backed name
+// resolver (account ids are shared across the site) from one token.
+func newPageFetcher(cfg *config.Config, token string) *pageFetcher {
+	return &pageFetcher{
+		client: confluence.NewClient(cfg.Instance, cfg.Email, token),
+		cfg:    cfg,
+		names:  jira.NewUserCache(jira.NewClient(cfg.Instance, cfg.Email, token)),
+		spaces: map[string]*confluence.Space{},
+	}
+}
+
+// loadPageFetcher loads the config and token and builds a pageFetcher.
+func loadPageFetcher() (*pageFetcher, error) {
+	cfg, err := config.Load()
+	if err != nil {
+		return nil, err
+	}
+	token, err := config.GetToken(cfg)
+	if err != nil {
+		return nil, fmt.Errorf("retrieving token: %w", err)
+	}
+	return newPageFetcher(cfg, token), nil
+}
+
+// fetch fetches a page with its attachments, comments and ancestors and
+// renders it.
+func (f *pageFetcher) fetch(id string) (*pageDoc, error) {
+	page, err := f.client.GetPage(id)
+	if err != nil {
+		return nil, wrapConfluenceError(err, id)
+	}
+
+	ctx := renderer.PageContext{WebURL: pageWebURL(f.cfg.Instance, page), Names: f.names}
+	if space := f.space(page.SpaceID); space != nil {
+		ctx.SpaceKey, ctx.SpaceName = space.Key, space.Name
+	}
+	if ctx.SpaceKey == "" {
+		ctx.SpaceKey = firstNonEmpty(spaceKeyFromWebUI(page.Links.WebUI), page.SpaceID)
 	}
-	webURL := pageWebURL(cfg.Instance, page)
 
 	// Attachments are secondary: a failure here should not block saving the page.
-	attachments, aerr := client.GetPageAttachments(id)
-	if aerr != nil {
-		fmt.Fprintf(os.Stderr, "warning: could not fetch attachments for page %s: %v\n", id, aerr)
+	if ctx.Attachments, err = f.client.GetPageAttachments(id); err != nil {
+		fmt.Fprintf(os.Stderr, "warning: could not fetch attachments for page %s: %v\n", id, err)
+	}
+	if ctx.Ancestors, err = f.client.GetPageAncestors(id); err != nil {
+		fmt.Fprintf(os.Stderr, "warning: could not fetch ancestors of page %s: %v\n", id, err)
 	}
 
-	content := renderer.RenderPage(page, spaceKey, webURL, attachments)
+	// Comments are secondary too, and skipped entirely with fetch_comments off.
+	// Without them, the saved copy keeps its previous Comments section.
+	commentsFetched := false
+	if f.cfg.ShouldFetchComments() {
+		commentsFetched = true
+		if page.FooterComments, err = f.client.GetFooterComments(id); err != nil {
+			fmt.Fprintf(os.Stderr, "warning: could not fetch comments for page %s: %v\n", id, err)
+			commentsFetched = false
+		}
+		if page.InlineComments, err = f.client.GetInlineComments(id); err != nil {
+			fmt.Fprintf(os.Stderr, "warning: could not fetch inline comments for page %s: %v\n", id, err)
+			commentsFetched = false
+		}
+		if !commentsFetched {
+			page.FooterComments, page.InlineComments = nil, nil
+		}
+	}
 
-	pagesDir := cfg.PagesDirOrDefault()
-	key := pageFileKey(spaceKey, page.ID, page.Title)
+	return &pageDoc{
+		Page:            page,
+		SpaceKey:        ctx.SpaceKey,
+		Key:             pageFileKey(ctx.SpaceKey, page.ID, page.Title),
+		Content:         renderer.RenderPage(page, ctx),
+		CommentsFetched: commentsFetched,
+	}, nil
+}
+
+// space looks a space up by id once; nil when it cannot be (the caller then
+// falls back to the key in the page's web link).
+func (f *pageFetcher) space(id string) *confluence.Space {
+	if id == "" {
+		return nil
+	}
+	if space, ok := f.spaces[id]; ok {
+		return space
+	}
+	space, err := f.client.GetSpace(id)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "warning: could not look up space %s: %v\n", id, err)
+		space = nil
+	}
+	f.spaces[id] = space
+	return space
+}
 
-	// Preserve a hand-added "## My Notes" section across re-pulls.
-	if existing, lerr := store.Load(pagesDir, key); lerr == nil {
-		content = preserveNotes(existing, content)
+// withPageNotes returns the page content carrying over a hand-added
+// "## My Notes" section (and the Comments, when they were not fetched) from
+// the copy already saved in dir.
+func withPageNotes(dir string, doc *pageDoc) string {
+	if existing, err := store.Load(dir, doc.Key); err == nil {
+		return preservePageSections(existing, doc)
 	}
+	return doc.Content
+}
 
-	if dryRun {
-		return showDryRunDir(pagesDir, key, content)
+// preservePageSections is preserveSections for pages: it keeps My Notes from
+// oldContent, and its Comments section when doc was fetched without comments.
+func preservePageSections(oldContent string, doc *pageDoc) string {
+	content := doc.Content
+	if !doc.CommentsFetched {
+		if comments := pageCommentsSection(oldContent); comments != "" {
+			content = strings.TrimRight(content, "\n") + "\n\n" + comments
+		}
 	}
+	return preserveNotes(oldContent, content)
+}
 
-	if err := store.Save(pagesDir, key, content); err != nil {
-		return fmt.Errorf("saving page: %w", err)
+// pageCommentsSection returns the "## Comments (N)" section of a saved page,
+// which runs up to My Notes (comment bodies may have "## " headings of their
+// own), or "" when there is none.
+func pageCommentsSection(content string) string {
+	lines := strings.Split(content, "\n")
+	start, end := -1, len(lines)
+	inFence := false
+	for i, line := range lines {
+		line = strings.TrimRight(line, " ")
+		if strings.HasPrefix(line, "```") {
+			inFence = !inFence
+		}
+		if inFence {
+			continue
+		}
+		if start < 0 && pageCommentsRe.MatchString(line) {
+			start = i
+		} else if start >= 0 && line == "## My Notes" {
+			end = i
+			break
+		}
+	}
+	if start < 0 {
+		return ""
 	}
+	return strings.TrimRight(strings.Join(lines[start:end], "\n"), "\n") + "\n"
+}
 
-	path, _ := store.TicketPath(pagesDir, key)
-	fmt.Printf("Saved Confluence page %s to %s\n", page.ID, path)
-	return nil
+// savePageDoc saves a page as <dir>/<key>.md, keeping My Notes, and returns
+// the file path.
+func savePageDoc(dir string, doc *pageDoc) (string, error) {
+	if err := store.Save(dir, doc.Key, withPageNotes(dir, doc)); err != nil {
+		return "", fmt.Errorf("saving page: %w", err)
+	}
+	return store.TicketPath(dir, doc.Key)
 }
 
 // resolvePageRef parses a page reference (numeric id or page URL) into a page id.
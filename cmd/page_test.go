@@ -1,6 +1,9 @@
 package cmd
 
-import "testing"
+import (
+	"strings"
+	"testing"
+)
 
 func TestResolvePageRef(t *testing.T) {
 	cases := []struct {
@@ -81,3 +84,26 @@ func TestSlugify(t *testing.T) {
 		}
 	}
 }
+
+func TestPreservePageSections(t *testing.T) {
+	old := "# Doc\n\n## Content\n\nOld body.\n\n## Comments (1)\n\n### Alice -- 2026-06-15\n\n## Heading in a comment\n\nLooks good\n\n" +
+		"## My Notes\n\nmine\n"
+	fresh := "# Doc\n\n## Content\n\nNew body.\n"
+
+	// Comments not fetched: the saved ones are kept, before My Notes.
+	got := preservePageSections(old, &pageDoc{Content: fresh})
+	want := fresh + "\n## Comments (1)\n\n### Alice -- 2026-06-15\n\n## Heading in a comment\n\nLooks good\n\n## My Notes\n\nmine\n"
+	if got != want {
+		t.Errorf("not fetched:\n%q\nwant\n%q", got, want)
+	}
+
+	// Fetched (here: none left): the fresh render wins.
+	got = preservePageSections(old, &pageDoc{Content: fresh, CommentsFetched: true})
+	if strings.Contains(got, "Looks good") || !strings.Contains(got, "## My Notes\n\nmine") {
+		t.Errorf("fetched:\n%s", got)
+	}
+
+	if got := pageCommentsSection("## Content\n\n```md\n## Comments (2)\n```\n"); got != "" {
+		t.Errorf("comments heading in a fence: %q", got)
+	}
+}
@@ -0,0 +1,337 @@
+package cmd
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"regexp"
+	"strings"
+	"time"
+
+	"github.com/erickhilda/atlit/internal/config"
+	"github.com/erickhilda/atlit/internal/confluence"
+	"github.com/erickhilda/atlit/internal/jira"
+	"github.com/erickhilda/atlit/internal/renderer"
+	"github.com/erickhilda/atlit/internal/store"
+	"github.com/spf13/cobra"
+)
+
+// standaloneImageRe matches a line holding nothing but a markdown image.
+var standaloneImageRe = regexp.MustCompile(`^!\[([^\]]*)\]\(([^)\s]+)\)$`)
+
+var pageCreateCmd = &cobra.Command{
+	Use:   "create <file.md>",
+	Short: "Publish a local markdown file as a new Confluence page",
+	Long: `Creates a Confluence page from a markdown file: the first "# " heading is the
+title and the rest is the body, converted to Atlassian Document Format. Local
+images on a line of their own (![diagram](img/flow.png), relative to the file)
+are uploaded as attachments and embedded where they appear. A "## My Notes"
+section stays local.
+
+Once published, the file is moved to pages_dir in the standard saved-page
+format (meta line, metadata table, Content), keeping its My Notes, so it can
+be edited and sent back with 'atlit page push'. If attaching the images fails,
+the page is still saved there and the draft is kept, marked as published.
+
+  atlit page create --space ENG design.md
+  atlit page create --space ENG --parent 12345 design.md
+  atlit page create --space ENG design.md --dry-run`,
+	Args: cobra.ExactArgs(1),
+	RunE: runPageCreate,
+}
+
+func init() {
+	pageCreateCmd.Flags().String("space", "", "Key of the space to create the page in (required)")
+	pageCreateCmd.Flags().String("parent", "", "Parent page (id or URL); default: the space's top level")
+	pageCreateCmd.Flags().Bool("dry-run", false, "Show the page that would be created without publishing")
+	_ = pageCreateCmd.MarkFlagRequired("space")
+	pageCmd.AddCommand(pageCreateCmd)
+}
+
+// pageDraft is a markdown file to publish: its title, body blocks and the
+// local images the body embeds.
+type pageDraft struct {
+	Title  string
+	Blocks []draftBlock
+	Images []draftImage
+}
+
+// draftBlock is a run of markdown, or (Image >= 0) a standalone image.
+type draftBlock struct {
+	Markdown string
+	Image    int
+}
+
+// draftImage is a local image file to upload, under the attachment name Name.
+type draftImage struct {
+	Path string
+	Name string
+}
+
+func runPageCreate(cmd *cobra.Command, args []string) error {
+	spaceKey, _ := cmd.Flags().GetString("space")
+	parentRef, _ := cmd.Flags().GetString("parent")
+	dryRun, _ := cmd.Flags().GetBool("dry-run")
+	spaceKey = strings.TrimSpace(spaceKey)
+	if spaceKey == "" {
+		return errors.New("--space is required")
+	}
+
+	path := args[0]
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return fmt.Errorf("reading %s: %w", path, err)
+	}
+	source := string(data)
+	if meta := store.ParsePageMeta(source); meta != nil {
+		return fmt.Errorf("%s is already page %s; use 'atlit page push %s' to update it", path, meta.ID, meta.ID)
+	}
+	draft, err := parsePageDraft(source, filepath.Dir(path))
+	if err != nil {
+		return fmt.Errorf("%s: %w", path, err)
+	}
+
+	parentID := ""
+	if strings.TrimSpace(parentRef) != "" {
+		if parentID, err = resolvePageRef(parentRef); err != nil {
+			return fmt.Errorf("--parent: %w", err)
+		}
+	}
+
+	if dryRun {
+		fmt.Printf("Would create %q in space %s", draft.Title, spaceKey)
+		if parentID != "" {
+			fmt.Printf(" under page %s", parentID)
+		}
+		fmt.Println()
+		for _, img := range draft.Images {
+			fmt.Printf("  attach %s as %s\n", img.Path, img.Name)
+		}
+		out, _ := json.MarshalIndent(draft.ADF("", nil), "", "  ")
+		fmt.Printf("\n%s\n", out)
+		return nil
+	}
+
+	cfg, err := config.Load()
+	if err != nil {
+		return err
+	}
+	token, err := config.GetToken(cfg)
+	if err != nil {
+		return fmt.Errorf("retrieving token: %w", err)
+	}
+	fetcher := newPageFetcher(cfg, token)
+
+	space, err := fetcher.client.GetSpaceByKey(spaceKey)
+	if err != nil {
+		if errors.Is(err, confluence.ErrNotFound) {
+			return fmt.Errorf("space %s not found or no access", spaceKey)
+		}
+		return wrapConfluenceError(err, spaceKey)
+	}
+	fetcher.spaces[space.ID] = space
+
+	return publishPageDraft(fetcher, space, parentID, path, source, draft)
+}
+
+// publishPageDraft creates the page, attaches its images and moves the draft
+// to pages_dir in the saved-page format. The page is recorded locally as soon
+// as it exists, so that when a later step fails a retry goes through 'atlit
+// page push' instead of creating a second page.
+func publishPageDraft(f *pageFetcher, space *confluence.Space, parentID, path, source string, draft *pageDraft) error {
+	// Images can only be attached to an existing page, so the page is created
+	// without them and updated once they are uploaded.
+	body, err := json.Marshal(draft.ADF("", nil))
+	if err != nil {
+		return fmt.Errorf("encoding ADF: %w", err)
+	}
+	page, err := f.client.CreatePage(confluence.NewPage{SpaceID: space.ID, ParentID: parentID, Title: draft.Title, Body: string(body)})
+	if err != nil {
+		if parentID != "" && errors.Is(err, confluence.ErrNotFound) {
+			return fmt.Errorf("parent page %s not found or no access", parentID)
+		}
+		return fmt.Errorf("creating page: %w", wrapConfluenceError(err, draft.Title))
+	}
+	fmt.Printf("Created page %s: %s\n", page.ID, draft.Title)
+
+	// The page exists now; save it as created (the create response need not
+	// carry the body, so render the one that was sent).
+	if page.Body.AtlasDocFormat.Value == "" {
+		page.Body.AtlasDocFormat.Value = string(body)
+	}
+	if page.Version == nil {
+		page.Version = &confluence.Version{Number: 1}
+	}
+	pagesDir := f.cfg.PagesDirOrDefault()
+	key := pageFileKey(space.Key, page.ID, page.Title)
+	ctx := renderer.PageContext{SpaceKey: space.Key, SpaceName: space.Name, WebURL: pageWebURL(f.cfg.Instance, page)}
+	if err := store.Save(pagesDir, key, preserveNotes(source, renderer.RenderPage(page, ctx))); err != nil {
+		return fmt.Errorf("page %s was created but saving it locally failed (run 'atlit page %s'): %w", page.ID, page.ID, err)
+	}
+	saved, _ := store.TicketPath(pagesDir, key)
+
+	if len(draft.Images) > 0 {
+		if err := attachDraftImages(f.client, page, draft); err != nil {
+			// Keep the draft for its image references, marked as published so
+			// that running create on it again is refused.
+			if !samePath(path, saved) {
+				stamped := store.StampPageMeta(source, page.ID, page.Version.Number, time.Now())
+				if werr := os.WriteFile(path, []byte(stamped), 0644); werr != nil {
+					fmt.Fprintf(os.Stderr, "warning: could not mark draft %s as published: %v\n", path, werr)
+				}
+			}
+			return fmt.Errorf("page %s was created without its images: %w\n"+
+				"It is saved to %s; %s keeps the image references. Add the images in Confluence, then run 'atlit page %s'",
+				page.ID, err, saved, path, page.ID)
+		}
+	}
+	if !samePath(path, saved) {
+		if err := os.Remove(path); err != nil {
+			fmt.Fprintf(os.Stderr, "warning: could not remove draft %s: %v\n", path, err)
+		}
+	}
+
+	if len(draft.Images) > 0 {
+		doc, err := f.fetch(page.ID)
+		if err == nil {
+			_, err = savePageDoc(pagesDir, doc)
+		}
+		if err != nil {
+			return fmt.Errorf("page %s was published, but refreshing %s failed (run 'atlit page %s'): %w", page.ID, saved, page.ID, err)
+		}
+	}
+	fmt.Printf("Saved to %s\n", saved)
+	return nil
+}
+
+// attachDraftImages uploads the draft's images to the page and publishes a
+// second version embedding them.
+func attachDraftImages(client *confluence.Client, page *confluence.Page, draft *pageDraft) error {
+	fileIDs := map[string]string{}
+	for _, img := range draft.Images {
+		data, err := os.ReadFile(img.Path)
+		if err != nil {
+			return err
+		}
+		att, err := client.UploadAttachment(page.ID, img.Name, data)
+		if err != nil {
+			return fmt.Errorf("uploading %s: %w", img.Path, err)
+		}
+		fileIDs[img.Name] = att.Extensions.FileID
+		fmt.Printf("Attached %s\n", img.Name)
+	}
+
+	body, err := json.Marshal(draft.ADF(page.ID, fileIDs))
+	if err != nil {
+		return fmt.Errorf("encoding ADF: %w", err)
+	}
+	version := 2
+	if page.Version != nil {
+		version = page.Version.Number + 1
+	}
+	_, err = client.UpdatePage(confluence.PageUpdate{
+		ID:      page.ID,
+		Status:  page.Status,
+		Title:   draft.Title,
+		Body:    string(body),
+		Version: version,
+		Message: "Embed images",
+	})
+	return err
+}
+
+// parsePageDraft splits a markdown file into title and body blocks. The
+// first "# " heading is the title; a "## My Notes" section is left out. Local
+// images on their own line, resolved against dir, become image blocks.
+func parsePageDraft(content, dir string) (*pageDraft, error) {
+	content = store.RemoveSection(content, "## My Notes")
+	draft := &pageDraft{}
+	index := map[string]int{} // attachment name -> position in draft.Images
+
+	var text []string
+	flush := func() {
+		if md := strings.TrimSpace(strings.Join(text, "\n")); md != "" {
+			draft.Blocks = append(draft.Blocks, draftBlock{Markdown: md, Image: -1})
+		}
+		text = nil
+	}
+
+	inFence := false
+	for _, line := range strings.Split(content, "\n") {
+		trimmed := strings.TrimSpace(line)
+		if strings.HasPrefix(trimmed, "```") {
+			inFence = !inFence
+		}
+		if !inFence {
+			if draft.Title == "" && strings.HasPrefix(line, "# ") {
+				draft.Title = strings.TrimSpace(strings.TrimPrefix(line, "# "))
+				continue
+			}
+			if m := standaloneImageRe.FindStringSubmatch(trimmed); m != nil && isLocalImageRef(m[2]) {
+				img := draftImage{Path: filepath.Join(dir, filepath.FromSlash(m[2]))}
+				img.Name = filepath.Base(img.Path)
+				i, seen := index[img.Name]
+				switch {
+				case seen && draft.Images[i].Path != img.Path:
+					return nil, fmt.Errorf("images %s and %s would both be attached as %s; rename one", draft.Images[i].Path, img.Path, img.Name)
+				case !seen:
+					if _, err := os.Stat(img.Path); err != nil {
+						return nil, fmt.Errorf("image %s: %w", m[2], err)
+					}
+					i = len(draft.Images)
+					index[img.Name] = i
+					draft.Images = append(draft.Images, img)
+				}
+				flush()
+				draft.Blocks = append(draft.Blocks, draftBlock{Image: i})
+				continue
+			}
+		}
+		text = append(text, line)
+	}
+	flush()
+
+	if draft.Title == "" {
+		return nil, errors.New(`no "# " title heading`)
+	}
+	return draft, nil
+}
+
+// isLocalImageRef reports whether an image reference points at a local file
+// rather than a URL.
+func isLocalImageRef(src string) bool {
+	return !strings.Contains(src, "://") && !strings.HasPrefix(src, "data:") && !strings.HasPrefix(src, "//")
+}
+
+// ADF renders the draft body. Images whose attachment file id is in fileIDs
+// are embedded as media of page pageID; the others are left out.
+func (d *pageDraft) ADF(pageID string, fileIDs map[string]string) *jira.ADFDoc {
+	doc := &jira.ADFDoc{Type: "doc", Version: 1, Content: []jira.ADFNode{}}
+	for _, b := range d.Blocks {
+		if b.Image < 0 {
+			doc.Content = append(doc.Content, jira.MarkdownToADF(b.Markdown).Content...)
+			continue
+		}
+		img := d.Images[b.Image]
+		id := fileIDs[img.Name]
+		if id == "" {
+			continue
+		}
+		doc.Content = append(doc.Content, jira.ADFNode{
+			Type:  "mediaSingle",
+			Attrs: map[string]any{"layout": "center"},
+			Content: []jira.ADFNode{{
+				Type: "media",
+				Attrs: map[string]any{
+					"type":       "file",
+					"id":         id,
+					"collection": "contentId-" + pageID,
+					"alt":        img.Name,
+				},
+			}},
+		})
+	}
+	return doc
+}
@@ -0,0 +1,213 @@
+package cmd
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/erickhilda/atlit/internal/config"
+	"github.com/erickhilda/atlit/internal/confluence"
+	"github.com/erickhilda/atlit/internal/store"
+)
+
+func writeDraftImage(t *testing.T, dir, rel string) {
+	t.Helper()
+	path := filepath.Join(dir, rel)
+	if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(path, []byte("png"), 0644); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func TestParsePageDraft(t *testing.T) {
+	dir := t.TempDir()
+	writeDraftImage(t, dir, "img/flow.png")
+	content := "# Payment Retries\n\nIntro.\n\n![flow](img/flow.png)\n\n## Design\n\n" +
+		"```md\n# not the title\n![x](img/flow.png)\n```\n\n" +
+		"![remote](https://example.com/a.png)\n\n![again](img/flow.png)\n\n" +
+		"## My Notes\n\nlocal only\n"
+
+	draft, err := parsePageDraft(content, dir)
+	if err != nil {
+		t.Fatalf("parsePageDraft: %v", err)
+	}
+	if draft.Title != "Payment Retries" {
+		t.Errorf("title = %q", draft.Title)
+	}
+	if len(draft.Images) != 1 || draft.Images[0].Name != "flow.png" || draft.Images[0].Path != filepath.Join(dir, "img", "flow.png") {
+		t.Fatalf("images = %+v", draft.Images)
+	}
+	if len(draft.Blocks) != 4 {
+		t.Fatalf("blocks = %+v", draft.Blocks)
+	}
+	if draft.Blocks[0].Markdown != "Intro." || draft.Blocks[1].Image != 0 || draft.Blocks[3].Image != 0 {
+		t.Errorf("blocks = %+v", draft.Blocks)
+	}
+	middle := draft.Blocks[2].Markdown
+	for _, want := range []string{"## Design", "# not the title", "![x](img/flow.png)", "https://example.com/a.png"} {
+		if !strings.Contains(middle, want) {
+			t.Errorf("middle block missing %q:\n%s", want, middle)
+		}
+	}
+	for _, b := range draft.Blocks {
+		if strings.Contains(b.Markdown, "local only") {
+			t.Error("My Notes leaked into the body")
+		}
+	}
+}
+
+func TestParsePageDraftErrors(t *testing.T) {
+	dir := t.TempDir()
+	writeDraftImage(t, dir, "a/x.png")
+	writeDraftImage(t, dir, "b/x.png")
+	cases := map[string]string{
+		"no title":      "Just text.\n",
+		"missing image": "# T\n\n![m](missing.png)\n",
+		"name clash":    "# T\n\n![a](a/x.png)\n\n![b](b/x.png)\n",
+	}
+	for name, content := range cases {
+		if _, err := parsePageDraft(content, dir); err == nil {
+			t.Errorf("%s: expected error", name)
+		}
+	}
+}
+
+func TestPageDraftADF(t *testing.T) {
+	dir := t.TempDir()
+	writeDraftImage(t, dir, "flow.png")
+	draft, err := parsePageDraft("# T\n\nBefore.\n\n![flow](flow.png)\n\nAfter.\n", dir)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	without := draft.ADF("", nil)
+	if len(without.Content) != 2 {
+		t.Errorf("without images: %d nodes, want 2", len(without.Content))
+	}
+
+	with := draft.ADF("777", map[string]string{"flow.png": "file-uuid"})
+	if len(with.Content) != 3 || with.Content[1].Type != "mediaSingle" {
+		t.Fatalf("with images: %+v", with.Content)
+	}
+	media := with.Content[1].Content[0]
+	if media.Type != "media" || media.Attrs["id"] != "file-uuid" || media.Attrs["collection"] != "contentId-777" || media.Attrs["alt"] != "flow.png" {
+		t.Errorf("media = %+v", media)
+	}
+}
+
+func TestIsLocalImageRef(t *testing.T) {
+	for src, want := range map[string]bool{
+		"img/flow.png":              true,
+		"../shared/a.png":           true,
+		"https://example.com/a.png": false,
+		"//cdn.example.com/a.png":   false,
+		"data:image/png;base64,xx":  false,
+	} {
+		if got := isLocalImageRef(src); got != want {
+			t.Errorf("isLocalImageRef(%q) = %v, want %v", src, got, want)
+		}
+	}
+}
+
+// createServer answers page creation with page 777 and fails attachment
+// uploads with uploadStatus.
+func createServer(t *testing.T, uploadStatus int) *httptest.Server {
+	t.Helper()
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch {
+		case r.Method == http.MethodPost && r.URL.Path == "/wiki/api/v2/pages":
+			_, _ = w.Write([]byte(`{"id":"777","status":"current","title":"Payment Retries","spaceId":"1","version":{"number":1}}`))
+		case r.Method == http.MethodPost && r.URL.Path == "/wiki/rest/api/content/777/child/attachment":
+			w.WriteHeader(uploadStatus)
+		default:
+			t.Errorf("unexpected request: %s %s", r.Method, r.URL)
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	t.Cleanup(srv.Close)
+	return srv
+}
+
+func TestPublishPageDraftMovesDraft(t *testing.T) {
+	srv := createServer(t, http.StatusOK)
+	pagesDir, draftDir := t.TempDir(), t.TempDir()
+	path := filepath.Join(draftDir, "retries.md")
+	source := "# Payment Retries\n\nIntro.\n\n## My Notes\n\nlocal only\n"
+	if err := os.WriteFile(path, []byte(source), 0644); err != nil {
+		t.Fatal(err)
+	}
+	draft, err := parsePageDraft(source, draftDir)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	f := newPageFetcher(&config.Config{Instance: srv.URL, PagesDir: pagesDir}, "tok")
+	space := &confluence.Space{ID: "1", Key: "ENG"}
+	if err := publishPageDraft(f, space, "", path, source, draft); err != nil {
+		t.Fatalf("publishPageDraft: %v", err)
+	}
+	if _, err := os.Stat(path); !os.IsNotExist(err) {
+		t.Errorf("draft not removed: %v", err)
+	}
+	saved, err := os.ReadFile(filepath.Join(pagesDir, "ENG__777__payment-retries.md"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if meta := store.ParsePageMeta(string(saved)); meta == nil || meta.ID != "777" || meta.Version != 1 {
+		t.Errorf("meta = %+v", meta)
+	}
+	for _, want := range []string{"## Content\n\nIntro.", "## My Notes\n\nlocal only"} {
+		if !strings.Contains(string(saved), want) {
+			t.Errorf("saved page missing %q:\n%s", want, saved)
+		}
+	}
+}
+
+func TestPublishPageDraftImageFailure(t *testing.T) {
+	srv := createServer(t, http.StatusInternalServerError)
+	pagesDir, draftDir := t.TempDir(), t.TempDir()
+	writeDraftImage(t, draftDir, "flow.png")
+	path := filepath.Join(draftDir, "retries.md")
+	source := "# Payment Retries\n\nIntro.\n\n![flow](flow.png)\n"
+	if err := os.WriteFile(path, []byte(source), 0644); err != nil {
+		t.Fatal(err)
+	}
+	draft, err := parsePageDraft(source, draftDir)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	f := newPageFetcher(&config.Config{Instance: srv.URL, PagesDir: pagesDir}, "tok")
+	err = publishPageDraft(f, &confluence.Space{ID: "1", Key: "ENG"}, "", path, source, draft)
+	if err == nil || !strings.Contains(err.Error(), "created without its images") {
+		t.Fatalf("err = %v", err)
+	}
+
+	// The page is saved where push and re-pulls look for it ...
+	saved, rerr := os.ReadFile(filepath.Join(pagesDir, "ENG__777__payment-retries.md"))
+	if rerr != nil {
+		t.Fatal(rerr)
+	}
+	if meta := store.ParsePageMeta(string(saved)); meta == nil || meta.ID != "777" {
+		t.Errorf("saved meta = %+v", meta)
+	}
+	if found, ferr := findPageFile(pagesDir, "777"); ferr != nil || !strings.HasSuffix(found, "ENG__777__payment-retries.md") {
+		t.Errorf("findPageFile = %q, %v", found, ferr)
+	}
+	// ... and the draft keeps its images but is marked, so create refuses it.
+	kept, rerr := os.ReadFile(path)
+	if rerr != nil {
+		t.Fatal(rerr)
+	}
+	if meta := store.ParsePageMeta(string(kept)); meta == nil || meta.ID != "777" {
+		t.Errorf("draft meta = %+v", meta)
+	}
+	if !strings.Contains(string(kept), "![flow](flow.png)") {
+		t.Errorf("draft lost its image reference:\n%s", kept)
+	}
+}
@@ -0,0 +1,199 @@
+package cmd
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"strings"
+	"time"
+
+	"github.com/erickhilda/atlit/internal/confluence"
+	"github.com/erickhilda/atlit/internal/renderer"
+	"github.com/spf13/cobra"
+)
+
+var pageHistoryCmd = &cobra.Command{
+	Use:   "history <PAGE-ID | URL>",
+	Short: "List a Confluence page's versions",
+	Long: `Lists the versions of a Confluence page, newest first, with author, date and
+version message. Minor edits are marked with "*".
+
+  atlit page history 12345
+  atlit page history 12345 --limit 100`,
+	Args: cobra.ExactArgs(1),
+	RunE: runPageHistory,
+}
+
+var pageDiffCmd = &cobra.Command{
+	Use:   "diff <PAGE-ID | URL>",
+	Short: "Diff two versions of a page, or the local file against Confluence",
+	Long: `Without --from, fetches the page and shows a unified diff of the saved file
+against it, like 'atlit diff' for tickets.
+
+With --from (and optionally --to, default: the current version), shows a
+unified diff of the page body (title and Content, as markdown) between two
+versions.
+
+  atlit page diff 12345
+  atlit page diff 12345 --from 12 --to 15
+  atlit page diff 12345 --from 12`,
+	Args: cobra.ExactArgs(1),
+	RunE: runPageDiff,
+}
+
+func init() {
+	pageHistoryCmd.Flags().Int("limit", 25, "Maximum number of versions to list (0 = all)")
+	pageDiffCmd.Flags().Int("from", 0, "Older version number to diff from")
+	pageDiffCmd.Flags().Int("to", 0, "Newer version number to diff to (default: the current version)")
+	pageDiffCmd.Flags().String("color", "auto", "Color output: auto, always, never")
+	pageCmd.AddCommand(pageHistoryCmd)
+	pageCmd.AddCommand(pageDiffCmd)
+}
+
+func runPageHistory(cmd *cobra.Command, args []string) error {
+	limit, _ := cmd.Flags().GetInt("limit")
+	if limit < 0 {
+		return fmt.Errorf("invalid --limit %d", limit)
+	}
+	id, err := resolvePageRef(args[0])
+	if err != nil {
+		return err
+	}
+	fetcher, err := loadPageFetcher()
+	if err != nil {
+		return err
+	}
+	versions, err := fetcher.client.GetPageVersions(id, limit)
+	if err != nil {
+		return wrapConfluenceError(err, id)
+	}
+	if len(versions) == 0 {
+		fmt.Printf("No versions for page %s\n", id)
+		return nil
+	}
+
+	fmt.Printf("Page %s: %d version(s)\n\n", id, len(versions))
+	fmt.Printf("%-8s %-28s %-12s %s\n", "VERSION", "AUTHOR", "UPDATED", "MESSAGE")
+	now := time.Now()
+	for _, v := range versions {
+		number := fmt.Sprint(v.Number)
+		if v.MinorEdit {
+			number += "*"
+		}
+		fmt.Printf("%-8s %-28s %-12s %s\n",
+			number,
+			truncate(firstNonEmpty(fetcher.names.DisplayName(v.AuthorID), v.AuthorID, "-"), 28),
+			formatPRUpdated(now, v.CreatedAt),
+			firstNonEmpty(v.Message, "-"),
+		)
+	}
+	return nil
+}
+
+func runPageDiff(cmd *cobra.Command, args []string) error {
+	from, _ := cmd.Flags().GetInt("from")
+	to, _ := cmd.Flags().GetInt("to")
+	colorFlag, _ := cmd.Flags().GetString("color")
+	if from < 0 || to < 0 {
+		return errors.New("version numbers must be positive")
+	}
+	if to > 0 && from == 0 {
+		return errors.New("--to needs --from")
+	}
+
+	id, err := resolvePageRef(args[0])
+	if err != nil {
+		return err
+	}
+	fetcher, err := loadPageFetcher()
+	if err != nil {
+		return err
+	}
+	if from == 0 {
+		return diffLocalPage(fetcher, id, colorFlag)
+	}
+	client := fetcher.client
+
+	if to == 0 {
+		current, err := client.GetPage(id)
+		if err != nil {
+			return wrapConfluenceError(err, id)
+		}
+		if current.Version != nil {
+			to = current.Version.Number
+		}
+	}
+	if from >= to {
+		return fmt.Errorf("--from (%d) must be older than --to (%d)", from, to)
+	}
+
+	older, err := client.GetPageVersion(id, from)
+	if err != nil {
+		return wrapPageVersionError(err, id, from)
+	}
+	newer, err := client.GetPageVersion(id, to)
+	if err != nil {
+		return wrapPageVersionError(err, id, to)
+	}
+	a, b := pageVersionMarkdown(older), pageVersionMarkdown(newer)
+	if a == b {
+		fmt.Printf("No changes to page %s between versions %d and %d\n", id, from, to)
+		return nil
+	}
+	return printUnifiedDiff(a, b, fmt.Sprintf("%s (v%d)", id, from), fmt.Sprintf("%s (v%d)", id, to), colorFlag)
+}
+
+// wrapPageVersionError explains a failure to fetch one version of a page.
+func wrapPageVersionError(err error, id string, n int) error {
+	if errors.Is(err, confluence.ErrNotFound) {
+		return fmt.Errorf("page %s has no version %d (or no access)", id, n)
+	}
+	return wrapConfluenceError(err, id)
+}
+
+// pageVersionMarkdown is what a version diff compares: the title and the body
+// as markdown.
+func pageVersionMarkdown(p *confluence.Page) string {
+	body := renderer.RenderPageBody(p.Body.AtlasDocFormat.Value)
+	return "# " + p.Title + "\n\n" + strings.TrimRight(body, "\n") + "\n"
+}
+
+// diffLocalPage diffs the saved file of a page against a fresh render.
+func diffLocalPage(fetcher *pageFetcher, id, colorFlag string) error {
+	path, err := findPageFile(fetcher.cfg.PagesDirOrDefault(), id)
+	if err != nil {
+		return err
+	}
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return err
+	}
+	local := string(data)
+
+	doc, err := fetcher.fetch(id)
+	if err != nil {
+		return err
+	}
+	// Compare against what a re-pull would save: My Notes, and the Comments
+	// when they were not fetched, carry over.
+	remote := preservePageSections(local, doc)
+
+	// The meta line differs on every fetch; compare what follows it.
+	local, remote = dropMetaLine(local), dropMetaLine(remote)
+	if local == remote {
+		fmt.Printf("No changes for page %s\n", id)
+		return nil
+	}
+	return printUnifiedDiff(local, remote, path+" (local)", id+" (remote)", colorFlag)
+}
+
+// dropMetaLine removes a leading atlit:meta line.
+func dropMetaLine(content string) string {
+	if !strings.HasPrefix(content, "<!-- atlit:meta ") {
+		return content
+	}
+	if idx := strings.IndexByte(content, '\n'); idx >= 0 {
+		return content[idx+1:]
+	}
+	return ""
+}
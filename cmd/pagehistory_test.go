@@ -0,0 +1,31 @@
+package cmd
+
+import (
+	"testing"
+
+	"github.com/erickhilda/atlit/internal/confluence"
+)
+
+func TestPageVersionMarkdown(t *testing.T) {
+	p := &confluence.Page{Title: "Design Doc"}
+	p.Body.AtlasDocFormat.Value = `{"type":"doc","version":1,"content":[{"type":"paragraph","content":[{"type":"text","text":"Hello"}]}]}`
+	if got, want := pageVersionMarkdown(p), "# Design Doc\n\nHello\n"; got != want {
+		t.Errorf("got %q, want %q", got, want)
+	}
+	if got, want := pageVersionMarkdown(&confluence.Page{Title: "Empty"}), "# Empty\n\n\n"; got != want {
+		t.Errorf("empty body: got %q, want %q", got, want)
+	}
+}
+
+func TestDropMetaLine(t *testing.T) {
+	cases := map[string]string{
+		"<!-- atlit:meta page=1 version=2 fetched=2026-06-10T12:00:00Z -->\n# T\n": "# T\n",
+		"# T\n": "# T\n",
+		"<!-- atlit:meta page=1 fetched=2026-06-10T12:00:00Z -->": "",
+	}
+	for in, want := range cases {
+		if got := dropMetaLine(in); got != want {
+			t.Errorf("dropMetaLine(%q) = %q, want %q", in, got, want)
+		}
+	}
+}
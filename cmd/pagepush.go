@@ -0,0 +1,391 @@
+package cmd
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"regexp"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/erickhilda/atlit/internal/config"
+	"github.com/erickhilda/atlit/internal/confluence"
+	"github.com/erickhilda/atlit/internal/jira"
+	"github.com/erickhilda/atlit/internal/renderer"
+	"github.com/erickhilda/atlit/internal/store"
+	"github.com/spf13/cobra"
+)
+
+// pageContentHeading opens the page body in a saved page file.
+const pageContentHeading = "## Content"
+
+// pageTrailingHeadingRe matches the sections atlit writes after the page
+// body. The body itself may contain "## " headings of its own, so it runs up
+// to the first of these rather than to the next H2.
+var pageTrailingHeadingRe = regexp.MustCompile(`^## (Attachments|Comments \(\d+\)|My Notes)$`)
+
+// roundTripNodes are the ADF node types that survive rendering to markdown and
+// converting back with jira.MarkdownToADF. mediaSingle images are carried over
+// from the live page by pagePushADF.
+var roundTripNodes = map[string]bool{
+	"doc": true, "paragraph": true, "text": true, "hardBreak": true, "heading": true,
+	"bulletList": true, "orderedList": true, "listItem": true, "codeBlock": true,
+	"blockquote": true, "mediaSingle": true, "media": true,
+}
+
+// roundTripMarks are the text marks jira.MarkdownToADF reproduces.
+var roundTripMarks = map[string]bool{"strong": true, "em": true, "code": true, "strike": true, "link": true}
+
+var pagePushCmd = &cobra.Command{
+	Use:   "push <PAGE-ID | URL>",
+	Short: "Push the locally-edited Content section back to Confluence",
+	Long: `Reads the saved page file and publishes its "## Content" section (and its
+title) as a new version of the Confluence page. The markdown is converted to
+Atlassian Document Format with the same converter 'atlit push' uses for Jira.
+
+Refuses to push if the page has a newer version in Confluence than the one
+recorded when it was fetched; re-fetch with 'atlit page <id>' and re-apply
+your edits.
+
+Images already on the page are kept where their ![name](name) lines are. Other
+content markdown cannot represent (mentions, macros, panels, status lozenges,
+tables, ...) would be replaced by its text rendering, so the push is refused
+when the page has any; --force pushes anyway.
+
+  atlit page push 12345 -m "Fix the rollout steps"
+  atlit page push 12345 --dry-run`,
+	Args: cobra.ExactArgs(1),
+	RunE: runPagePush,
+}
+
+func init() {
+	pagePushCmd.Flags().StringP("message", "m", "", "Version message shown in the page history")
+	pagePushCmd.Flags().Bool("dry-run", false, "Print the ADF that would be sent without updating the page")
+	pagePushCmd.Flags().Bool("force", false, "Push even if the page has content markdown cannot represent (it is lost)")
+	pageCmd.AddCommand(pagePushCmd)
+}
+
+func runPagePush(cmd *cobra.Command, args []string) error {
+	message, _ := cmd.Flags().GetString("message")
+	dryRun, _ := cmd.Flags().GetBool("dry-run")
+	force, _ := cmd.Flags().GetBool("force")
+
+	id, err := resolvePageRef(args[0])
+	if err != nil {
+		return err
+	}
+	cfg, err := config.Load()
+	if err != nil {
+		return err
+	}
+
+	path, err := findPageFile(cfg.PagesDirOrDefault(), id)
+	if err != nil {
+		return err
+	}
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return err
+	}
+	local := string(data)
+	meta := store.ParsePageMeta(local)
+	if meta == nil || meta.ID != id {
+		return fmt.Errorf("%s has no atlit:meta header for page %s; re-fetch it with 'atlit page %s'", path, id, id)
+	}
+	if meta.Version == 0 {
+		return fmt.Errorf("%s does not record the page version it was fetched at; re-fetch it with 'atlit page %s' and re-apply your edits", path, id)
+	}
+	body, ok := pageContentBody(local)
+	if !ok {
+		return fmt.Errorf("%s has no %q section", path, pageContentHeading)
+	}
+
+	token, err := config.GetToken(cfg)
+	if err != nil {
+		return fmt.Errorf("retrieving token: %w", err)
+	}
+	client := confluence.NewClient(cfg.Instance, cfg.Email, token)
+
+	page, err := client.GetPage(id)
+	if err != nil {
+		return wrapConfluenceError(err, id)
+	}
+	if err := checkPageVersion(id, meta.Version, page.Version); err != nil {
+		return err
+	}
+
+	title := firstNonEmpty(pageFileTitle(local), page.Title)
+	remoteBody, _ := pageContentBody(renderer.RenderPage(page, renderer.PageContext{}))
+	if body == remoteBody && title == page.Title {
+		fmt.Println("Nothing to push: the Content section and title match the page.")
+		return nil
+	}
+
+	doc, lossy := pagePushADF(body, page.Body.AtlasDocFormat.Value)
+	if len(lossy) > 0 {
+		list := strings.Join(lossy, ", ")
+		switch {
+		case dryRun:
+			fmt.Fprintf(os.Stderr, "warning: pushing would drop content markdown cannot represent: %s\n", list)
+		case !force:
+			return fmt.Errorf("page %s has content that would be lost on push: %s\n"+
+				"Edit it in Confluence, or re-run with --force to push anyway", id, list)
+		}
+	}
+	adf, err := json.Marshal(doc)
+	if err != nil {
+		return fmt.Errorf("encoding ADF: %w", err)
+	}
+	update := confluence.PageUpdate{
+		ID:      id,
+		Status:  page.Status,
+		Title:   title,
+		Body:    string(adf),
+		Version: meta.Version + 1,
+		Message: strings.TrimSpace(message),
+	}
+
+	if dryRun {
+		fmt.Printf("Would push %s to page %s as version %d (%q)\n\n", path, id, update.Version, title)
+		out, _ := json.MarshalIndent(doc, "", "  ")
+		fmt.Println(string(out))
+		return nil
+	}
+
+	updated, err := client.UpdatePage(update)
+	if err != nil {
+		if errors.Is(err, confluence.ErrConflict) {
+			return fmt.Errorf("page %s changed while pushing; re-fetch it with 'atlit page %s' and re-apply your edits", id, id)
+		}
+		return fmt.Errorf("updating page: %w", wrapConfluenceError(err, id))
+	}
+
+	version := update.Version
+	if updated.Version != nil && updated.Version.Number > 0 {
+		version = updated.Version.Number
+	}
+	// Record the new version so the next push from this file is not
+	// mistaken for a stale one.
+	stamped := store.StampPageMeta(local, id, version, time.Now())
+	if err := os.WriteFile(path, []byte(stamped), 0644); err != nil {
+		return fmt.Errorf("page updated, but recording the new version in %s failed: %w", path, err)
+	}
+	fmt.Printf("Updated page %s to version %d\n", id, version)
+	return nil
+}
+
+// pagePushADF converts the Content markdown of a saved page to ADF for a push.
+// remoteADF is the live page body: its images (mediaSingle nodes, rendered as
+// standalone ![name](name) lines) are put back as they are where those lines
+// still stand. lossy lists, sorted, the node and mark types of the live page
+// the push would not reproduce; an unreadable body counts as lossy.
+func pagePushADF(body, remoteADF string) (doc *jira.ADFDoc, lossy []string) {
+	media := map[string]jira.ADFNode{}
+	if strings.TrimSpace(remoteADF) != "" {
+		var remote jira.ADFDoc
+		if err := json.Unmarshal([]byte(remoteADF), &remote); err != nil {
+			lossy = append(lossy, "unreadable page body")
+		} else {
+			lossy = append(lossy, lossyADF(remote.Content)...)
+			collectPageMedia(remote.Content, media)
+		}
+	}
+
+	doc = &jira.ADFDoc{Type: "doc", Version: 1, Content: []jira.ADFNode{}}
+	var text []string
+	flush := func() {
+		if md := strings.TrimSpace(strings.Join(text, "\n")); md != "" {
+			doc.Content = append(doc.Content, jira.MarkdownToADF(md).Content...)
+		}
+		text = nil
+	}
+	inFence := false
+	for _, line := range strings.Split(body, "\n") {
+		trimmed := strings.TrimSpace(line)
+		if strings.HasPrefix(trimmed, "```") {
+			inFence = !inFence
+		}
+		if !inFence {
+			if m := standaloneImageRe.FindStringSubmatch(trimmed); m != nil {
+				if node, ok := media[m[2]]; ok {
+					flush()
+					doc.Content = append(doc.Content, node)
+					continue
+				}
+			}
+		}
+		text = append(text, line)
+	}
+	flush()
+	return doc, lossy
+}
+
+// lossyADF lists the node and mark types in nodes that are not in
+// roundTripNodes or roundTripMarks, once each and sorted. Images are only
+// carried over at the top level, so a nested one counts too.
+func lossyADF(nodes []jira.ADFNode) []string {
+	seen := map[string]bool{}
+	var walk func(nodes []jira.ADFNode, top bool)
+	walk = func(nodes []jira.ADFNode, top bool) {
+		for _, n := range nodes {
+			switch {
+			case n.Type == "mediaSingle" && !top:
+				seen["nested image"] = true
+			case !roundTripNodes[n.Type]:
+				seen[n.Type] = true
+			}
+			for _, m := range n.Marks {
+				if !roundTripMarks[m.Type] {
+					seen[m.Type+" formatting"] = true
+				}
+			}
+			walk(n.Content, false)
+		}
+	}
+	walk(nodes, true)
+	lossy := make([]string, 0, len(seen))
+	for t := range seen {
+		lossy = append(lossy, t)
+	}
+	sort.Strings(lossy)
+	return lossy
+}
+
+// collectPageMedia indexes the top-level images of a page body by the link
+// target jira.RenderADF gives them (the file name, else the media id).
+func collectPageMedia(nodes []jira.ADFNode, media map[string]jira.ADFNode) {
+	for _, n := range nodes {
+		if n.Type != "mediaSingle" {
+			continue
+		}
+		for _, child := range n.Content {
+			if child.Type != "media" {
+				continue
+			}
+			src, _ := child.Attrs["alt"].(string)
+			if kind, _ := child.Attrs["type"].(string); kind == "external" {
+				src, _ = child.Attrs["url"].(string)
+			} else if src == "" {
+				src, _ = child.Attrs["id"].(string)
+			}
+			if src != "" {
+				media[src] = n
+			}
+		}
+	}
+}
+
+// checkPageVersion refuses a push when the page gained versions after the
+// local file was fetched.
+func checkPageVersion(id string, localVersion int, remote *confluence.Version) error {
+	if remote == nil || remote.Number <= localVersion {
+		return nil
+	}
+	return fmt.Errorf(
+		"page %s was updated in Confluence after your last fetch\n"+
+			"  remote version: %d\n"+
+			"  local version:  %d\n"+
+			"Run 'atlit page %s' first, then re-apply your edits",
+		id, remote.Number, localVersion, id,
+	)
+}
+
+// pageContentBody returns the markdown of a saved page's Content section,
+// without its heading, up to the first section atlit writes after it. The
+// "no content" placeholder counts as empty. ok is false without the section.
+func pageContentBody(content string) (string, bool) {
+	lines := strings.Split(content, "\n")
+	start := -1
+	for i, line := range lines {
+		if strings.TrimRight(line, " ") == pageContentHeading {
+			start = i + 1
+			break
+		}
+	}
+	if start < 0 {
+		return "", false
+	}
+	end := len(lines)
+	inFence := false
+	for i := start; i < len(lines) && end == len(lines); i++ {
+		line := strings.TrimRight(lines[i], " ")
+		if strings.HasPrefix(line, "```") {
+			inFence = !inFence
+		}
+		if inFence {
+			continue
+		}
+		if pageTrailingHeadingRe.MatchString(line) {
+			end = i
+		}
+	}
+	body := strings.TrimSpace(strings.Join(lines[start:end], "\n"))
+	if body == "*No content.*" {
+		body = ""
+	}
+	return body, true
+}
+
+// pageFileTitle returns the "# " title line of a saved page, or "".
+func pageFileTitle(content string) string {
+	for _, line := range strings.Split(content, "\n") {
+		if strings.HasPrefix(line, "# ") {
+			return strings.TrimSpace(strings.TrimPrefix(line, "# "))
+		}
+		if strings.HasPrefix(line, "## ") {
+			break
+		}
+	}
+	return ""
+}
+
+// findPageFile locates the saved file of a page anywhere under pagesDir
+// (flat or in a tree saved with --recursive). When the page was saved more
+// than once, the most recently fetched copy wins.
+func findPageFile(pagesDir, id string) (string, error) {
+	dir, err := config.ExpandPath(pagesDir)
+	if err != nil {
+		return "", err
+	}
+	var best string
+	var bestFetched time.Time
+	err = filepath.WalkDir(dir, func(path string, d fs.DirEntry, err error) error {
+		if err != nil {
+			if path == dir {
+				return err
+			}
+			return nil
+		}
+		name := d.Name()
+		if d.IsDir() || !strings.HasSuffix(name, ".md") {
+			return nil
+		}
+		stem := strings.TrimSuffix(name, ".md")
+		if !strings.HasSuffix(stem, "__"+id) && !strings.Contains(stem, "__"+id+"__") {
+			return nil
+		}
+		data, rerr := os.ReadFile(path)
+		if rerr != nil {
+			return nil
+		}
+		meta := store.ParsePageMeta(string(data))
+		if meta == nil || meta.ID != id {
+			return nil
+		}
+		if best == "" || meta.Fetched.After(bestFetched) {
+			best, bestFetched = path, meta.Fetched
+		}
+		return nil
+	})
+	if err != nil && !errors.Is(err, fs.ErrNotExist) {
+		return "", err
+	}
+	if best == "" {
+		return "", fmt.Errorf("no saved file for page %s in %s; run 'atlit page %s' first", id, dir, id)
+	}
+	return best, nil
+}
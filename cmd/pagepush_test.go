@@ -0,0 +1,143 @@
+package cmd
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/erickhilda/atlit/internal/confluence"
+	"github.com/erickhilda/atlit/internal/jira"
+	"github.com/erickhilda/atlit/internal/renderer"
+)
+
+const savedPage = "<!-- atlit:meta page=12345 version=7 fetched=2026-06-10T12:00:00Z -->\n" +
+	"# Design Doc\n\n" +
+	"| Field | Value |\n|-------|-------|\n| Page ID | 12345 |\n\n" +
+	"## Content\n\n" +
+	"Intro.\n\n## Overview\n\nDetails.\n\n```md\n## My Notes\n```\n\n" +
+	"## Attachments\n\n- a.png\n\n" +
+	"## My Notes\n\nmine\n"
+
+func TestPageContentBody(t *testing.T) {
+	body, ok := pageContentBody(savedPage)
+	if !ok {
+		t.Fatal("expected a Content section")
+	}
+	want := "Intro.\n\n## Overview\n\nDetails.\n\n```md\n## My Notes\n```"
+	if body != want {
+		t.Errorf("body =\n%q\nwant\n%q", body, want)
+	}
+
+	if body, _ := pageContentBody("# T\n\n## Content\n\nBody.\n\n## Comments (2)\n\n### Alice -- 2026-06-15\n"); body != "Body." {
+		t.Errorf("body before comments = %q", body)
+	}
+	if body, ok := pageContentBody("# T\n\n## Content\n\n*No content.*\n"); !ok || body != "" {
+		t.Errorf("placeholder: body = %q, ok = %v", body, ok)
+	}
+	if _, ok := pageContentBody("# T\n\nno sections\n"); ok {
+		t.Error("expected no Content section")
+	}
+}
+
+func TestPageFileTitle(t *testing.T) {
+	if got := pageFileTitle(savedPage); got != "Design Doc" {
+		t.Errorf("title = %q", got)
+	}
+	if got := pageFileTitle("## Content\n\n# Not a title\n"); got != "" {
+		t.Errorf("title = %q, want empty", got)
+	}
+}
+
+func TestCheckPageVersion(t *testing.T) {
+	if err := checkPageVersion("1", 7, &confluence.Version{Number: 7}); err != nil {
+		t.Errorf("same version: %v", err)
+	}
+	if err := checkPageVersion("1", 7, nil); err != nil {
+		t.Errorf("unknown remote version: %v", err)
+	}
+	err := checkPageVersion("1", 7, &confluence.Version{Number: 9})
+	if err == nil || !strings.Contains(err.Error(), "remote version: 9") {
+		t.Errorf("newer remote: %v", err)
+	}
+}
+
+func TestFindPageFile(t *testing.T) {
+	dir := t.TempDir()
+	write := func(rel, content string) string {
+		path := filepath.Join(dir, rel)
+		if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+			t.Fatal(err)
+		}
+		if err := os.WriteFile(path, []byte(content), 0644); err != nil {
+			t.Fatal(err)
+		}
+		return path
+	}
+	write("ENG__12345__design-doc.md", savedPage)
+	newer := write("ENG__1__home/ENG__12345__design-doc.md",
+		strings.Replace(savedPage, "fetched=2026-06-10T12:00:00Z", "fetched=2026-06-11T12:00:00Z", 1))
+	write("ENG__123456__other.md", strings.Replace(savedPage, "page=12345", "page=123456", 1))
+
+	got, err := findPageFile(dir, "12345")
+	if err != nil {
+		t.Fatalf("findPageFile: %v", err)
+	}
+	if got != newer {
+		t.Errorf("got %s, want the most recently fetched %s", got, newer)
+	}
+	if _, err := findPageFile(dir, "999"); err == nil {
+		t.Error("expected an error for an unsaved page")
+	}
+}
+
+func TestPagePushADFKeepsImagesAndReportsMentions(t *testing.T) {
+	remote := `{"type":"doc","version":1,"content":[
+		{"type":"paragraph","content":[{"type":"text","text":"Ask "},{"type":"mention","attrs":{"id":"acc-1","text":"@Alice"}}]},
+		{"type":"mediaSingle","attrs":{"layout":"center","width":60},"content":[
+			{"type":"media","attrs":{"type":"file","id":"file-1","collection":"contentId-12345","alt":"arch.png"}}]},
+		{"type":"paragraph","content":[{"type":"text","text":"Outro."}]}
+	]}`
+	body := renderer.RenderPageBody(remote)
+	if !strings.Contains(body, "![arch.png](arch.png)") {
+		t.Fatalf("rendered body has no image line:\n%s", body)
+	}
+
+	doc, lossy := pagePushADF(strings.Replace(body, "Outro.", "Outro, edited.", 1), remote)
+	if strings.Join(lossy, ",") != "mention" {
+		t.Errorf("lossy = %v, want [mention]", lossy)
+	}
+	if len(doc.Content) != 3 {
+		t.Fatalf("content = %+v", doc.Content)
+	}
+	img := doc.Content[1]
+	if img.Type != "mediaSingle" || img.Attrs["width"] != float64(60) ||
+		len(img.Content) != 1 || img.Content[0].Attrs["id"] != "file-1" {
+		t.Errorf("image not carried over: %+v", img)
+	}
+	if got := doc.Content[2].Content[0].Text; got != "Outro, edited." {
+		t.Errorf("edited paragraph = %q", got)
+	}
+
+	// Plain pages push without loss; an unknown image line stays text.
+	plain := `{"type":"doc","version":1,"content":[{"type":"paragraph","content":[{"type":"text","text":"Hi","marks":[{"type":"strong"}]}]}]}`
+	doc, lossy = pagePushADF("**Hi**\n\n![new](new.png)", plain)
+	if len(lossy) != 0 || len(doc.Content) != 2 || doc.Content[1].Type != "paragraph" {
+		t.Errorf("plain page: lossy = %v, content = %+v", lossy, doc.Content)
+	}
+}
+
+func TestLossyADF(t *testing.T) {
+	nodes := []jira.ADFNode{
+		{Type: "panel", Content: []jira.ADFNode{{Type: "paragraph", Content: []jira.ADFNode{
+			{Type: "status"},
+			{Type: "text", Text: "x", Marks: []jira.ADFMark{{Type: "underline"}}},
+		}}}},
+		{Type: "bulletList", Content: []jira.ADFNode{{Type: "listItem", Content: []jira.ADFNode{{Type: "mediaSingle"}}}}},
+		{Type: "extension"},
+	}
+	want := "extension,nested image,panel,status,underline formatting"
+	if got := strings.Join(lossyADF(nodes), ","); got != want {
+		t.Errorf("lossyADF = %s, want %s", got, want)
+	}
+}
@@ -0,0 +1,264 @@
+package cmd
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"regexp"
+	"strings"
+	"time"
+
+	"github.com/erickhilda/atlit/internal/config"
+	"github.com/erickhilda/atlit/internal/confluence"
+	"github.com/spf13/cobra"
+)
+
+// relativeSinceRe matches --updated-since offsets such as "7d", "2w" or "12h".
+var relativeSinceRe = regexp.MustCompile(`^(\d+)([hdwM])$`)
+
+var pageSearchCmd = &cobra.Command{
+	Use:   "search [CQL]",
+	Short: "Search Confluence pages with preset filters or raw CQL",
+	Long: `Searches Confluence and lists matching pages (id, space, title, last
+update). Nothing is written unless --pull is given, which saves every hit as
+'atlit page <id>' would.
+
+Preset filters (composed with AND, restricted to pages):
+  atlit page search --space OPS --title runbook
+  atlit page search --space "OPS,ENG" --label oncall   # comma -> space in (...)
+  atlit page search --mine --updated-since 7d          # pages you contributed to
+  atlit page search --space ENG --updated-since 2026-01-01
+
+Advanced (raw CQL; cannot be combined with preset filters). Only pages are
+listed; add 'type = page' so other content does not count towards --limit:
+  atlit page search 'type = page and text ~ "oncall" and space = OPS'
+
+Output:
+  atlit page search --space OPS --format json
+  atlit page search --label adr --pull                 # save the hits too
+
+--updated-since takes an offset (12h, 7d, 2w, 3M) or a date (YYYY-MM-DD).`,
+	Args: cobra.MaximumNArgs(1),
+	RunE: runPageSearch,
+}
+
+func init() {
+	pageSearchCmd.Flags().String("space", "", "Filter by space key; comma-separate for multiple")
+	pageSearchCmd.Flags().String("title", "", "Filter by words in the title (title ~ ...)")
+	pageSearchCmd.Flags().String("label", "", "Filter by label; comma-separate for multiple")
+	pageSearchCmd.Flags().Bool("mine", false, "Filter to pages you created or edited (contributor = currentUser())")
+	pageSearchCmd.Flags().String("updated-since", "", "Filter to pages updated since an offset (7d, 2w) or a date (YYYY-MM-DD)")
+	pageSearchCmd.Flags().Int("limit", 25, "Maximum number of pages to list")
+	pageSearchCmd.Flags().String("format", "table", "Output format: table or json")
+	pageSearchCmd.Flags().Bool("pull", false, "Save every matching page to pages_dir")
+	pageCmd.AddCommand(pageSearchCmd)
+}
+
+// pageSearchFilters are the preset filters of 'atlit page search'.
+type pageSearchFilters struct {
+	space, title, label, updatedSince string
+	mine                              bool
+}
+
+func (f pageSearchFilters) empty() bool {
+	return f.space == "" && f.title == "" && f.label == "" && f.updatedSince == "" && !f.mine
+}
+
+// pageSearchHit is one row of the search output, also its JSON shape.
+type pageSearchHit struct {
+	ID      string `json:"id"`
+	Space   string `json:"space"`
+	Title   string `json:"title"`
+	Updated string `json:"updated"`
+	URL     string `json:"url,omitempty"`
+}
+
+func runPageSearch(cmd *cobra.Command, args []string) error {
+	var f pageSearchFilters
+	f.space, _ = cmd.Flags().GetString("space")
+	f.title, _ = cmd.Flags().GetString("title")
+	f.label, _ = cmd.Flags().GetString("label")
+	f.updatedSince, _ = cmd.Flags().GetString("updated-since")
+	f.mine, _ = cmd.Flags().GetBool("mine")
+	limit, _ := cmd.Flags().GetInt("limit")
+	format, _ := cmd.Flags().GetString("format")
+	pull, _ := cmd.Flags().GetBool("pull")
+
+	f.space = strings.TrimSpace(f.space)
+	f.title = strings.TrimSpace(f.title)
+	f.label = strings.TrimSpace(f.label)
+	f.updatedSince = strings.TrimSpace(f.updatedSince)
+
+	if format != "table" && format != "json" {
+		return fmt.Errorf("invalid --format %q: use table or json", format)
+	}
+	if limit <= 0 {
+		return fmt.Errorf("invalid --limit %d", limit)
+	}
+
+	var cql string
+	if len(args) == 1 {
+		if !f.empty() {
+			return errors.New("raw CQL cannot be combined with preset filters (--space/--title/--label/--mine/--updated-since)")
+		}
+		cql = strings.TrimSpace(args[0])
+		if cql == "" {
+			return errors.New("empty CQL query")
+		}
+	} else {
+		if f.empty() {
+			return errors.New("provide a CQL query or at least one filter: --space, --title, --label, --mine, --updated-since")
+		}
+		var err error
+		if cql, err = buildCQL(f); err != nil {
+			return err
+		}
+	}
+
+	cfg, err := config.Load()
+	if err != nil {
+		return err
+	}
+	token, err := config.GetToken(cfg)
+	if err != nil {
+		return fmt.Errorf("retrieving token: %w", err)
+	}
+	fetcher := newPageFetcher(cfg, token)
+
+	results, err := fetcher.client.SearchContent(cql, limit)
+	if err != nil {
+		if errors.Is(err, confluence.ErrUnauthorized) {
+			return fmt.Errorf("authentication failed: %w", err)
+		}
+		return fmt.Errorf("searching: %w", err)
+	}
+	hits, skipped := pageSearchHits(cfg.Instance, results)
+	if skipped > 0 {
+		fmt.Fprintf(os.Stderr, "note: left out %d result(s) that are not pages (blog posts, comments, attachments); add 'type = page' to the query to keep them from using up --limit\n", skipped)
+	}
+
+	if format == "json" {
+		if hits == nil {
+			hits = []pageSearchHit{}
+		}
+		data, err := json.MarshalIndent(hits, "", "  ")
+		if err != nil {
+			return err
+		}
+		fmt.Println(string(data))
+	} else {
+		printPageSearch(cql, hits)
+	}
+
+	if !pull || len(hits) == 0 {
+		return nil
+	}
+	return pullSearchHits(fetcher, hits)
+}
+
+// buildCQL assembles the preset query, newest-updated first.
+func buildCQL(f pageSearchFilters) (string, error) {
+	clauses := []string{"type = page"}
+	if f.space != "" {
+		clauses = append(clauses, inClause("space", f.space))
+	}
+	if f.title != "" {
+		clauses = append(clauses, "title ~ "+quoteJQL(f.title))
+	}
+	if f.label != "" {
+		clauses = append(clauses, inClause("label", f.label))
+	}
+	if f.mine {
+		clauses = append(clauses, "contributor = currentUser()")
+	}
+	if f.updatedSince != "" {
+		since, err := cqlSince(f.updatedSince)
+		if err != nil {
+			return "", err
+		}
+		clauses = append(clauses, "lastmodified >= "+since)
+	}
+	return strings.Join(clauses, " AND ") + " ORDER BY lastmodified DESC", nil
+}
+
+// inClause renders field = "v", or field in ("a", "b") for a comma list.
+func inClause(field, list string) string {
+	var quoted []string
+	for _, v := range strings.Split(list, ",") {
+		if v = strings.TrimSpace(v); v != "" {
+			quoted = append(quoted, quoteJQL(v))
+		}
+	}
+	if len(quoted) == 1 {
+		return field + " = " + quoted[0]
+	}
+	return field + " in (" + strings.Join(quoted, ", ") + ")"
+}
+
+// cqlSince turns an --updated-since value into a CQL date expression: an
+// offset becomes now("-7d"), a date is quoted as is.
+func cqlSince(s string) (string, error) {
+	if m := relativeSinceRe.FindStringSubmatch(s); m != nil {
+		return fmt.Sprintf(`now("-%s%s")`, m[1], m[2]), nil
+	}
+	if _, err := time.Parse("2006-01-02", s); err == nil {
+		return quoteJQL(s), nil
+	}
+	return "", fmt.Errorf("invalid --updated-since %q: use an offset like 7d, 2w, 12h, 3M or a date YYYY-MM-DD", s)
+}
+
+// pageSearchHits flattens search results into output rows. Raw CQL can match
+// other content types, which 'atlit page' cannot fetch; they are left out and
+// counted in skipped.
+func pageSearchHits(instance string, results []confluence.SearchResult) (hits []pageSearchHit, skipped int) {
+	for _, r := range results {
+		if r.Type != "page" {
+			skipped++
+			continue
+		}
+		h := pageSearchHit{ID: r.ID, Space: r.Space.Key, Title: r.Title, Updated: r.Version.When}
+		if r.Links.WebUI != "" {
+			h.URL = strings.TrimRight(instance, "/") + "/wiki" + r.Links.WebUI
+		}
+		hits = append(hits, h)
+	}
+	return hits, skipped
+}
+
+func printPageSearch(cql string, hits []pageSearchHit) {
+	fmt.Printf("CQL: %s\n", cql)
+	if len(hits) == 0 {
+		fmt.Println("\nNo pages match.")
+		return
+	}
+	fmt.Printf("\n%-12s %-10s %-60s %s\n", "ID", "SPACE", "TITLE", "UPDATED")
+	now := time.Now()
+	for _, h := range hits {
+		fmt.Printf("%-12s %-10s %-60s %s\n", h.ID, truncate(h.Space, 10), truncate(h.Title, 60), formatPRUpdated(now, h.Updated))
+	}
+}
+
+// pullSearchHits saves each hit like 'atlit page <id>', reporting failures
+// without stopping.
+func pullSearchHits(fetcher *pageFetcher, hits []pageSearchHit) error {
+	pagesDir := fetcher.cfg.PagesDirOrDefault()
+	saved, failed := 0, 0
+	for _, h := range hits {
+		doc, err := fetcher.fetch(h.ID)
+		if err == nil {
+			_, err = savePageDoc(pagesDir, doc)
+		}
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "warning: page %s: %v\n", h.ID, err)
+			failed++
+			continue
+		}
+		saved++
+	}
+	fmt.Fprintf(os.Stderr, "Saved %d page(s) to %s\n", saved, pagesDir)
+	if failed > 0 {
+		return fmt.Errorf("%d page(s) could not be saved", failed)
+	}
+	return nil
+}
@@ -0,0 +1,68 @@
+package cmd
+
+import (
+	"testing"
+
+	"github.com/erickhilda/atlit/internal/confluence"
+)
+
+func TestBuildCQL(t *testing.T) {
+	cases := []struct {
+		name string
+		f    pageSearchFilters
+		want string
+	}{
+		{"space", pageSearchFilters{space: "OPS"},
+			`type = page AND space = "OPS" ORDER BY lastmodified DESC`},
+		{"spaces and title", pageSearchFilters{space: "OPS, ENG", title: "run book"},
+			`type = page AND space in ("OPS", "ENG") AND title ~ "run book" ORDER BY lastmodified DESC`},
+		{"label mine", pageSearchFilters{label: "oncall", mine: true},
+			`type = page AND label = "oncall" AND contributor = currentUser() ORDER BY lastmodified DESC`},
+		{"relative since", pageSearchFilters{updatedSince: "7d"},
+			`type = page AND lastmodified >= now("-7d") ORDER BY lastmodified DESC`},
+		{"date since", pageSearchFilters{space: "ENG", updatedSince: "2026-01-01"},
+			`type = page AND space = "ENG" AND lastmodified >= "2026-01-01" ORDER BY lastmodified DESC`},
+	}
+	for _, tc := range cases {
+		got, err := buildCQL(tc.f)
+		if err != nil {
+			t.Errorf("%s: %v", tc.name, err)
+			continue
+		}
+		if got != tc.want {
+			t.Errorf("%s:\n got %s\nwant %s", tc.name, got, tc.want)
+		}
+	}
+}
+
+func TestCQLSinceInvalid(t *testing.T) {
+	for _, in := range []string{"7", "d7", "yesterday", "2026-13-01", "7 d"} {
+		if _, err := cqlSince(in); err == nil {
+			t.Errorf("cqlSince(%q): expected error", in)
+		}
+	}
+}
+
+func TestPageSearchHits(t *testing.T) {
+	var r confluence.SearchResult
+	r.ID, r.Type, r.Title = "123", "page", "Runbook"
+	r.Space.Key = "OPS"
+	r.Version.When = "2026-06-15T09:00:00.000Z"
+	r.Links.WebUI = "/spaces/OPS/pages/123/Runbook"
+
+	var blog, comment confluence.SearchResult
+	blog.ID, blog.Type, blog.Title = "124", "blogpost", "Release notes"
+	comment.ID, comment.Type = "125", "comment"
+
+	hits, skipped := pageSearchHits("https://acme.atlassian.net/", []confluence.SearchResult{blog, r, comment})
+	if len(hits) != 1 || skipped != 2 {
+		t.Fatalf("got %d hits, %d skipped; want 1 and 2", len(hits), skipped)
+	}
+	h := hits[0]
+	if h.ID != "123" || h.Space != "OPS" || h.Title != "Runbook" || h.Updated != r.Version.When {
+		t.Errorf("unexpected hit: %+v", h)
+	}
+	if h.URL != "https://acme.atlassian.net/wiki/spaces/OPS/pages/123/Runbook" {
+		t.Errorf("url = %q", h.URL)
+	}
+}
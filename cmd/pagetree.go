@@ -0,0 +1,114 @@
+package cmd
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+
+	"github.com/erickhilda/atlit/internal/config"
+	"github.com/erickhilda/atlit/internal/renderer"
+	"github.com/erickhilda/atlit/internal/store"
+)
+
+// pageTree saves pages and their descendants into a directory tree that
+// mirrors the page hierarchy: a page is saved as <dir>/<key>.md and its
+// children under <dir>/<key>/. indexDir is where index.md goes; entry paths
+// are relative to it.
+type pageTree struct {
+	fetcher  *pageFetcher
+	maxDepth int // levels of children to follow; 0 = all
+	dryRun   bool
+	indexDir string
+
+	saved, failed int
+}
+
+// newPageTree expands indexDir up front so index links can be computed
+// relative to it.
+func newPageTree(fetcher *pageFetcher, indexDir string, maxDepth int, dryRun bool) (*pageTree, error) {
+	dir, err := config.ExpandPath(indexDir)
+	if err != nil {
+		return nil, err
+	}
+	return &pageTree{fetcher: fetcher, maxDepth: maxDepth, dryRun: dryRun, indexDir: dir}, nil
+}
+
+// save writes doc into dir and then its children, depth levels below the
+// starting page, returning the page's index entry. Pages that cannot be
+// fetched or saved are reported and skipped along with their subtrees.
+func (t *pageTree) save(doc *pageDoc, dir string, depth int) renderer.PageIndexEntry {
+	path := filepath.Join(dir, doc.Key+".md")
+	entry := renderer.PageIndexEntry{Title: doc.Page.Title, Path: t.rel(path)}
+	if t.dryRun {
+		fmt.Printf("Would save %s\n", path)
+	} else if _, err := savePageDoc(dir, doc); err != nil {
+		fmt.Fprintf(os.Stderr, "warning: page %s: %v\n", doc.Page.ID, err)
+		t.failed++
+		return entry
+	}
+	t.saved++
+
+	if t.maxDepth > 0 && depth >= t.maxDepth {
+		return entry
+	}
+	children, err := t.fetcher.client.GetChildPages(doc.Page.ID)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "warning: children of page %s: %v\n", doc.Page.ID, wrapConfluenceError(err, doc.Page.ID))
+		t.failed++
+		return entry
+	}
+	childDir := filepath.Join(dir, doc.Key)
+	for _, c := range children {
+		cdoc, err := t.fetcher.fetch(c.ID)
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "warning: %v\n", err)
+			t.failed++
+			continue
+		}
+		entry.Children = append(entry.Children, t.save(cdoc, childDir, depth+1))
+	}
+	return entry
+}
+
+// rel is path relative to the index directory, with forward slashes for
+// markdown links.
+func (t *pageTree) rel(path string) string {
+	r, err := filepath.Rel(t.indexDir, path)
+	if err != nil {
+		return filepath.ToSlash(path)
+	}
+	return filepath.ToSlash(r)
+}
+
+// finish writes index.md and reports the outcome.
+func (t *pageTree) finish(title, source string, entries []renderer.PageIndexEntry) error {
+	content := renderer.RenderPageIndex(title, source, entries)
+	indexPath := filepath.Join(t.indexDir, "index.md")
+	if t.dryRun {
+		fmt.Printf("Would save %d page(s) and %s\n", t.saved, indexPath)
+	} else {
+		if err := store.Save(t.indexDir, "index", content); err != nil {
+			return fmt.Errorf("saving index: %w", err)
+		}
+		fmt.Printf("Saved %d page(s); index at %s\n", t.saved, indexPath)
+	}
+	if t.failed > 0 {
+		return fmt.Errorf("%d page(s) could not be saved", t.failed)
+	}
+	return nil
+}
+
+// savePageTree saves the fetched root page as usual and its descendants under
+// <pages_dir>/<root key>/, with an index.md there.
+func savePageTree(fetcher *pageFetcher, root *pageDoc, pagesDir string, maxDepth int, dryRun bool) error {
+	dir, err := config.ExpandPath(pagesDir)
+	if err != nil {
+		return err
+	}
+	t, err := newPageTree(fetcher, filepath.Join(dir, root.Key), maxDepth, dryRun)
+	if err != nil {
+		return err
+	}
+	entry := t.save(root, dir, 0)
+	return t.finish(root.Page.Title, "page:"+root.Page.ID, []renderer.PageIndexEntry{entry})
+}
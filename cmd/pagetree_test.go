@@ -0,0 +1,25 @@
+package cmd
+
+import (
+	"path/filepath"
+	"testing"
+)
+
+func TestPageTreeRel(t *testing.T) {
+	root := filepath.Join(t.TempDir(), "pages")
+	cases := []struct {
+		indexDir, path, want string
+	}{
+		// atlit page --recursive: index sits in the root page's directory.
+		{filepath.Join(root, "ENG__1__home"), filepath.Join(root, "ENG__1__home.md"), "../ENG__1__home.md"},
+		{filepath.Join(root, "ENG__1__home"), filepath.Join(root, "ENG__1__home", "ENG__2__child", "ENG__3__leaf.md"), "ENG__2__child/ENG__3__leaf.md"},
+		// atlit space export: index sits beside the top-level pages.
+		{filepath.Join(root, "ENG"), filepath.Join(root, "ENG", "ENG__1__home.md"), "ENG__1__home.md"},
+	}
+	for _, tc := range cases {
+		tree := &pageTree{indexDir: tc.indexDir}
+		if got := tree.rel(tc.path); got != tc.want {
+			t.Errorf("rel(%q) from %q = %q, want %q", tc.path, tc.indexDir, got, tc.want)
+		}
+	}
+}
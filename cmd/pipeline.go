@@ -0,0 +1,282 @@
+package cmd
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/erickhilda/atlit/internal/bitbucket"
+	"github.com/erickhilda/atlit/internal/config"
+	"github.com/erickhilda/atlit/internal/renderer"
+	"github.com/erickhilda/atlit/internal/store"
+	"github.com/spf13/cobra"
+)
+
+var pipelineCmd = &cobra.Command{
+	Use:   "pipeline",
+	Short: "Inspect Bitbucket Pipelines runs",
+	Long: `Lists Bitbucket Pipelines runs, shows their steps and saves step logs as
+markdown, so a red PR can be investigated without the browser.
+
+The repository is inferred from the git remote (run inside the repo); list
+takes it as an argument and show/logs as --repo.`,
+}
+
+var pipelineListCmd = &cobra.Command{
+	Use:   "list [repo | workspace/repo]",
+	Short: "List a repository's recent pipeline runs",
+	Args:  cobra.MaximumNArgs(1),
+	RunE:  runPipelineList,
+}
+
+var pipelineShowCmd = &cobra.Command{
+	Use:   "show <UUID | BUILD#>",
+	Short: "Show a pipeline run with its steps and durations",
+	Args:  cobra.ExactArgs(1),
+	RunE:  runPipelineShow,
+}
+
+var pipelineLogsCmd = &cobra.Command{
+	Use:   "logs <UUID | BUILD#>",
+	Short: "Save a pipeline run's step logs as markdown",
+	Long: `Fetches the logs of a pipeline run's steps and saves them as one markdown
+file in prs_dir, next to the PR the run belongs to:
+
+  <workspace>__<repo>__<id>.pipeline-<build>.md   (run of a PR or its branch)
+  <workspace>__<repo>.pipeline-<build>.md         (no open PR found)
+
+  atlit pipeline logs 812
+  atlit pipeline logs 812 --failed-only
+  atlit pipeline logs 812 --step "Unit tests" --repo acme/widget`,
+	Args: cobra.ExactArgs(1),
+	RunE: runPipelineLogs,
+}
+
+func init() {
+	pipelineListCmd.Flags().Int("limit", 20, "Maximum number of runs to list")
+	pipelineShowCmd.Flags().String("repo", "", "Repository (repo or workspace/repo; default: from the git remote)")
+	pipelineLogsCmd.Flags().String("repo", "", "Repository (repo or workspace/repo; default: from the git remote)")
+	pipelineLogsCmd.Flags().String("step", "", "Only save the log of the step with this name")
+	pipelineLogsCmd.Flags().Bool("failed-only", false, "Only save the logs of failed steps")
+	pipelineLogsCmd.Flags().Bool("dry-run", false, "Show what would be saved without writing")
+
+	pipelineCmd.AddCommand(pipelineListCmd)
+	pipelineCmd.AddCommand(pipelineShowCmd)
+	pipelineCmd.AddCommand(pipelineLogsCmd)
+	rootCmd.AddCommand(pipelineCmd)
+}
+
+// pipelineClient loads the config and resolves the repository (argument or
+// --repo, else the git remote) for a pipeline command.
+func pipelineClient(repoArg string) (*config.Config, *bitbucket.Client, string, string, error) {
+	cfg, err := config.Load()
+	if err != nil {
+		return nil, nil, "", "", err
+	}
+	var workspace, repo string
+	if repoArg == "" {
+		workspace, repo, err = inferFromGitRemote()
+		if err != nil {
+			return nil, nil, "", "", fmt.Errorf("not in a Bitbucket repo (%v); name the repository as repo or workspace/repo", err)
+		}
+	} else if workspace, repo, err = resolveRepoRef(repoArg, cfg); err != nil {
+		return nil, nil, "", "", err
+	}
+	token, err := config.GetBitbucketToken(cfg)
+	if err != nil {
+		return nil, nil, "", "", fmt.Errorf("retrieving Bitbucket token (run 'atlit auth bitbucket'): %w", err)
+	}
+	return cfg, bitbucket.NewClient(cfg.Email, token), workspace, repo, nil
+}
+
+// wrapPipelineError explains a pipeline lookup failure.
+func wrapPipelineError(err error, workspace, repo, ref string) error {
+	if errors.Is(err, bitbucket.ErrNotFound) {
+		return fmt.Errorf("pipeline %s not found in %s/%s (or Pipelines is not enabled)", ref, workspace, repo)
+	}
+	return wrapBBListError(err, workspace, repo)
+}
+
+func runPipelineList(cmd *cobra.Command, args []string) error {
+	limit, _ := cmd.Flags().GetInt("limit")
+	arg := ""
+	if len(args) == 1 {
+		arg = args[0]
+	}
+	_, client, workspace, repo, err := pipelineClient(arg)
+	if err != nil {
+		return err
+	}
+	pipelines, err := client.ListPipelines(workspace, repo, limit)
+	if err != nil {
+		return wrapBBListError(err, workspace, repo)
+	}
+	if len(pipelines) == 0 {
+		fmt.Printf("No pipeline runs for %s/%s\n", workspace, repo)
+		return nil
+	}
+
+	fmt.Printf("Pipelines: %s/%s (%d)\n\n", workspace, repo, len(pipelines))
+	fmt.Printf("%-7s %-12s %-32s %-10s %-9s %s\n", "#", "STATE", "BRANCH", "TRIGGER", "DURATION", "CREATED")
+	now := time.Now()
+	for _, p := range pipelines {
+		fmt.Printf("%-7d %-12s %-32s %-10s %-9s %s\n",
+			p.BuildNumber,
+			p.State,
+			truncate(firstNonEmpty(p.Target.RefName, "-"), 32),
+			truncate(strings.ToLower(p.Trigger.Name), 10),
+			renderer.FormatDuration(p.DurationInSeconds),
+			formatPRUpdated(now, p.CreatedOn),
+		)
+	}
+	return nil
+}
+
+func runPipelineShow(cmd *cobra.Command, args []string) error {
+	repoFlag, _ := cmd.Flags().GetString("repo")
+	_, client, workspace, repo, err := pipelineClient(repoFlag)
+	if err != nil {
+		return err
+	}
+	p, err := client.GetPipeline(workspace, repo, args[0])
+	if err != nil {
+		return wrapPipelineError(err, workspace, repo, args[0])
+	}
+	steps, err := client.GetPipelineSteps(workspace, repo, p.UUID)
+	if err != nil {
+		return wrapPipelineError(err, workspace, repo, args[0])
+	}
+
+	fmt.Printf("Pipeline #%d: %s\n", p.BuildNumber, p.State)
+	fmt.Printf("  Repo:     %s/%s\n", workspace, repo)
+	fmt.Printf("  Branch:   %s\n", firstNonEmpty(p.Target.RefName, "-"))
+	fmt.Printf("  Commit:   %s\n", firstNonEmpty(p.Target.Commit.Hash, "-"))
+	fmt.Printf("  Trigger:  %s\n", firstNonEmpty(p.Trigger.Name, "-"))
+	fmt.Printf("  Duration: %s\n", renderer.FormatDuration(p.DurationInSeconds))
+	fmt.Printf("  UUID:     %s\n\n", p.UUID)
+
+	fmt.Printf("%-32s %-12s %s\n", "STEP", "STATE", "DURATION")
+	for _, s := range steps {
+		fmt.Printf("%-32s %-12s %s\n", truncate(s.Name, 32), s.State, renderer.FormatDuration(s.DurationInSeconds))
+	}
+	return nil
+}
+
+// selectSteps picks the steps whose logs to save: the one named step (case
+// insensitive), and/or only failed ones.
+func selectSteps(steps []bitbucket.PipelineStep, name string, failedOnly bool) ([]bitbucket.PipelineStep, error) {
+	var out []bitbucket.PipelineStep
+	var names []string
+	found := false
+	for _, s := range steps {
+		names = append(names, s.Name)
+		if name != "" && !strings.EqualFold(s.Name, name) {
+			continue
+		}
+		found = true
+		if failedOnly && !s.State.Failed() {
+			continue
+		}
+		out = append(out, s)
+	}
+	if name != "" && !found {
+		return nil, fmt.Errorf("no step named %q; steps: %s", name, strings.Join(names, ", "))
+	}
+	return out, nil
+}
+
+// pipelineFileKey names the saved logs of a build after the PR file they
+// belong to, or after the repo when no PR is known.
+func pipelineFileKey(workspace, repo string, prID, build int) string {
+	if prID > 0 {
+		return fmt.Sprintf("%s.pipeline-%d", prFileKey(workspace, repo, prID), build)
+	}
+	return fmt.Sprintf("%s__%s.pipeline-%d", workspace, repo, build)
+}
+
+// pipelinePR finds the PR a run belongs to: the PR of a pull-request
+// pipeline, else an open PR from the run's branch. 0 when there is none.
+func pipelinePR(client *bitbucket.Client, workspace, repo string, p *bitbucket.Pipeline) int {
+	if p.Target.PullRequest != nil {
+		return p.Target.PullRequest.ID
+	}
+	if p.Target.RefName == "" {
+		return 0
+	}
+	prs, err := client.QueryPullRequests(workspace, repo, fmt.Sprintf(`state="OPEN" AND source.branch.name=%q`, p.Target.RefName))
+	if err != nil || len(prs) == 0 {
+		return 0
+	}
+	return prs[0].ID
+}
+
+func runPipelineLogs(cmd *cobra.Command, args []string) error {
+	repoFlag, _ := cmd.Flags().GetString("repo")
+	stepName, _ := cmd.Flags().GetString("step")
+	failedOnly, _ := cmd.Flags().GetBool("failed-only")
+	dryRun, _ := cmd.Flags().GetBool("dry-run")
+
+	cfg, client, workspace, repo, err := pipelineClient(repoFlag)
+	if err != nil {
+		return err
+	}
+	p, err := client.GetPipeline(workspace, repo, args[0])
+	if err != nil {
+		return wrapPipelineError(err, workspace, repo, args[0])
+	}
+	steps, err := client.GetPipelineSteps(workspace, repo, p.UUID)
+	if err != nil {
+		return wrapPipelineError(err, workspace, repo, args[0])
+	}
+	selected, err := selectSteps(steps, strings.TrimSpace(stepName), failedOnly)
+	if err != nil {
+		return err
+	}
+	if len(selected) == 0 {
+		if failedOnly {
+			fmt.Printf("No failed steps in pipeline #%d (%s); nothing to save.\n", p.BuildNumber, p.State)
+		} else {
+			fmt.Printf("Pipeline #%d (%s) has no steps; nothing to save.\n", p.BuildNumber, p.State)
+		}
+		return nil
+	}
+
+	for i := range selected {
+		log, err := client.GetPipelineStepLog(workspace, repo, p.UUID, selected[i].UUID)
+		switch {
+		case errors.Is(err, bitbucket.ErrNotFound):
+			// The step never ran (skipped after an earlier failure).
+		case err != nil:
+			return fmt.Errorf("fetching log of step %q: %w", selected[i].Name, wrapBBListError(err, workspace, repo))
+		default:
+			selected[i].Log = log
+		}
+	}
+
+	prsDir := cfg.PRsDirOrDefault()
+	prID := pipelinePR(client, workspace, repo, p)
+	prLink := ""
+	if prID > 0 {
+		prLink = fmt.Sprintf("#%d", prID)
+		if ok, _ := store.Exists(prsDir, prFileKey(workspace, repo, prID)); ok {
+			prLink = fmt.Sprintf("[PR #%d](%s.md)", prID, prFileKey(workspace, repo, prID))
+		}
+	}
+	key := pipelineFileKey(workspace, repo, prID, p.BuildNumber)
+	content := renderer.RenderPipelineLogs(workspace, repo, p, steps, selected, prLink)
+
+	path, err := store.TicketPath(prsDir, key)
+	if err != nil {
+		return err
+	}
+	if dryRun {
+		fmt.Printf("Would save %d step log(s) of pipeline #%d to %s (%d bytes)\n", len(selected), p.BuildNumber, path, len(content))
+		return nil
+	}
+	if err := store.Save(prsDir, key, content); err != nil {
+		return err
+	}
+	fmt.Printf("Saved %d step log(s) of pipeline #%d (%s) to %s\n", len(selected), p.BuildNumber, p.State, path)
+	return nil
+}
@@ -0,0 +1,51 @@
+package cmd
+
+import (
+	"encoding/json"
+	"strings"
+	"testing"
+
+	"github.com/erickhilda/atlit/internal/bitbucket"
+)
+
+func TestSelectSteps(t *testing.T) {
+	var steps []bitbucket.PipelineStep
+	if err := json.Unmarshal([]byte(`[
+		{"name":"Build","state":{"name":"COMPLETED","result":{"name":"SUCCESSFUL"}}},
+		{"name":"Unit tests","state":{"name":"COMPLETED","result":{"name":"FAILED"}}},
+		{"name":"Deploy","state":{"name":"COMPLETED","result":{"name":"STOPPED"}}}]`), &steps); err != nil {
+		t.Fatal(err)
+	}
+	names := func(ss []bitbucket.PipelineStep) string {
+		var out []string
+		for _, s := range ss {
+			out = append(out, s.Name)
+		}
+		return strings.Join(out, ",")
+	}
+
+	if got, _ := selectSteps(steps, "", false); names(got) != "Build,Unit tests,Deploy" {
+		t.Errorf("all = %s", names(got))
+	}
+	if got, _ := selectSteps(steps, "", true); names(got) != "Unit tests,Deploy" {
+		t.Errorf("failed only = %s", names(got))
+	}
+	if got, _ := selectSteps(steps, "unit TESTS", false); names(got) != "Unit tests" {
+		t.Errorf("by name = %s", names(got))
+	}
+	if got, err := selectSteps(steps, "Build", true); err != nil || len(got) != 0 {
+		t.Errorf("passed step with --failed-only = %s, %v", names(got), err)
+	}
+	if _, err := selectSteps(steps, "Lint", false); err == nil || !strings.Contains(err.Error(), "Build, Unit tests, Deploy") {
+		t.Errorf("unknown step err = %v", err)
+	}
+}
+
+func TestPipelineFileKey(t *testing.T) {
+	if got := pipelineFileKey("acme", "widget", 42, 812); got != "acme__widget__42.pipeline-812" {
+		t.Errorf("with PR = %q", got)
+	}
+	if got := pipelineFileKey("acme", "widget", 0, 812); got != "acme__widget.pipeline-812" {
+		t.Errorf("without PR = %q", got)
+	}
+}
@@ -3,8 +3,8 @@ package cmd
 import (
 	"errors"
 	"fmt"
+	"net/url"
 	"os"
-	"os/exec"
 	"regexp"
 	"strconv"
 	"strings"
@@ -24,15 +24,22 @@ const largeDiffBytes = 500 * 1024
 var jiraKeyRe = regexp.MustCompile(`[A-Z][A-Z0-9]+-\d+`)
 
 var prCmd = &cobra.Command{
-	Use:   "pr <ID | repo/ID | workspace/repo/ID>",
-	Short: "Fetch a Bitbucket pull request and save as markdown",
-	Long: `Fetches a Bitbucket Cloud pull request (metadata, diff, comments) and saves it
-as local markdown for code-review context.
+	Use:   "pr <ID | repo/ID | workspace/repo/ID | URL>",
+	Short: "Fetch a pull request and save as markdown",
+	Long: `Fetches a Bitbucket Cloud, GitHub or GitLab pull request (metadata, diff,
+comments) and saves it as local markdown for code-review context. GitLab merge
+requests and GitHub PRs render in the same format as Bitbucket ones.
 
 Reference forms:
-  atlit pr 4521                       infer workspace/repo from the git remote (run inside the repo)
-  atlit pr widget/4521                repo explicit, workspace from config (bitbucket_workspace)
-  atlit pr acme/widget/4521           fully explicit`,
+  atlit pr 4521                       infer host/workspace/repo from the git remote (run inside the repo)
+  atlit pr widget/4521                Bitbucket repo, workspace from config (bitbucket_workspace)
+  atlit pr acme/widget/4521           Bitbucket, fully explicit
+  atlit pr https://github.com/acme/widget/pull/4521
+  atlit pr https://gitlab.com/acme/platform/widget/-/merge_requests/4521
+
+GitHub Enterprise and self-managed GitLab hosts are recognised once set with
+'atlit config set github_host|gitlab_host <host>'. Tokens come from
+'atlit auth github|gitlab' (or GITHUB_TOKEN / GITLAB_TOKEN).`,
 	Args: cobra.ExactArgs(1),
 	RunE: runPR,
 }
@@ -55,6 +62,12 @@ Repo reference forms:
 func init() {
 	prCmd.Flags().Bool("no-diff", false, "Omit the unified diff (keep diffstat + comments)")
 	prCmd.Flags().Bool("dry-run", false, "Show what would change without saving")
+	prCmd.Flags().StringSlice("include", nil, "Only embed diffs of files matching these globs (e.g. '*.go,cmd/**')")
+	prCmd.Flags().StringSlice("exclude", nil, "Leave out diffs of files matching these globs (e.g. 'vendor/**,*.lock')")
+	prCmd.Flags().Int("max-file-bytes", 0, "Leave out a file's diff when it is larger than this many bytes")
+	prCmd.Flags().Bool("split", false, "Write each file's diff to its own markdown file, linked from the PR file")
+	prCmd.Flags().Bool("since-last", false, "Also show the interdiff and new comments since the previous fetch")
+	prCmd.Flags().Bool("context-files", false, "Save the full contents of changed files (source and destination) beside the PR file")
 
 	prListCmd.Flags().String("state", "open", "Filter by state: open|merged|declined|all")
 	prListCmd.Flags().Int("limit", 30, "Maximum number of PRs to list (rows shown, not the repo total)")
@@ -227,112 +240,181 @@ func wrapBBListError(err error, workspace, repo string) error {
 func runPR(cmd *cobra.Command, args []string) error {
 	noDiff, _ := cmd.Flags().GetBool("no-diff")
 	dryRun, _ := cmd.Flags().GetBool("dry-run")
+	var diffOpts diffOptions
+	diffOpts.include, _ = cmd.Flags().GetStringSlice("include")
+	diffOpts.exclude, _ = cmd.Flags().GetStringSlice("exclude")
+	diffOpts.maxFileBytes, _ = cmd.Flags().GetInt("max-file-bytes")
+	diffOpts.split, _ = cmd.Flags().GetBool("split")
+	sinceLast, _ := cmd.Flags().GetBool("since-last")
+	contextFiles, _ := cmd.Flags().GetBool("context-files")
+	if noDiff && diffOpts.active() {
+		return errors.New("--no-diff cannot be combined with --include, --exclude, --max-file-bytes or --split")
+	}
+	if diffOpts.maxFileBytes < 0 {
+		return fmt.Errorf("invalid --max-file-bytes %d", diffOpts.maxFileBytes)
+	}
 
 	cfg, err := config.Load()
 	if err != nil {
 		return err
 	}
 
-	workspace, repo, id, err := resolvePRRef(args[0], cfg)
+	ref, err := resolvePRRef(args[0], cfg)
 	if err != nil {
 		return err
 	}
-
-	token, err := config.GetBitbucketToken(cfg)
-	if err != nil {
-		return fmt.Errorf("retrieving Bitbucket token (run 'atlit auth bitbucket'): %w", err)
+	if contextFiles && ref.Host != hostBitbucket {
+		return fmt.Errorf("%s is a %s PR; --context-files is only supported for Bitbucket", ref, ref.Host)
 	}
 
-	client := bitbucket.NewClient(cfg.Email, token)
+	opts := prFileOptions{noDiff: noDiff, dryRun: dryRun, sinceLast: sinceLast, contextFiles: contextFiles, diff: diffOpts}
+	_, _, err = savePRFile(cfg, newPRClients(cfg), ref, opts)
+	return err
+}
 
-	pr, err := client.GetPullRequest(workspace, repo, id)
-	if err != nil {
-		return wrapBBError(err, workspace, repo, id)
-	}
+// prFileOptions controls savePRFile. jiraKey and ticketPath override the
+// ticket detected from the PR branch/title (used by `atlit pull --with-prs`,
+// which knows the ticket and may not have saved its file yet); diff filters or
+// splits the embedded diff; sinceLast adds what changed since the previous
+// fetch; contextFiles saves the full contents of the changed files beside it.
+type prFileOptions struct {
+	noDiff, dryRun, sinceLast bool
+	contextFiles              bool
+	jiraKey, ticketPath       string
+	diff                      diffOptions
+}
 
-	diffstat, err := client.GetPullRequestDiffstat(workspace, repo, id)
-	if err != nil {
-		return wrapBBError(err, workspace, repo, id)
+// savePRFile fetches a PR with its diffstat, comments and diff, renders it and
+// saves it under prs_dir (or shows the dry-run diff), returning the file path
+// and the fetched data.
+func savePRFile(cfg *config.Config, clients *prClients, ref prRef, opts prFileOptions) (string, *prData, error) {
+	prsDir := cfg.PRsDirOrDefault()
+	key := ref.fileKey()
+	existing, loadErr := store.Load(prsDir, key)
+
+	var prev *store.PRMeta
+	if opts.sinceLast {
+		if loadErr == nil {
+			prev = store.ParsePRMeta(existing)
+		}
+		if prev == nil || prev.Commit == "" {
+			return "", nil, fmt.Errorf("no earlier fetch of %s with a recorded commit; run 'atlit pr' without --since-last first", ref)
+		}
 	}
 
-	comments, err := client.GetPullRequestComments(workspace, repo, id)
+	d, err := fetchPR(clients, ref, opts.noDiff)
 	if err != nil {
-		return wrapBBError(err, workspace, repo, id)
+		return "", nil, err
 	}
 
-	diff := ""
-	if !noDiff {
-		diff, err = client.GetPullRequestDiff(workspace, repo, id)
+	diff, diffstat := d.Diff, d.Diffstat
+	if opts.diff.active() && diff != "" {
+		fd := filterDiff(diff, diffstat, opts.diff)
+		diff, diffstat = fd.Diff(), fd.Diffstat
+		if opts.diff.split {
+			if err := writeSplitFiles(prsDir, key, ref, d, &fd, opts.dryRun); err != nil {
+				return "", nil, err
+			}
+			diff, diffstat = "", fd.Diffstat
+		}
+	}
+	if opts.contextFiles {
+		client, err := clients.bitbucket()
 		if err != nil {
-			return wrapBBError(err, workspace, repo, id)
+			return "", nil, err
 		}
-		if len(diff) > largeDiffBytes {
-			fmt.Fprintf(os.Stderr, "warning: diff is %d KB; consider --no-diff or reviewing specific files\n", len(diff)/1024)
+		if diffstat, err = writeContextFiles(client, prsDir, key, ref, d.PR, diffstat, opts.diff, opts.dryRun); err != nil {
+			return "", nil, err
 		}
 	}
+	if len(diff) > largeDiffBytes {
+		fmt.Fprintf(os.Stderr, "warning: diff is %d KB; consider --exclude, --max-file-bytes, --split or --no-diff\n", len(diff)/1024)
+	}
 
-	jiraKey := detectJiraKey(pr)
-	ticketPath := localTicketPath(cfg, jiraKey)
+	jiraKey, ticketPath := opts.jiraKey, opts.ticketPath
+	if jiraKey == "" {
+		jiraKey = detectJiraKey(d.PR)
+		ticketPath = localTicketPath(cfg, jiraKey)
+	}
 
-	content := renderer.RenderPullRequest(pr, workspace, repo, diffstat, diff, comments, jiraKey, ticketPath)
+	content := renderer.RenderPullRequest(d.PR, ref.Workspace, ref.Repo, diffstat, diff, d.Comments, jiraKey, ticketPath)
 
-	prsDir := cfg.PRsDirOrDefault()
-	key := prFileKey(workspace, repo, id)
+	if prev != nil {
+		section, err := sinceLastSection(clients, ref, prev, d)
+		if err != nil {
+			return "", nil, err
+		}
+		content = insertBeforeSection(content, "## Description", section)
+	}
+
+	path, err := store.TicketPath(prsDir, key)
+	if err != nil {
+		return "", nil, err
+	}
 
 	// Preserve a hand-added "## My Notes" section across re-pulls.
-	if existing, err := store.Load(prsDir, key); err == nil {
+	if loadErr == nil {
 		content = preserveNotes(existing, content)
 	}
 
-	if dryRun {
-		return showDryRunDir(prsDir, key, content)
+	if opts.dryRun {
+		return path, d, showDryRunDir(prsDir, key, content)
 	}
 
 	if err := store.Save(prsDir, key, content); err != nil {
-		return fmt.Errorf("saving PR: %w", err)
+		return "", nil, fmt.Errorf("saving PR: %w", err)
 	}
 
-	path, _ := store.TicketPath(prsDir, key)
-	fmt.Printf("Saved %s/%s PR #%d to %s\n", workspace, repo, id, path)
-	return nil
+	fmt.Printf("Saved %s PR %s to %s\n", ref.Host, ref, path)
+	return path, d, nil
 }
 
-// resolvePRRef parses a PR reference into workspace, repo, and numeric id.
-func resolvePRRef(arg string, cfg *config.Config) (workspace, repo string, id int, err error) {
+// resolvePRRef parses a PR reference. A URL may point at any supported host
+// and a bare id is resolved against the git remote (Bitbucket, GitHub or
+// GitLab); the repo/id and workspace/repo/id forms are Bitbucket refs.
+func resolvePRRef(arg string, cfg *config.Config) (prRef, error) {
+	if strings.Contains(arg, "://") {
+		return parsePRURL(arg, cfg)
+	}
+	ref := prRef{Host: hostBitbucket, Server: "bitbucket.org"}
+	var err error
 	parts := strings.Split(arg, "/")
 	switch len(parts) {
 	case 3: // workspace/repo/id
-		workspace, repo = parts[0], parts[1]
-		id, err = parsePRID(parts[2])
+		ref.Workspace, ref.Repo = parts[0], parts[1]
+		ref.ID, err = parsePRID(parts[2])
 	case 2: // repo/id
-		repo = parts[0]
-		id, err = parsePRID(parts[1])
-		workspace = cfg.BitbucketWorkspace
-		if workspace == "" {
+		ref.Repo = parts[0]
+		ref.ID, err = parsePRID(parts[1])
+		ref.Workspace = cfg.BitbucketWorkspace
+		if ref.Workspace == "" {
 			if ws, _, gerr := inferFromGitRemote(); gerr == nil {
-				workspace = ws
+				ref.Workspace = ws
 			}
 		}
-		if workspace == "" {
-			return "", "", 0, fmt.Errorf("no workspace: set 'bitbucket_workspace' in config or use 'workspace/%s'", arg)
+		if ref.Workspace == "" {
+			return prRef{}, fmt.Errorf("no workspace: set 'bitbucket_workspace' in config or use 'workspace/%s'", arg)
 		}
-	case 1: // id only -> infer workspace + repo from git remote
-		id, err = parsePRID(parts[0])
-		if err != nil {
-			return "", "", 0, err
+	case 1: // id only -> infer host, workspace and repo from git remote
+		id, perr := parsePRID(parts[0])
+		if perr != nil {
+			return prRef{}, perr
+		}
+		remote, gerr := gitOriginURL()
+		if gerr == nil {
+			ref, gerr = parseGitRemote(remote, cfg)
 		}
-		ws, r, gerr := inferFromGitRemote()
 		if gerr != nil {
-			return "", "", 0, fmt.Errorf("not in a Bitbucket repo (%v); use 'atlit pr <repo>/%d' or 'atlit pr <workspace>/<repo>/%d'", gerr, id, id)
+			return prRef{}, fmt.Errorf("not in a Bitbucket, GitHub or GitLab repo (%v); use 'atlit pr <repo>/%d', 'atlit pr <workspace>/<repo>/%d' or a PR URL", gerr, id, id)
 		}
-		workspace, repo = ws, r
+		ref.ID = id
 	default:
-		return "", "", 0, fmt.Errorf("invalid PR reference %q", arg)
+		return prRef{}, fmt.Errorf("invalid PR reference %q", arg)
 	}
 	if err != nil {
-		return "", "", 0, err
+		return prRef{}, err
 	}
-	return workspace, repo, id, nil
+	return ref, nil
 }
 
 func parsePRID(s string) (int, error) {
@@ -346,13 +428,14 @@ func parsePRID(s string) (int, error) {
 	return id, nil
 }
 
-// inferFromGitRemote derives workspace and repo from the origin remote URL.
+// inferFromGitRemote derives a Bitbucket workspace and repo from the origin
+// remote URL.
 func inferFromGitRemote() (workspace, repo string, err error) {
-	out, err := exec.Command("git", "remote", "get-url", "origin").Output()
+	remote, err := gitOriginURL()
 	if err != nil {
-		return "", "", fmt.Errorf("no git 'origin' remote")
+		return "", "", err
 	}
-	return parseBitbucketRemote(strings.TrimSpace(string(out)))
+	return parseBitbucketRemote(remote)
 }
 
 // parseBitbucketRemote extracts workspace and repo from an SSH or HTTPS
@@ -376,6 +459,25 @@ func parseBitbucketRemote(remote string) (workspace, repo string, err error) {
 	return segs[0], segs[1], nil
 }
 
+// parseBitbucketPRURL extracts workspace, repo and id from a Bitbucket Cloud
+// pull request URL such as https://bitbucket.org/acme/widget/pull-requests/42
+// (trailing path segments like /diff are ignored).
+func parseBitbucketPRURL(raw string) (workspace, repo string, id int, err error) {
+	u, err := url.Parse(strings.TrimSpace(raw))
+	if err != nil || !strings.EqualFold(u.Hostname(), "bitbucket.org") {
+		return "", "", 0, fmt.Errorf("not a Bitbucket PR URL: %s", raw)
+	}
+	segs := strings.Split(strings.Trim(u.Path, "/"), "/")
+	if len(segs) < 4 || segs[0] == "" || segs[1] == "" || segs[2] != "pull-requests" {
+		return "", "", 0, fmt.Errorf("not a Bitbucket PR URL: %s", raw)
+	}
+	id, err = parsePRID(segs[3])
+	if err != nil {
+		return "", "", 0, err
+	}
+	return segs[0], segs[1], id, nil
+}
+
 // detectJiraKey finds a Jira key in the PR source branch, falling back to title.
 func detectJiraKey(pr *bitbucket.PullRequest) string {
 	if k := jiraKeyRe.FindString(pr.Source.Branch.Name); k != "" {
@@ -156,20 +156,94 @@ func TestFormatPRUpdated(t *testing.T) {
 func TestResolvePRRefExplicit(t *testing.T) {
 	cfg := &config.Config{BitbucketWorkspace: "acme"}
 
-	ws, repo, id, err := resolvePRRef("other/gadget/7", cfg)
-	if err != nil || ws != "other" || repo != "gadget" || id != 7 {
-		t.Errorf("3-part: %s/%s/%d err=%v", ws, repo, id, err)
+	ref, err := resolvePRRef("other/gadget/7", cfg)
+	if err != nil || ref.Host != hostBitbucket || ref.Workspace != "other" || ref.Repo != "gadget" || ref.ID != 7 {
+		t.Errorf("3-part: %+v err=%v", ref, err)
 	}
 
-	ws, repo, id, err = resolvePRRef("widget/15", cfg)
-	if err != nil || ws != "acme" || repo != "widget" || id != 15 {
-		t.Errorf("2-part: %s/%s/%d err=%v", ws, repo, id, err)
+	ref, err = resolvePRRef("widget/15", cfg)
+	if err != nil || ref.Workspace != "acme" || ref.Repo != "widget" || ref.ID != 15 {
+		t.Errorf("2-part: %+v err=%v", ref, err)
 	}
 
-	if _, _, _, err := resolvePRRef("widget/notanum", cfg); err == nil {
+	if _, err := resolvePRRef("widget/notanum", cfg); err == nil {
 		t.Error("expected error for non-numeric id")
 	}
-	if _, _, _, err := resolvePRRef("widget/0", cfg); err == nil {
+	if _, err := resolvePRRef("widget/0", cfg); err == nil {
 		t.Error("expected error for non-positive id")
 	}
+
+	ref, err = resolvePRRef("https://github.com/acme/widget/pull/9", cfg)
+	if err != nil || ref.Host != hostGitHub || ref.ID != 9 {
+		t.Errorf("URL: %+v err=%v", ref, err)
+	}
+}
+
+func TestParseBitbucketPRURL(t *testing.T) {
+	for _, raw := range []string{
+		"https://bitbucket.org/acme/widget/pull-requests/42",
+		"https://bitbucket.org/acme/widget/pull-requests/42/diff",
+		"https://bitbucket.org/acme/widget/pull-requests/42?tab=comments",
+	} {
+		ws, repo, id, err := parseBitbucketPRURL(raw)
+		if err != nil || ws != "acme" || repo != "widget" || id != 42 {
+			t.Errorf("%s: %s/%s/%d err=%v", raw, ws, repo, id, err)
+		}
+	}
+
+	for _, raw := range []string{
+		"https://github.com/acme/widget/pull/42",
+		"https://bitbucket.org/acme/widget/branches/main",
+		"https://bitbucket.org/acme/widget/pull-requests/abc",
+		"",
+	} {
+		if _, _, _, err := parseBitbucketPRURL(raw); err == nil {
+			t.Errorf("%q: expected error", raw)
+		}
+	}
+}
+
+func TestAccessProblem(t *testing.T) {
+	ref := prRef{Host: hostBitbucket, Workspace: "acme", Repo: "widget", ID: 42}
+	for _, tc := range []struct {
+		perm      string
+		needWrite bool
+		ok        bool
+	}{
+		{"read", false, true},
+		{"", false, true},
+		{"write", true, true},
+		{"admin", true, true},
+		{"read", true, false},
+		{"", true, false},
+	} {
+		err := accessProblem(ref, tc.perm, tc.needWrite)
+		if (err == nil) != tc.ok {
+			t.Errorf("accessProblem(%q, %v) = %v", tc.perm, tc.needWrite, err)
+		}
+	}
+}
+
+func TestBuildPRComment(t *testing.T) {
+	nc, err := buildPRComment("  nit  ", "a.go", 3, 0)
+	if err != nil || nc.Raw != "nit" || nc.Path != "a.go" || nc.Line != 3 {
+		t.Errorf("inline: %+v err=%v", nc, err)
+	}
+	if _, err := buildPRComment("ok", "", 0, 12); err != nil {
+		t.Errorf("reply: %v", err)
+	}
+
+	for _, tc := range []struct {
+		msg, file   string
+		line, reply int
+	}{
+		{"", "", 0, 0},
+		{"x", "a.go", 0, 0},
+		{"x", "", 4, 0},
+		{"x", "", 0, -1},
+	} {
+		if _, err := buildPRComment(tc.msg, tc.file, tc.line, tc.reply); err == nil {
+			t.Errorf("%+v: expected error", tc)
+		}
+	}
 }
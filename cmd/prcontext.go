@@ -0,0 +1,145 @@
+package cmd
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"os"
+	"path"
+	"path/filepath"
+	"strings"
+
+	"github.com/erickhilda/atlit/internal/bitbucket"
+	"github.com/erickhilda/atlit/internal/config"
+)
+
+// contextFileMaxBytes caps each context file when --max-file-bytes is not
+// given, so a changed asset or data dump does not land on disk whole.
+const contextFileMaxBytes = 1 << 20
+
+// contextDirName is the directory, beside the PR file, holding the full
+// contents of its changed files: new/<path> at the source commit and
+// old/<path> at the destination commit.
+func contextDirName(key string) string {
+	return key + ".context"
+}
+
+// contextVersion is one version of a changed file to fetch.
+type contextVersion struct {
+	side   string // "new" or "old"
+	commit string
+	path   string
+}
+
+// contextVersions lists the file versions worth fetching for a diffstat
+// entry: the new file unless it was removed, the old one unless it was added.
+func contextVersions(e bitbucket.DiffstatEntry, source, destination string) []contextVersion {
+	var out []contextVersion
+	if e.New != nil && e.New.Path != "" && e.Status != "removed" {
+		out = append(out, contextVersion{side: "new", commit: source, path: e.New.Path})
+	}
+	if e.Old != nil && e.Old.Path != "" && e.Status != "added" {
+		out = append(out, contextVersion{side: "old", commit: destination, path: e.Old.Path})
+	}
+	return out
+}
+
+// safeRepoPath reports whether a repo path from the API can be written under
+// the context directory without escaping it.
+func safeRepoPath(p string) bool {
+	clean := path.Clean(p)
+	return clean != "." && !path.IsAbs(clean) && clean != ".." && !strings.HasPrefix(clean, "../")
+}
+
+// contextSkipReason says why fetched file contents are not kept, or "".
+func contextSkipReason(data []byte, maxBytes int) string {
+	if maxBytes <= 0 {
+		maxBytes = contextFileMaxBytes
+	}
+	switch {
+	case len(data) > maxBytes:
+		return fmt.Sprintf("%d KB is over the size limit", (len(data)+1023)/1024)
+	case bytes.IndexByte(data, 0) >= 0:
+		return "binary"
+	}
+	return ""
+}
+
+// appendNote adds to a diffstat entry's note.
+func appendNote(note, more string) string {
+	if note == "" {
+		return more
+	}
+	return note + "; " + more
+}
+
+// writeContextFiles fetches the full contents of the PR's changed files (both
+// sides, subject to the include/exclude globs, generated-file patterns and
+// size limit of o) into the PR's context directory, replacing what an earlier
+// fetch left there, and links each version from the diffstat. With dryRun
+// nothing is fetched or written.
+func writeContextFiles(client *bitbucket.Client, prsDir, key string, ref prRef, pr *bitbucket.PullRequest, diffstat []bitbucket.DiffstatEntry, o diffOptions, dryRun bool) ([]bitbucket.DiffstatEntry, error) {
+	source, destination := pr.Source.Commit.Hash, pr.Destination.Commit.Hash
+	if source == "" || destination == "" {
+		return nil, fmt.Errorf("%s: the PR's commits are unknown, so file contents cannot be fetched", ref)
+	}
+	rel := contextDirName(key)
+	dir, err := config.ExpandPath(filepath.Join(prsDir, rel))
+	if err != nil {
+		return nil, err
+	}
+	if !dryRun {
+		if err := os.RemoveAll(dir); err != nil {
+			return nil, fmt.Errorf("clearing %s: %w", dir, err)
+		}
+	}
+
+	out := append([]bitbucket.DiffstatEntry(nil), diffstat...)
+	pathOnly := diffOptions{include: o.include, exclude: o.exclude}
+	saved := 0
+	for i, e := range out {
+		var parts []string
+		for _, v := range contextVersions(e, source, destination) {
+			if !safeRepoPath(v.path) || pathOnly.omitReason(fileDiff{Path: v.path}) != "" {
+				continue
+			}
+			target := path.Join(rel, v.side, path.Clean(v.path))
+			if dryRun {
+				parts = append(parts, fmt.Sprintf("[%s](%s)", v.side, target))
+				saved++
+				continue
+			}
+			data, err := client.GetFileContent(ref.Workspace, ref.Repo, v.commit, v.path)
+			if errors.Is(err, bitbucket.ErrNotFound) {
+				parts = append(parts, v.side+" not found")
+				continue
+			}
+			if err != nil {
+				return nil, fmt.Errorf("fetching %s at %s: %w", v.path, v.commit, err)
+			}
+			if reason := contextSkipReason(data, o.maxFileBytes); reason != "" {
+				parts = append(parts, v.side+" omitted: "+reason)
+				continue
+			}
+			local := filepath.Join(dir, v.side, filepath.FromSlash(path.Clean(v.path)))
+			if err := os.MkdirAll(filepath.Dir(local), 0755); err != nil {
+				return nil, err
+			}
+			if err := os.WriteFile(local, data, 0644); err != nil {
+				return nil, fmt.Errorf("saving %s: %w", v.path, err)
+			}
+			parts = append(parts, fmt.Sprintf("[%s](%s)", v.side, target))
+			saved++
+		}
+		if len(parts) > 0 {
+			out[i].Note = appendNote(e.Note, "context: "+strings.Join(parts, ", "))
+		}
+	}
+
+	verb := "Saved"
+	if dryRun {
+		verb = "Would save"
+	}
+	fmt.Printf("%s %d context file(s) to %s\n", verb, saved, dir)
+	return out, nil
+}
@@ -0,0 +1,84 @@
+package cmd
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/erickhilda/atlit/internal/bitbucket"
+)
+
+func TestContextVersions(t *testing.T) {
+	f := func(p string) *bitbucket.DiffFile { return &bitbucket.DiffFile{Path: p} }
+	cases := []struct {
+		entry bitbucket.DiffstatEntry
+		want  string
+	}{
+		{bitbucket.DiffstatEntry{Status: "modified", Old: f("a.go"), New: f("a.go")}, "new@src:a.go,old@dst:a.go"},
+		{bitbucket.DiffstatEntry{Status: "added", New: f("b.go")}, "new@src:b.go"},
+		{bitbucket.DiffstatEntry{Status: "removed", Old: f("c.go"), New: f("c.go")}, "old@dst:c.go"},
+		{bitbucket.DiffstatEntry{Status: "renamed", Old: f("d.go"), New: f("pkg/d.go")}, "new@src:pkg/d.go,old@dst:d.go"},
+	}
+	for _, tc := range cases {
+		var got []string
+		for _, v := range contextVersions(tc.entry, "src", "dst") {
+			got = append(got, v.side+"@"+v.commit+":"+v.path)
+		}
+		if strings.Join(got, ",") != tc.want {
+			t.Errorf("%s: got %v, want %s", tc.entry.Status, got, tc.want)
+		}
+	}
+}
+
+func TestSafeRepoPath(t *testing.T) {
+	for p, want := range map[string]bool{
+		"a.go": true, "cmd/x/y.go": true, "a/../b.go": true,
+		"../etc/passwd": false, "a/../../b": false, "/abs": false, "": false, "..": false,
+	} {
+		if got := safeRepoPath(p); got != want {
+			t.Errorf("safeRepoPath(%q) = %v, want %v", p, got, want)
+		}
+	}
+}
+
+func TestContextSkipReason(t *testing.T) {
+	if r := contextSkipReason([]byte("package x\n"), 0); r != "" {
+		t.Errorf("text file skipped: %q", r)
+	}
+	if r := contextSkipReason([]byte("PNG\x00\x01"), 0); r != "binary" {
+		t.Errorf("binary = %q", r)
+	}
+	if r := contextSkipReason(make([]byte, 3000), 2048); r != "3 KB is over the size limit" {
+		t.Errorf("too large = %q", r)
+	}
+}
+
+func TestWriteContextFilesDryRun(t *testing.T) {
+	dir := t.TempDir()
+	pr := &bitbucket.PullRequest{}
+	pr.Source.Commit.Hash, pr.Destination.Commit.Hash = "aaa", "bbb"
+	diffstat := []bitbucket.DiffstatEntry{
+		{Status: "modified", Old: &bitbucket.DiffFile{Path: "cmd/a.go"}, New: &bitbucket.DiffFile{Path: "cmd/a.go"}, Note: "diff omitted: excluded"},
+		{Status: "modified", Old: &bitbucket.DiffFile{Path: "go.sum"}, New: &bitbucket.DiffFile{Path: "go.sum"}},
+		{Status: "added", New: &bitbucket.DiffFile{Path: "docs/x.md"}},
+	}
+	ref := prRef{Host: hostBitbucket, Workspace: "acme", Repo: "widget", ID: 42}
+	got, err := writeContextFiles(nil, dir, ref.fileKey(), ref, pr, diffstat, diffOptions{exclude: []string{"docs/**"}}, true)
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := "diff omitted: excluded; context: [new](acme__widget__42.context/new/cmd/a.go), [old](acme__widget__42.context/old/cmd/a.go)"
+	if got[0].Note != want {
+		t.Errorf("note = %q, want %q", got[0].Note, want)
+	}
+	if got[1].Note != "" || got[2].Note != "" {
+		t.Errorf("generated and excluded files should get no context: %q, %q", got[1].Note, got[2].Note)
+	}
+	if diffstat[0].Note != "diff omitted: excluded" {
+		t.Error("input diffstat was modified")
+	}
+	if _, err := os.Stat(filepath.Join(dir, "acme__widget__42.context")); !os.IsNotExist(err) {
+		t.Errorf("dry run wrote files: %v", err)
+	}
+}
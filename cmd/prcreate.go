@@ -0,0 +1,217 @@
+package cmd
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"os/exec"
+	"strings"
+
+	"github.com/erickhilda/atlit/internal/bitbucket"
+	"github.com/erickhilda/atlit/internal/config"
+	"github.com/erickhilda/atlit/internal/store"
+	"github.com/spf13/cobra"
+)
+
+var prCreateCmd = &cobra.Command{
+	Use:   "create",
+	Short: "Open a Bitbucket pull request from the current branch",
+	Long: `Opens a Bitbucket Cloud pull request from the current git branch, in the
+workspace/repo of the origin remote. The Jira key in the branch name picks the
+local ticket file (see 'atlit pull'): the PR title is "<KEY>: <summary>" and the
+description is the ticket's "## Technical Requirements" section plus a link to
+the issue. The repository's default reviewers are added and the new PR is
+opened in the browser.
+
+The branch must already be pushed to origin.
+
+  atlit pr create
+  atlit pr create --destination develop --close-source-branch
+  atlit pr create --title "Retry failed imports" --no-open
+  atlit pr create --dry-run        # print the request payload only`,
+	Args: cobra.NoArgs,
+	RunE: runPRCreate,
+}
+
+func init() {
+	prCreateCmd.Flags().String("title", "", "PR title (default: <KEY>: <ticket summary>)")
+	prCreateCmd.Flags().String("description", "", "PR description (default: the ticket's Technical Requirements)")
+	prCreateCmd.Flags().String("destination", "", "Target branch (default: the repository's main branch)")
+	prCreateCmd.Flags().Bool("no-default-reviewers", false, "Do not add the repository's default reviewers")
+	prCreateCmd.Flags().Bool("close-source-branch", false, "Delete the source branch when the PR is merged")
+	prCreateCmd.Flags().Bool("no-open", false, "Do not open the new PR in the browser")
+	prCreateCmd.Flags().Bool("dry-run", false, "Print the request payload without creating the PR")
+	prCmd.AddCommand(prCreateCmd)
+}
+
+// gitCurrentBranch returns the branch checked out in the working directory.
+func gitCurrentBranch() (string, error) {
+	out, err := exec.Command("git", "rev-parse", "--abbrev-ref", "HEAD").Output()
+	if err != nil {
+		return "", errors.New("not in a git repository")
+	}
+	branch := strings.TrimSpace(string(out))
+	if branch == "HEAD" {
+		return "", errors.New("HEAD is detached; check out the branch to open a PR from")
+	}
+	return branch, nil
+}
+
+// ticketSummary returns the summary from a ticket file's "# KEY: Summary"
+// heading.
+func ticketSummary(content, key string) string {
+	for _, line := range strings.Split(content, "\n") {
+		if strings.HasPrefix(line, "# ") {
+			return strings.TrimSpace(strings.TrimPrefix(strings.TrimPrefix(line, "# "), key+":"))
+		}
+	}
+	return ""
+}
+
+// branchTitle turns a branch name into a title when there is no ticket file:
+// "feature/PROJ-12-retry-imports" becomes "retry imports".
+func branchTitle(branch, key string) string {
+	name := branch[strings.LastIndex(branch, "/")+1:]
+	if key != "" {
+		name = strings.Replace(name, key, "", 1)
+	}
+	name = strings.NewReplacer("-", " ", "_", " ").Replace(name)
+	return strings.Join(strings.Fields(name), " ")
+}
+
+// prDraft builds the title and description of a new PR from the branch, its
+// Jira key and the local ticket file content (empty when there is none).
+func prDraft(branch, key, ticket, instance string) (title, description string) {
+	summary := ""
+	if ticket != "" {
+		summary = ticketSummary(ticket, key)
+	}
+	if summary == "" {
+		summary = branchTitle(branch, key)
+	}
+	switch {
+	case key == "":
+		title = summary
+	case summary == "":
+		title = key
+	default:
+		title = key + ": " + summary
+	}
+
+	var parts []string
+	const heading = "## Technical Requirements"
+	if section := store.ExtractSection(ticket, heading); section != "" {
+		if body := strings.TrimSpace(sectionBody(section, heading)); body != "" {
+			parts = append(parts, body)
+		}
+	}
+	if key != "" && instance != "" {
+		parts = append(parts, fmt.Sprintf("Jira: [%s](%s/browse/%s)", key, strings.TrimRight(instance, "/"), key))
+	}
+	return title, strings.Join(parts, "\n\n")
+}
+
+// defaultReviewerUUIDs lists the default reviewers to add, leaving out the
+// author (Bitbucket rejects a PR that names its author as reviewer).
+func defaultReviewerUUIDs(reviewers []bitbucket.Account, me string) []string {
+	var out []string
+	for _, r := range reviewers {
+		if r.UUID != "" && r.UUID != me {
+			out = append(out, r.UUID)
+		}
+	}
+	return out
+}
+
+func runPRCreate(cmd *cobra.Command, _ []string) error {
+	title, _ := cmd.Flags().GetString("title")
+	description, _ := cmd.Flags().GetString("description")
+	destination, _ := cmd.Flags().GetString("destination")
+	noReviewers, _ := cmd.Flags().GetBool("no-default-reviewers")
+	closeSource, _ := cmd.Flags().GetBool("close-source-branch")
+	noOpen, _ := cmd.Flags().GetBool("no-open")
+	dryRun, _ := cmd.Flags().GetBool("dry-run")
+
+	cfg, err := config.Load()
+	if err != nil {
+		return err
+	}
+	workspace, repo, err := inferFromGitRemote()
+	if err != nil {
+		return fmt.Errorf("not in a Bitbucket repo (%v); run 'atlit pr create' inside the repo", err)
+	}
+	branch, err := gitCurrentBranch()
+	if err != nil {
+		return err
+	}
+	if destination != "" && destination == branch {
+		return fmt.Errorf("source and destination are both %s", branch)
+	}
+
+	key := jiraKeyRe.FindString(branch)
+	ticket := ""
+	if p := localTicketPath(cfg, key); p != "" {
+		data, err := os.ReadFile(p)
+		if err != nil {
+			return err
+		}
+		ticket = string(data)
+	} else if key != "" {
+		fmt.Fprintf(os.Stderr, "note: no local ticket file for %s; run 'atlit pull %s' to use its summary\n", key, key)
+	}
+	draftTitle, draftDescription := prDraft(branch, key, ticket, cfg.Instance)
+	np := bitbucket.NewPullRequest{
+		Title:             firstNonEmpty(strings.TrimSpace(title), draftTitle),
+		Description:       firstNonEmpty(strings.TrimSpace(description), draftDescription),
+		Source:            branch,
+		Destination:       destination,
+		CloseSourceBranch: closeSource,
+	}
+	if np.Title == "" {
+		return errors.New("no title: pass --title")
+	}
+
+	token, err := config.GetBitbucketToken(cfg)
+	if err != nil {
+		return fmt.Errorf("retrieving Bitbucket token (run 'atlit auth bitbucket'): %w", err)
+	}
+	client := bitbucket.NewClient(cfg.Email, token)
+	if !noReviewers {
+		me, err := client.CurrentUser()
+		if err != nil {
+			return wrapBBListError(err, workspace, repo)
+		}
+		reviewers, err := client.DefaultReviewers(workspace, repo)
+		if err != nil {
+			return wrapBBListError(err, workspace, repo)
+		}
+		np.Reviewers = defaultReviewerUUIDs(reviewers, me.UUID)
+	}
+
+	if dryRun {
+		fmt.Printf("Would create a PR in %s/%s:\n\n", workspace, repo)
+		out, _ := json.MarshalIndent(np.Payload(), "", "  ")
+		fmt.Println(string(out))
+		return nil
+	}
+
+	pr, err := client.CreatePullRequest(workspace, repo, np)
+	if err != nil {
+		var apiErr *bitbucket.APIError
+		if errors.As(err, &apiErr) && apiErr.StatusCode == 400 {
+			return fmt.Errorf("%w (is %s pushed? try 'git push -u origin %s')", err, branch, branch)
+		}
+		return wrapBBListError(err, workspace, repo)
+	}
+	fmt.Printf("Created PR #%d: %s -> %s (%d reviewer(s))\n", pr.ID, pr.Source.Branch.Name, pr.Destination.Branch.Name, len(np.Reviewers))
+	url := pr.Links.HTML.Href
+	if url == "" {
+		url = fmt.Sprintf("https://bitbucket.org/%s/%s/pull-requests/%d", workspace, repo, pr.ID)
+	}
+	fmt.Println(url)
+	if noOpen {
+		return nil
+	}
+	return openBrowser(url)
+}
@@ -0,0 +1,43 @@
+package cmd
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/erickhilda/atlit/internal/bitbucket"
+)
+
+func TestPRDraftFromTicket(t *testing.T) {
+	ticket := "<!-- atlit:meta key=PROJ-12 fetched=2026-06-10T12:00:00Z -->\n# PROJ-12: Retry failed imports\n\n" +
+		"## Description\n\nImports fail.\n\n## Technical Requirements\n\n- back off\n- cap at 5\n\n## My Notes\n\nlocal\n"
+	title, desc := prDraft("feature/PROJ-12-retry", "PROJ-12", ticket, "https://acme.atlassian.net/")
+	if title != "PROJ-12: Retry failed imports" {
+		t.Errorf("title = %q", title)
+	}
+	want := "- back off\n- cap at 5\n\nJira: [PROJ-12](https://acme.atlassian.net/browse/PROJ-12)"
+	if desc != want {
+		t.Errorf("description = %q, want %q", desc, want)
+	}
+}
+
+func TestPRDraftWithoutTicket(t *testing.T) {
+	title, desc := prDraft("feature/PROJ-12-retry-failed_imports", "PROJ-12", "", "")
+	if title != "PROJ-12: retry failed imports" || desc != "" {
+		t.Errorf("got %q / %q", title, desc)
+	}
+	title, _ = prDraft("fix-typo", "", "", "https://acme.atlassian.net")
+	if title != "fix typo" {
+		t.Errorf("no key: title = %q", title)
+	}
+	title, _ = prDraft("PROJ-3", "PROJ-3", "", "")
+	if title != "PROJ-3" {
+		t.Errorf("key only: title = %q", title)
+	}
+}
+
+func TestDefaultReviewerUUIDs(t *testing.T) {
+	got := defaultReviewerUUIDs([]bitbucket.Account{{UUID: "{a}"}, {UUID: "{me}"}, {UUID: "{b}"}}, "{me}")
+	if strings.Join(got, ",") != "{a},{b}" {
+		t.Errorf("reviewers = %v", got)
+	}
+}
@@ -0,0 +1,205 @@
+package cmd
+
+import (
+	"fmt"
+	"os"
+	"path"
+	"path/filepath"
+	"regexp"
+	"strings"
+
+	"github.com/erickhilda/atlit/internal/bitbucket"
+	"github.com/erickhilda/atlit/internal/config"
+	"github.com/erickhilda/atlit/internal/renderer"
+	"github.com/erickhilda/atlit/internal/store"
+	"github.com/erickhilda/atlit/internal/unidiff"
+)
+
+// generatedPatterns are files whose diffs are noise for a reviewer: lockfiles,
+// minified or compiled assets, snapshots and generated code. They are left out
+// whenever diff filtering is in use, unless --include names them.
+var generatedPatterns = []string{
+	"go.sum", "package-lock.json", "yarn.lock", "pnpm-lock.yaml", "*.lock",
+	"*.min.js", "*.min.css", "*.map", "*.snap",
+	"*.pb.go", "*_generated.go", "*.generated.*", "*_pb2.py",
+	"vendor/**", "node_modules/**",
+}
+
+// diffOptions are the `atlit pr` flags that filter or split the diff.
+type diffOptions struct {
+	include, exclude []string
+	maxFileBytes     int
+	split            bool
+}
+
+// active reports whether any filtering or splitting was asked for; without it
+// the diff is embedded as fetched.
+func (o diffOptions) active() bool {
+	return len(o.include) > 0 || len(o.exclude) > 0 || o.maxFileBytes > 0 || o.split
+}
+
+// fileDiff is one file's section of a unified diff.
+type fileDiff struct {
+	Path string
+	Text string
+}
+
+// splitDiff cuts a unified diff into per-file sections at "diff --git" lines.
+// A file is named by its new path, or its old path when deleted.
+func splitDiff(diff string) []fileDiff {
+	var out []fileDiff
+	for _, f := range unidiff.Parse(diff) {
+		out = append(out, fileDiff{Path: f.Path(), Text: f.Text})
+	}
+	return out
+}
+
+// matchGlob reports whether p matches pattern. A pattern without "/" is
+// matched against the file name ("*.go", "go.sum"); otherwise against the
+// whole path, where "**" spans directories ("vendor/**", "**/testdata/*").
+func matchGlob(pattern, p string) bool {
+	pattern = strings.TrimPrefix(strings.TrimSpace(pattern), "/")
+	if pattern == "" {
+		return false
+	}
+	if !strings.Contains(pattern, "/") {
+		ok, _ := path.Match(pattern, path.Base(p))
+		return ok
+	}
+	var re strings.Builder
+	re.WriteString("^")
+	for i := 0; i < len(pattern); i++ {
+		switch c := pattern[i]; {
+		case strings.HasPrefix(pattern[i:], "**/"):
+			re.WriteString("(?:.*/)?")
+			i += 2
+		case strings.HasPrefix(pattern[i:], "**"):
+			re.WriteString(".*")
+			i++
+		case c == '*':
+			re.WriteString("[^/]*")
+		case c == '?':
+			re.WriteString("[^/]")
+		default:
+			re.WriteString(regexp.QuoteMeta(string(c)))
+		}
+	}
+	re.WriteString("$")
+	ok, _ := regexp.MatchString(re.String(), p)
+	return ok
+}
+
+func matchAny(patterns []string, p string) bool {
+	for _, pat := range patterns {
+		if matchGlob(pat, p) {
+			return true
+		}
+	}
+	return false
+}
+
+// omitReason says why a file's diff is left out, or "" to keep it.
+func (o diffOptions) omitReason(f fileDiff) string {
+	included := len(o.include) > 0 && matchAny(o.include, f.Path)
+	switch {
+	case len(o.include) > 0 && !included:
+		return "diff omitted: not in --include"
+	case matchAny(o.exclude, f.Path):
+		return "diff omitted: excluded"
+	case !included && matchAny(generatedPatterns, f.Path):
+		return "diff omitted: generated"
+	case o.maxFileBytes > 0 && len(f.Text) > o.maxFileBytes:
+		return fmt.Sprintf("diff omitted: %d KB is over --max-file-bytes", (len(f.Text)+1023)/1024)
+	}
+	return ""
+}
+
+// filteredDiff is the result of applying diffOptions to a PR's diff.
+type filteredDiff struct {
+	Kept     []fileDiff
+	Diffstat []bitbucket.DiffstatEntry // copy annotated with per-file notes
+}
+
+// Diff joins the kept sections back into one unified diff.
+func (f filteredDiff) Diff() string {
+	var b strings.Builder
+	for _, fd := range f.Kept {
+		b.WriteString(fd.Text)
+	}
+	return b.String()
+}
+
+// filterDiff splits diff by file and drops what o leaves out, noting each
+// omitted file on a copy of the diffstat (which always lists every file).
+// Files that are in the diff but not the diffstat get an entry of their own.
+func filterDiff(diff string, diffstat []bitbucket.DiffstatEntry, o diffOptions) filteredDiff {
+	res := filteredDiff{Diffstat: append([]bitbucket.DiffstatEntry(nil), diffstat...)}
+	index := map[string]int{}
+	for i, e := range res.Diffstat {
+		index[e.Path()] = i
+	}
+	for _, f := range splitDiff(diff) {
+		i, ok := index[f.Path]
+		if !ok {
+			i = len(res.Diffstat)
+			index[f.Path] = i
+			res.Diffstat = append(res.Diffstat, bitbucket.DiffstatEntry{New: &bitbucket.DiffFile{Path: f.Path}})
+		}
+		if reason := o.omitReason(f); reason != "" {
+			res.Diffstat[i].Note = reason
+			continue
+		}
+		res.Kept = append(res.Kept, f)
+	}
+	return res
+}
+
+// splitFileName is the per-file markdown name (without .md) for a path.
+func splitFileName(p string) string {
+	return strings.ReplaceAll(p, "/", "__")
+}
+
+// splitDirName is the directory, beside the PR file, holding its per-file
+// diffs.
+func splitDirName(key string) string {
+	return key + ".files"
+}
+
+// writeSplitFiles writes one markdown file per kept diff into the PR's split
+// directory, replacing what a previous --split left there, and links each
+// file from the diffstat. With dryRun it only reports what would be written.
+func writeSplitFiles(prsDir, key string, ref prRef, d *prData, fd *filteredDiff, dryRun bool) error {
+	dir := filepath.Join(prsDir, splitDirName(key))
+	if dryRun {
+		fmt.Printf("Would write %d per-file diffs to %s\n", len(fd.Kept), dir)
+	} else {
+		expanded, err := config.ExpandPath(dir)
+		if err != nil {
+			return err
+		}
+		old, _ := filepath.Glob(filepath.Join(expanded, "*.md"))
+		for _, f := range old {
+			if err := os.Remove(f); err != nil {
+				return fmt.Errorf("clearing %s: %w", dir, err)
+			}
+		}
+	}
+
+	index := map[string]int{}
+	for i, e := range fd.Diffstat {
+		index[e.Path()] = i
+	}
+	for _, f := range fd.Kept {
+		name := splitFileName(f.Path)
+		entry := fd.Diffstat[index[f.Path]]
+		fd.Diffstat[index[f.Path]].Note = fmt.Sprintf("[diff](%s/%s.md)", splitDirName(key), name)
+		if dryRun {
+			continue
+		}
+		content := renderer.RenderPullRequestFile(d.PR, ref.Workspace, ref.Repo, entry, "../"+key+".md", f.Text)
+		if err := store.Save(dir, name, content); err != nil {
+			return fmt.Errorf("saving %s diff: %w", f.Path, err)
+		}
+	}
+	return nil
+}
@@ -0,0 +1,148 @@
+package cmd
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/erickhilda/atlit/internal/bitbucket"
+)
+
+const prDiffTestDiff = "diff --git a/cmd/main.go b/cmd/main.go\n" +
+	"--- a/cmd/main.go\n" +
+	"+++ b/cmd/main.go\n" +
+	"@@ -1,2 +1,2 @@\n" +
+	"--- old comment\n" +
+	"+++ new comment\n" +
+	"diff --git a/go.sum b/go.sum\n" +
+	"--- a/go.sum\n" +
+	"+++ b/go.sum\n" +
+	"@@ -1 +1 @@\n" +
+	"-a v1\n" +
+	"+a v2\n" +
+	"diff --git a/vendor/x/x.go b/vendor/x/x.go\n" +
+	"deleted file mode 100644\n" +
+	"--- a/vendor/x/x.go\n" +
+	"+++ /dev/null\n" +
+	"@@ -1 +0,0 @@\n" +
+	"-package x\n" +
+	"diff --git a/logo.png b/logo.png\n" +
+	"Binary files a/logo.png and b/logo.png differ\n"
+
+func TestSplitDiff(t *testing.T) {
+	files := splitDiff(prDiffTestDiff)
+	var paths []string
+	for _, f := range files {
+		paths = append(paths, f.Path)
+	}
+	if got := strings.Join(paths, ","); got != "cmd/main.go,go.sum,vendor/x/x.go,logo.png" {
+		t.Errorf("paths = %s", got)
+	}
+	if !strings.HasSuffix(files[0].Text, "+++ new comment\n") || strings.Contains(files[0].Text, "go.sum") {
+		t.Errorf("first section = %q", files[0].Text)
+	}
+	var joined strings.Builder
+	for _, f := range files {
+		joined.WriteString(f.Text)
+	}
+	if joined.String() != prDiffTestDiff {
+		t.Error("sections should join back into the original diff")
+	}
+}
+
+func TestMatchGlob(t *testing.T) {
+	cases := []struct {
+		pattern, path string
+		want          bool
+	}{
+		{"*.go", "internal/parse.go", true},
+		{"*.go", "internal/parse.gox", false},
+		{"vendor/**", "vendor/a/b.go", true},
+		{"vendor/**", "internal/vendor/b.go", false},
+		{"**/testdata/*", "a/b/testdata/x.json", true},
+		{"**/testdata/*", "testdata/x.json", true},
+		{"cmd/*.go", "cmd/sub/x.go", false},
+		{"*.lock", "Cargo.lock", true},
+	}
+	for _, tc := range cases {
+		if got := matchGlob(tc.pattern, tc.path); got != tc.want {
+			t.Errorf("matchGlob(%q, %q) = %v, want %v", tc.pattern, tc.path, got, tc.want)
+		}
+	}
+}
+
+func TestFilterDiff(t *testing.T) {
+	diffstat := []bitbucket.DiffstatEntry{
+		{LinesAdded: 1, LinesRemoved: 1, New: &bitbucket.DiffFile{Path: "cmd/main.go"}},
+		{LinesAdded: 1, LinesRemoved: 1, New: &bitbucket.DiffFile{Path: "go.sum"}},
+		{LinesRemoved: 1, Old: &bitbucket.DiffFile{Path: "vendor/x/x.go"}},
+	}
+
+	fd := filterDiff(prDiffTestDiff, diffstat, diffOptions{exclude: []string{"*.png"}, maxFileBytes: 1 << 20})
+	if len(fd.Kept) != 1 || fd.Kept[0].Path != "cmd/main.go" {
+		t.Errorf("kept = %+v", fd.Kept)
+	}
+	notes := map[string]string{}
+	for _, e := range fd.Diffstat {
+		notes[e.Path()] = e.Note
+	}
+	want := map[string]string{
+		"cmd/main.go":   "",
+		"go.sum":        "diff omitted: generated",
+		"vendor/x/x.go": "diff omitted: generated",
+		"logo.png":      "diff omitted: excluded",
+	}
+	for p, n := range want {
+		if notes[p] != n {
+			t.Errorf("note for %s = %q, want %q", p, notes[p], n)
+		}
+	}
+	if diffstat[1].Note != "" {
+		t.Error("filterDiff should not modify the caller's diffstat")
+	}
+
+	fd = filterDiff(prDiffTestDiff, diffstat, diffOptions{include: []string{"go.sum"}})
+	if len(fd.Kept) != 1 || fd.Kept[0].Path != "go.sum" {
+		t.Errorf("--include should override the generated list: %+v", fd.Kept)
+	}
+
+	fd = filterDiff(prDiffTestDiff, diffstat, diffOptions{maxFileBytes: 10})
+	if len(fd.Kept) != 0 || !strings.Contains(fd.Diffstat[0].Note, "over --max-file-bytes") {
+		t.Errorf("oversized file kept: %+v", fd.Diffstat[0])
+	}
+}
+
+func TestWriteSplitFiles(t *testing.T) {
+	dir := t.TempDir()
+	key := "acme__widget__42"
+	stale := filepath.Join(dir, key+".files", "gone.go.md")
+	if err := os.MkdirAll(filepath.Dir(stale), 0755); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(stale, []byte("old"), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	ref := prRef{Host: hostBitbucket, Workspace: "acme", Repo: "widget", ID: 42}
+	d := &prData{PR: &bitbucket.PullRequest{ID: 42, Title: "Retries"}}
+	fd := filterDiff(prDiffTestDiff, nil, diffOptions{split: true})
+	if err := writeSplitFiles(dir, key, ref, d, &fd, false); err != nil {
+		t.Fatalf("writeSplitFiles: %v", err)
+	}
+
+	if _, err := os.Stat(stale); !os.IsNotExist(err) {
+		t.Error("stale per-file diff should be removed")
+	}
+	data, err := os.ReadFile(filepath.Join(dir, key+".files", "cmd__main.go.md"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !strings.Contains(string(data), "Part of [PR #42: Retries](../acme__widget__42.md)") ||
+		!strings.Contains(string(data), "+++ new comment") {
+		t.Errorf("per-file diff:\n%s", data)
+	}
+	if fd.Diffstat[0].Note != "[diff](acme__widget__42.files/cmd__main.go.md)" {
+		t.Errorf("index note = %q", fd.Diffstat[0].Note)
+	}
+}
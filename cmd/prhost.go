@@ -0,0 +1,647 @@
+package cmd
+
+import (
+	"errors"
+	"fmt"
+	"net/url"
+	"os/exec"
+	"sort"
+	"strings"
+
+	"github.com/erickhilda/atlit/internal/bitbucket"
+	"github.com/erickhilda/atlit/internal/config"
+	"github.com/erickhilda/atlit/internal/github"
+	"github.com/erickhilda/atlit/internal/gitlab"
+)
+
+// Code hosts `atlit pr` can fetch from.
+const (
+	hostBitbucket = "bitbucket"
+	hostGitHub    = "github"
+	hostGitLab    = "gitlab"
+)
+
+// prRef identifies a pull request on any supported host. Server is the
+// GitHub/GitLab host name (github.com, a GitHub Enterprise host, ...); for
+// GitLab, Workspace is the group path, which may be nested ("group/sub").
+type prRef struct {
+	Host      string
+	Server    string
+	Workspace string
+	Repo      string
+	ID        int
+}
+
+// String renders the ref the way messages show it: "acme/widget#42".
+func (r prRef) String() string {
+	return fmt.Sprintf("%s/%s#%d", r.Workspace, r.Repo, r.ID)
+}
+
+// arg renders a Bitbucket ref the way resolvePRRef reads it back, for
+// suggested commands: "acme/widget/42".
+func (r prRef) arg() string {
+	return fmt.Sprintf("%s/%s/%d", r.Workspace, r.Repo, r.ID)
+}
+
+// fileKey is the PR's file name (without .md) under prs_dir. Bitbucket keeps
+// the original "<workspace>__<repo>__<id>" layout; other hosts are prefixed
+// so the same owner/repo/id on two hosts cannot collide.
+func (r prRef) fileKey() string {
+	if r.Host == hostBitbucket {
+		return prFileKey(r.Workspace, r.Repo, r.ID)
+	}
+	return r.Host + "__" + prFileKey(strings.ReplaceAll(r.Workspace, "/", "__"), r.Repo, r.ID)
+}
+
+// hostFor classifies a git server host name, or returns "" when it is not a
+// known Bitbucket, GitHub or GitLab host.
+func hostFor(server string, cfg *config.Config) string {
+	server = strings.ToLower(server)
+	githubHost, gitlabHost := configuredHost(cfg.GitHubHost), configuredHost(cfg.GitLabHost)
+	switch {
+	case server == "bitbucket.org":
+		return hostBitbucket
+	case server == github.PublicHost || (githubHost != "" && server == githubHost):
+		return hostGitHub
+	case server == gitlab.PublicHost || (gitlabHost != "" && server == gitlabHost):
+		return hostGitLab
+	}
+	return ""
+}
+
+// configuredHost normalizes a github_host / gitlab_host value the way
+// 'atlit config set' does, for config files edited by hand ("GHE.Acme.com/").
+// An unusable value matches nothing.
+func configuredHost(value string) string {
+	host, err := normalizeHost(value)
+	if err != nil {
+		return ""
+	}
+	return host
+}
+
+// parsePRURL parses a pull request URL on any supported host:
+//
+//	https://bitbucket.org/acme/widget/pull-requests/42
+//	https://github.com/acme/widget/pull/42
+//	https://gitlab.com/acme/platform/widget/-/merge_requests/42
+//
+// GitHub Enterprise and self-managed GitLab hosts must be configured with
+// github_host / gitlab_host.
+func parsePRURL(raw string, cfg *config.Config) (prRef, error) {
+	u, err := url.Parse(strings.TrimSpace(raw))
+	if err != nil || u.Host == "" {
+		return prRef{}, fmt.Errorf("invalid PR URL %q", raw)
+	}
+	server := strings.ToLower(u.Hostname())
+	segs := strings.Split(strings.Trim(u.Path, "/"), "/")
+
+	switch hostFor(server, cfg) {
+	case hostBitbucket:
+		ws, repo, id, err := parseBitbucketPRURL(raw)
+		if err != nil {
+			return prRef{}, err
+		}
+		return prRef{Host: hostBitbucket, Server: server, Workspace: ws, Repo: repo, ID: id}, nil
+	case hostGitHub:
+		if len(segs) < 4 || segs[0] == "" || segs[1] == "" || segs[2] != "pull" {
+			return prRef{}, fmt.Errorf("not a GitHub pull request URL: %s", raw)
+		}
+		id, err := parsePRID(segs[3])
+		if err != nil {
+			return prRef{}, err
+		}
+		return prRef{Host: hostGitHub, Server: server, Workspace: segs[0], Repo: segs[1], ID: id}, nil
+	case hostGitLab:
+		// <group path>/<project>/-/merge_requests/<iid>[/...]
+		for i := 2; i+2 < len(segs); i++ {
+			if segs[i] == "-" && segs[i+1] == "merge_requests" {
+				id, err := parsePRID(segs[i+2])
+				if err != nil {
+					return prRef{}, err
+				}
+				return prRef{Host: hostGitLab, Server: server,
+					Workspace: strings.Join(segs[:i-1], "/"), Repo: segs[i-1], ID: id}, nil
+			}
+		}
+		return prRef{}, fmt.Errorf("not a GitLab merge request URL: %s", raw)
+	}
+	return prRef{}, fmt.Errorf("unknown code host %q (set 'github_host' or 'gitlab_host' for self-hosted servers)", server)
+}
+
+// gitOriginURL returns the origin remote URL of the repo in the working directory.
+func gitOriginURL() (string, error) {
+	out, err := exec.Command("git", "remote", "get-url", "origin").Output()
+	if err != nil {
+		return "", fmt.Errorf("no git 'origin' remote")
+	}
+	return strings.TrimSpace(string(out)), nil
+}
+
+// parseGitRemote identifies the host, workspace (owner or group path) and repo
+// of an SSH or HTTPS remote URL. The returned ref has no ID.
+func parseGitRemote(remote string, cfg *config.Config) (prRef, error) {
+	var server, path string
+	switch {
+	case strings.Contains(remote, "://"):
+		u, err := url.Parse(remote)
+		if err != nil {
+			return prRef{}, fmt.Errorf("cannot parse remote: %s", remote)
+		}
+		server, path = u.Hostname(), u.Path
+	case strings.Contains(remote, ":"):
+		// scp-like syntax: git@host:path
+		hostPart, p, _ := strings.Cut(remote, ":")
+		if i := strings.LastIndex(hostPart, "@"); i >= 0 {
+			hostPart = hostPart[i+1:]
+		}
+		server, path = hostPart, p
+	default:
+		return prRef{}, fmt.Errorf("cannot parse remote: %s", remote)
+	}
+
+	host := hostFor(server, cfg)
+	if host == "" {
+		return prRef{}, fmt.Errorf("origin is not a Bitbucket, GitHub or GitLab remote: %s", remote)
+	}
+	segs := strings.Split(strings.TrimSuffix(strings.Trim(path, "/"), ".git"), "/")
+	for _, s := range segs {
+		if s == "" {
+			return prRef{}, fmt.Errorf("cannot parse workspace/repo from remote: %s", remote)
+		}
+	}
+	// GitLab projects may sit in nested groups; the other hosts are owner/repo.
+	if len(segs) < 2 || (host != hostGitLab && len(segs) != 2) {
+		return prRef{}, fmt.Errorf("cannot parse workspace/repo from remote: %s", remote)
+	}
+	return prRef{
+		Host:      host,
+		Server:    strings.ToLower(server),
+		Workspace: strings.Join(segs[:len(segs)-1], "/"),
+		Repo:      segs[len(segs)-1],
+	}, nil
+}
+
+// prClients creates API clients on first use, reading each host's token once
+// (`atlit pull --with-prs` may fetch several PRs).
+type prClients struct {
+	cfg *config.Config
+	bb  *bitbucket.Client
+	gh  map[string]*github.Client
+	gl  map[string]*gitlab.Client
+}
+
+func newPRClients(cfg *config.Config) *prClients {
+	return &prClients{cfg: cfg, gh: map[string]*github.Client{}, gl: map[string]*gitlab.Client{}}
+}
+
+func (c *prClients) bitbucket() (*bitbucket.Client, error) {
+	if c.bb == nil {
+		token, err := config.GetBitbucketToken(c.cfg)
+		if err != nil {
+			return nil, fmt.Errorf("retrieving Bitbucket token (run 'atlit auth bitbucket'): %w", err)
+		}
+		c.bb = bitbucket.NewClient(c.cfg.Email, token)
+	}
+	return c.bb, nil
+}
+
+func (c *prClients) github(server string) (*github.Client, error) {
+	if cl, ok := c.gh[server]; ok {
+		return cl, nil
+	}
+	token, err := config.GetGitHubToken(c.cfg)
+	if err != nil {
+		return nil, fmt.Errorf("retrieving GitHub token (run 'atlit auth github' or set GITHUB_TOKEN): %w", err)
+	}
+	c.gh[server] = github.NewClient(server, token)
+	return c.gh[server], nil
+}
+
+func (c *prClients) gitlab(server string) (*gitlab.Client, error) {
+	if cl, ok := c.gl[server]; ok {
+		return cl, nil
+	}
+	token, err := config.GetGitLabToken(c.cfg)
+	if err != nil {
+		return nil, fmt.Errorf("retrieving GitLab token (run 'atlit auth gitlab' or set GITLAB_TOKEN): %w", err)
+	}
+	c.gl[server] = gitlab.NewClient(server, token)
+	return c.gl[server], nil
+}
+
+// prData is everything RenderPullRequest needs. GitHub and GitLab payloads are
+// converted to the Bitbucket model so every host renders identically.
+type prData struct {
+	PR       *bitbucket.PullRequest
+	Diffstat []bitbucket.DiffstatEntry
+	Comments []bitbucket.Comment
+	Diff     string
+}
+
+// fetchPR loads a PR's metadata, diffstat, comments and (unless noDiff) diff
+// from its host.
+func fetchPR(clients *prClients, ref prRef, noDiff bool) (*prData, error) {
+	switch ref.Host {
+	case hostGitHub:
+		client, err := clients.github(ref.Server)
+		if err != nil {
+			return nil, err
+		}
+		d, err := fetchGitHubPR(client, ref, noDiff)
+		return d, wrapPRHostError(err, ref)
+	case hostGitLab:
+		client, err := clients.gitlab(ref.Server)
+		if err != nil {
+			return nil, err
+		}
+		d, err := fetchGitLabMR(client, ref, noDiff)
+		return d, wrapPRHostError(err, ref)
+	default:
+		client, err := clients.bitbucket()
+		if err != nil {
+			return nil, err
+		}
+		d, err := fetchBitbucketPR(client, ref, noDiff)
+		if err != nil {
+			return nil, wrapBBError(err, ref.Workspace, ref.Repo, ref.ID)
+		}
+		return d, nil
+	}
+}
+
+// fetchPRMeta loads only a PR's metadata, converted to the shared model.
+func fetchPRMeta(clients *prClients, ref prRef) (*bitbucket.PullRequest, error) {
+	switch ref.Host {
+	case hostGitHub:
+		client, err := clients.github(ref.Server)
+		if err != nil {
+			return nil, err
+		}
+		pr, err := client.GetPullRequest(ref.Workspace, ref.Repo, ref.ID)
+		if err != nil {
+			return nil, wrapPRHostError(err, ref)
+		}
+		return githubPullRequest(pr), nil
+	case hostGitLab:
+		client, err := clients.gitlab(ref.Server)
+		if err != nil {
+			return nil, err
+		}
+		mr, err := client.GetMergeRequest(ref.Workspace+"/"+ref.Repo, ref.ID)
+		if err != nil {
+			return nil, wrapPRHostError(err, ref)
+		}
+		return gitlabPullRequest(mr), nil
+	default:
+		client, err := clients.bitbucket()
+		if err != nil {
+			return nil, err
+		}
+		pr, err := client.GetPullRequest(ref.Workspace, ref.Repo, ref.ID)
+		if err != nil {
+			return nil, wrapBBError(err, ref.Workspace, ref.Repo, ref.ID)
+		}
+		return pr, nil
+	}
+}
+
+// fetchInterdiff loads the diff between two commits of a PR's source branch.
+// A commit that is gone (the branch was force-pushed and the old head
+// garbage-collected) is reported as such rather than as a missing PR.
+func fetchInterdiff(clients *prClients, ref prRef, from, to string) (string, error) {
+	var diff string
+	var err error
+	switch ref.Host {
+	case hostGitHub:
+		var client *github.Client
+		if client, err = clients.github(ref.Server); err != nil {
+			return "", err
+		}
+		diff, err = client.GetCompareDiff(ref.Workspace, ref.Repo, from, to)
+	case hostGitLab:
+		var client *gitlab.Client
+		if client, err = clients.gitlab(ref.Server); err != nil {
+			return "", err
+		}
+		var files []gitlab.FileDiff
+		if files, err = client.Compare(ref.Workspace+"/"+ref.Repo, from, to); err == nil {
+			var b strings.Builder
+			for _, fd := range files {
+				b.WriteString(fd.Unified())
+			}
+			diff = b.String()
+		}
+	default:
+		var client *bitbucket.Client
+		if client, err = clients.bitbucket(); err != nil {
+			return "", err
+		}
+		diff, err = client.GetCommitRangeDiff(ref.Workspace, ref.Repo, from, to)
+	}
+	switch {
+	case err == nil:
+		return diff, nil
+	case errors.Is(err, bitbucket.ErrNotFound), errors.Is(err, github.ErrNotFound), errors.Is(err, gitlab.ErrNotFound):
+		return "", fmt.Errorf("cannot diff %s against the previous fetch: commit %s is no longer available (was the branch force-pushed?)", ref, shortCommit(from))
+	case ref.Host == hostBitbucket:
+		return "", wrapBBError(err, ref.Workspace, ref.Repo, ref.ID)
+	default:
+		return "", wrapPRHostError(err, ref)
+	}
+}
+
+func fetchBitbucketPR(client *bitbucket.Client, ref prRef, noDiff bool) (*prData, error) {
+	pr, err := client.GetPullRequest(ref.Workspace, ref.Repo, ref.ID)
+	if err != nil {
+		return nil, err
+	}
+	d := &prData{PR: pr}
+	if d.Diffstat, err = client.GetPullRequestDiffstat(ref.Workspace, ref.Repo, ref.ID); err != nil {
+		return nil, err
+	}
+	if d.Comments, err = client.GetPullRequestComments(ref.Workspace, ref.Repo, ref.ID); err != nil {
+		return nil, err
+	}
+	if pr.Tasks, err = client.GetPullRequestTasks(ref.Workspace, ref.Repo, ref.ID); err != nil {
+		return nil, err
+	}
+	if hash := pr.Source.Commit.Hash; hash != "" {
+		if pr.Statuses, err = client.GetCommitStatuses(ref.Workspace, ref.Repo, hash); err != nil {
+			return nil, err
+		}
+	}
+	if !noDiff {
+		if d.Diff, err = client.GetPullRequestDiff(ref.Workspace, ref.Repo, ref.ID); err != nil {
+			return nil, err
+		}
+	}
+	return d, nil
+}
+
+func fetchGitHubPR(client *github.Client, ref prRef, noDiff bool) (*prData, error) {
+	owner, repo, n := ref.Workspace, ref.Repo, ref.ID
+	pr, err := client.GetPullRequest(owner, repo, n)
+	if err != nil {
+		return nil, err
+	}
+	files, err := client.GetPullRequestFiles(owner, repo, n)
+	if err != nil {
+		return nil, err
+	}
+	conversation, err := client.GetIssueComments(owner, repo, n)
+	if err != nil {
+		return nil, err
+	}
+	inline, err := client.GetReviewComments(owner, repo, n)
+	if err != nil {
+		return nil, err
+	}
+	reviews, err := client.GetReviews(owner, repo, n)
+	if err != nil {
+		return nil, err
+	}
+	d := &prData{
+		PR:       githubPullRequest(pr),
+		Diffstat: githubDiffstat(files),
+		Comments: githubComments(conversation, inline, reviews),
+	}
+	d.PR.Participants = githubParticipants(pr, reviews)
+	if !noDiff {
+		if d.Diff, err = client.GetPullRequestDiff(owner, repo, n); err != nil {
+			return nil, err
+		}
+	}
+	return d, nil
+}
+
+func fetchGitLabMR(client *gitlab.Client, ref prRef, noDiff bool) (*prData, error) {
+	project := ref.Workspace + "/" + ref.Repo
+	mr, err := client.GetMergeRequest(project, ref.ID)
+	if err != nil {
+		return nil, err
+	}
+	diffs, err := client.GetMergeRequestDiffs(project, ref.ID)
+	if err != nil {
+		return nil, err
+	}
+	discussions, err := client.GetDiscussions(project, ref.ID)
+	if err != nil {
+		return nil, err
+	}
+	d := &prData{
+		PR:       gitlabPullRequest(mr),
+		Diffstat: gitlabDiffstat(diffs),
+		Comments: gitlabComments(discussions),
+	}
+	if !noDiff {
+		var b strings.Builder
+		for _, fd := range diffs {
+			b.WriteString(fd.Unified())
+		}
+		d.Diff = b.String()
+	}
+	return d, nil
+}
+
+// githubPullRequest converts a GitHub PR to the shared model. GitHub has no
+// "declined" state; a closed, unmerged PR is reported as CLOSED.
+func githubPullRequest(pr *github.PullRequest) *bitbucket.PullRequest {
+	out := &bitbucket.PullRequest{
+		ID:          pr.Number,
+		Title:       pr.Title,
+		State:       strings.ToUpper(pr.State),
+		Description: pr.Body,
+		CreatedOn:   pr.CreatedAt,
+		UpdatedOn:   pr.UpdatedAt,
+	}
+	if pr.Merged {
+		out.State = "MERGED"
+	}
+	out.Author.DisplayName = pr.User.Login
+	out.Source.Branch.Name = pr.Head.Ref
+	out.Source.Commit.Hash = pr.Head.SHA
+	out.Destination.Branch.Name = pr.Base.Ref
+	out.Links.HTML.Href = pr.HTMLURL
+	return out
+}
+
+func githubDiffstat(files []github.File) []bitbucket.DiffstatEntry {
+	out := make([]bitbucket.DiffstatEntry, 0, len(files))
+	for _, f := range files {
+		e := bitbucket.DiffstatEntry{Status: f.Status, LinesAdded: f.Additions, LinesRemoved: f.Deletions}
+		switch f.Status {
+		case "removed":
+			e.Old = &bitbucket.DiffFile{Path: f.Filename}
+		case "renamed":
+			e.Old = &bitbucket.DiffFile{Path: f.PreviousFilename}
+			e.New = &bitbucket.DiffFile{Path: f.Filename}
+		default:
+			e.New = &bitbucket.DiffFile{Path: f.Filename}
+		}
+		out = append(out, e)
+	}
+	return out
+}
+
+// githubComments merges conversation comments, inline review comments and
+// review summaries into one list ordered by creation time. Review comment
+// replies keep their parent so they render as threads.
+func githubComments(conversation, inline []github.Comment, reviews []github.Review) []bitbucket.Comment {
+	var out []bitbucket.Comment
+	add := func(id int64, user, created, body string, in *bitbucket.Inline) {
+		var c bitbucket.Comment
+		c.ID = int(id)
+		c.Content.Raw = body
+		c.User.DisplayName = user
+		c.CreatedOn = created
+		c.Inline = in
+		out = append(out, c)
+	}
+	for _, c := range conversation {
+		add(c.ID, c.User.Login, c.CreatedAt, c.Body, nil)
+	}
+	for _, c := range inline {
+		line := c.Line
+		if line == nil {
+			line = c.OriginalLine // outdated comment
+		}
+		add(c.ID, c.User.Login, c.CreatedAt, c.Body, &bitbucket.Inline{Path: c.Path, To: line})
+		if c.InReplyToID != 0 {
+			out[len(out)-1].Parent = &bitbucket.CommentRef{ID: int(c.InReplyToID)}
+		}
+	}
+	for _, r := range reviews {
+		if strings.TrimSpace(r.Body) != "" {
+			add(r.ID, r.User.Login, r.SubmittedAt, r.Body, nil)
+		}
+	}
+	sort.SliceStable(out, func(i, j int) bool { return out[i].CreatedOn < out[j].CreatedOn })
+	return out
+}
+
+// githubParticipants maps requested reviewers and each reviewer's latest
+// approving or change-requesting review to participants.
+func githubParticipants(pr *github.PullRequest, reviews []github.Review) []bitbucket.Participant {
+	var out []bitbucket.Participant
+	index := map[string]int{}
+	for _, u := range pr.RequestedReviewers {
+		index[u.Login] = len(out)
+		out = append(out, bitbucket.Participant{User: bitbucket.Account{DisplayName: u.Login}, Role: "REVIEWER"})
+	}
+	for _, r := range reviews {
+		var state string
+		switch r.State {
+		case "APPROVED":
+			state = "approved"
+		case "CHANGES_REQUESTED":
+			state = "changes_requested"
+		default:
+			continue
+		}
+		i, ok := index[r.User.Login]
+		if !ok {
+			i = len(out)
+			index[r.User.Login] = i
+			out = append(out, bitbucket.Participant{User: bitbucket.Account{DisplayName: r.User.Login}, Role: "REVIEWER"})
+		}
+		out[i].State, out[i].Approved = state, state == "approved"
+	}
+	return out
+}
+
+// gitlabPullRequest converts a merge request to the shared model, mapping
+// GitLab's lower-case states to the upper-case ones Bitbucket uses.
+func gitlabPullRequest(mr *gitlab.MergeRequest) *bitbucket.PullRequest {
+	state := strings.ToUpper(mr.State)
+	if state == "OPENED" {
+		state = "OPEN"
+	}
+	out := &bitbucket.PullRequest{
+		ID:          mr.IID,
+		Title:       mr.Title,
+		State:       state,
+		Description: mr.Description,
+		CreatedOn:   mr.CreatedAt,
+		UpdatedOn:   mr.UpdatedAt,
+	}
+	out.Author.DisplayName = firstNonEmpty(mr.Author.Name, mr.Author.Username)
+	out.Source.Branch.Name = mr.SourceBranch
+	out.Source.Commit.Hash = mr.SHA
+	out.Destination.Branch.Name = mr.TargetBranch
+	out.Links.HTML.Href = mr.WebURL
+	return out
+}
+
+func gitlabDiffstat(diffs []gitlab.FileDiff) []bitbucket.DiffstatEntry {
+	out := make([]bitbucket.DiffstatEntry, 0, len(diffs))
+	for _, fd := range diffs {
+		added, removed := fd.Stats()
+		e := bitbucket.DiffstatEntry{Status: "modified", LinesAdded: added, LinesRemoved: removed}
+		switch {
+		case fd.NewFile:
+			e.Status = "added"
+			e.New = &bitbucket.DiffFile{Path: fd.NewPath}
+		case fd.DeletedFile:
+			e.Status = "removed"
+			e.Old = &bitbucket.DiffFile{Path: fd.OldPath}
+		case fd.RenamedFile:
+			e.Status = "renamed"
+			e.Old = &bitbucket.DiffFile{Path: fd.OldPath}
+			e.New = &bitbucket.DiffFile{Path: fd.NewPath}
+		default:
+			e.New = &bitbucket.DiffFile{Path: fd.NewPath}
+		}
+		out = append(out, e)
+	}
+	return out
+}
+
+// gitlabComments flattens discussion threads into comments, replies pointing
+// at the thread's first note, and drops the system notes GitLab generates for
+// pushes, label changes and the like.
+func gitlabComments(discussions []gitlab.Discussion) []bitbucket.Comment {
+	var out []bitbucket.Comment
+	for _, d := range discussions {
+		root := 0
+		for _, n := range d.Notes {
+			if n.System {
+				continue
+			}
+			var c bitbucket.Comment
+			c.ID = n.ID
+			if root == 0 {
+				root = n.ID
+			} else {
+				c.Parent = &bitbucket.CommentRef{ID: root}
+			}
+			c.Content.Raw = n.Body
+			c.User.DisplayName = firstNonEmpty(n.Author.Name, n.Author.Username)
+			c.CreatedOn = n.CreatedAt
+			if p := n.Position; p != nil {
+				c.Inline = &bitbucket.Inline{Path: firstNonEmpty(p.NewPath, p.OldPath), To: p.NewLine, From: p.OldLine}
+			}
+			out = append(out, c)
+		}
+	}
+	return out
+}
+
+// wrapPRHostError maps GitHub/GitLab errors to user-facing messages, like
+// wrapBBError does for Bitbucket.
+func wrapPRHostError(err error, ref prRef) error {
+	if err == nil {
+		return nil
+	}
+	auth := "atlit auth " + ref.Host
+	switch {
+	case errors.Is(err, github.ErrUnauthorized), errors.Is(err, gitlab.ErrUnauthorized):
+		return fmt.Errorf("authentication failed: %w (re-run '%s')", err, auth)
+	case errors.Is(err, github.ErrForbidden), errors.Is(err, gitlab.ErrForbidden):
+		return err
+	case errors.Is(err, github.ErrNotFound), errors.Is(err, gitlab.ErrNotFound):
+		return fmt.Errorf("PR %s not found or no access", ref)
+	default:
+		return err
+	}
+}
@@ -0,0 +1,191 @@
+package cmd
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/erickhilda/atlit/internal/config"
+	"github.com/erickhilda/atlit/internal/github"
+	"github.com/erickhilda/atlit/internal/gitlab"
+)
+
+func TestParsePRURL(t *testing.T) {
+	cfg := &config.Config{GitHubHost: "github.example.com", GitLabHost: "git.example.com"}
+	cases := []struct {
+		in   string
+		want prRef
+	}{
+		{"https://bitbucket.org/acme/widget/pull-requests/42", prRef{hostBitbucket, "bitbucket.org", "acme", "widget", 42}},
+		{"https://github.com/acme/widget/pull/42/files", prRef{hostGitHub, "github.com", "acme", "widget", 42}},
+		{"https://github.example.com/acme/widget/pull/3", prRef{hostGitHub, "github.example.com", "acme", "widget", 3}},
+		{"https://gitlab.com/acme/platform/widget/-/merge_requests/9", prRef{hostGitLab, "gitlab.com", "acme/platform", "widget", 9}},
+		{"https://git.example.com/team/widget/-/merge_requests/1/diffs", prRef{hostGitLab, "git.example.com", "team", "widget", 1}},
+	}
+	for _, tc := range cases {
+		got, err := parsePRURL(tc.in, cfg)
+		if err != nil || got != tc.want {
+			t.Errorf("parsePRURL(%q) = %+v, %v; want %+v", tc.in, got, err, tc.want)
+		}
+	}
+
+	for _, bad := range []string{
+		"https://github.com/acme/widget/issues/4",
+		"https://gitlab.com/acme/widget/merge_requests/4",
+		"https://code.other.com/acme/widget/pull/4",
+		"widget/4",
+	} {
+		if _, err := parsePRURL(bad, cfg); err == nil {
+			t.Errorf("parsePRURL(%q): expected error", bad)
+		}
+	}
+}
+
+func TestHostForConfiguredHostCase(t *testing.T) {
+	cfg := &config.Config{GitHubHost: "GHE.Acme.com/", GitLabHost: "Git.Example.com"}
+	got, err := parsePRURL("https://ghe.acme.com/acme/widget/pull/7", cfg)
+	want := prRef{hostGitHub, "ghe.acme.com", "acme", "widget", 7}
+	if err != nil || got != want {
+		t.Errorf("parsePRURL = %+v, %v; want %+v", got, err, want)
+	}
+	if h := hostFor("GIT.example.com", cfg); h != hostGitLab {
+		t.Errorf("hostFor(GIT.example.com) = %q, want %q", h, hostGitLab)
+	}
+}
+
+func TestPRRefArgResolves(t *testing.T) {
+	ref := prRef{Host: hostBitbucket, Server: "bitbucket.org", Workspace: "acme", Repo: "widget", ID: 42}
+	got, err := resolvePRRef(ref.arg(), &config.Config{})
+	if err != nil || got != ref {
+		t.Errorf("resolvePRRef(%q) = %+v, %v; want %+v", ref.arg(), got, err, ref)
+	}
+}
+
+func TestParseGitRemote(t *testing.T) {
+	cfg := &config.Config{GitLabHost: "git.example.com"}
+	cases := []struct {
+		in   string
+		want prRef
+	}{
+		{"git@github.com:acme/widget.git", prRef{Host: hostGitHub, Server: "github.com", Workspace: "acme", Repo: "widget"}},
+		{"https://github.com/acme/widget", prRef{Host: hostGitHub, Server: "github.com", Workspace: "acme", Repo: "widget"}},
+		{"git@bitbucket.org:acme/widget.git", prRef{Host: hostBitbucket, Server: "bitbucket.org", Workspace: "acme", Repo: "widget"}},
+		{"ssh://git@git.example.com:2222/acme/platform/widget.git", prRef{Host: hostGitLab, Server: "git.example.com", Workspace: "acme/platform", Repo: "widget"}},
+	}
+	for _, tc := range cases {
+		got, err := parseGitRemote(tc.in, cfg)
+		if err != nil || got != tc.want {
+			t.Errorf("parseGitRemote(%q) = %+v, %v; want %+v", tc.in, got, err, tc.want)
+		}
+	}
+
+	for _, bad := range []string{
+		"git@example.org:acme/widget.git",
+		"https://github.com/acme/platform/widget.git",
+		"not a remote",
+	} {
+		if _, err := parseGitRemote(bad, cfg); err == nil {
+			t.Errorf("parseGitRemote(%q): expected error", bad)
+		}
+	}
+}
+
+func TestPRRefFileKey(t *testing.T) {
+	if got := (prRef{Host: hostBitbucket, Workspace: "acme", Repo: "widget", ID: 4}).fileKey(); got != "acme__widget__4" {
+		t.Errorf("bitbucket key = %q", got)
+	}
+	if got := (prRef{Host: hostGitLab, Workspace: "acme/platform", Repo: "widget", ID: 4}).fileKey(); got != "gitlab__acme__platform__widget__4" {
+		t.Errorf("gitlab key = %q", got)
+	}
+}
+
+func TestGitHubPullRequestConversion(t *testing.T) {
+	pr := githubPullRequest(&github.PullRequest{
+		Number: 5, Title: "T", State: "closed", Merged: true,
+		User: github.User{Login: "alice"}, Head: github.Ref{Ref: "feature/PROJ-3"}, Base: github.Ref{Ref: "main"},
+		HTMLURL: "https://github.com/acme/widget/pull/5",
+	})
+	if pr.State != "MERGED" || pr.Author.DisplayName != "alice" || pr.Source.Branch.Name != "feature/PROJ-3" ||
+		pr.Destination.Branch.Name != "main" || pr.Links.HTML.Href != "https://github.com/acme/widget/pull/5" {
+		t.Errorf("converted PR = %+v", pr)
+	}
+	if detectJiraKey(pr) != "PROJ-3" {
+		t.Errorf("jira key not detected from converted branch")
+	}
+	if got := githubPullRequest(&github.PullRequest{State: "closed"}).State; got != "CLOSED" {
+		t.Errorf("closed unmerged state = %q", got)
+	}
+
+	line, orig := 12, 8
+	comments := githubComments(
+		[]github.Comment{{ID: 1, Body: "conversation", User: github.User{Login: "bob"}, CreatedAt: "2026-03-02T10:00:00Z"}},
+		[]github.Comment{
+			{ID: 2, Body: "inline", Path: "a.go", Line: &line, CreatedAt: "2026-03-01T10:00:00Z"},
+			{ID: 3, Body: "outdated", Path: "b.go", OriginalLine: &orig, CreatedAt: "2026-03-03T10:00:00Z"},
+		},
+		[]github.Review{{ID: 4, Body: "", SubmittedAt: "2026-03-01T09:00:00Z"}},
+	)
+	var order []string
+	for _, c := range comments {
+		order = append(order, c.Content.Raw)
+	}
+	if strings.Join(order, ",") != "inline,conversation,outdated" {
+		t.Errorf("comment order = %v (empty review bodies should be dropped)", order)
+	}
+	if in := comments[2].Inline; in == nil || in.Path != "b.go" || in.To == nil || *in.To != 8 {
+		t.Errorf("outdated comment location = %+v", in)
+	}
+}
+
+func TestGitLabConversion(t *testing.T) {
+	pr := gitlabPullRequest(&gitlab.MergeRequest{IID: 3, State: "opened", Author: gitlab.User{Username: "alice"}})
+	if pr.State != "OPEN" || pr.ID != 3 || pr.Author.DisplayName != "alice" {
+		t.Errorf("converted MR = %+v", pr)
+	}
+
+	stat := gitlabDiffstat([]gitlab.FileDiff{
+		{OldPath: "gone.go", NewPath: "gone.go", DeletedFile: true, Diff: "@@ -1 +0,0 @@\n-x\n"},
+		{OldPath: "old.go", NewPath: "new.go", RenamedFile: true},
+	})
+	if stat[0].Status != "removed" || stat[0].Path() != "gone.go" || stat[0].LinesRemoved != 1 {
+		t.Errorf("deleted entry = %+v", stat[0])
+	}
+	if stat[1].Status != "renamed" || stat[1].Path() != "new.go" || stat[1].Old.Path != "old.go" {
+		t.Errorf("renamed entry = %+v", stat[1])
+	}
+
+	line := 4
+	comments := gitlabComments([]gitlab.Discussion{
+		{Notes: []gitlab.Note{{ID: 1, Body: "added 1 commit", System: true}}},
+		{Notes: []gitlab.Note{
+			{ID: 2, Body: "nit", Author: gitlab.User{Name: "Bob"}, Position: &gitlab.Position{NewPath: "a.go", NewLine: &line}},
+			{ID: 3, Body: "fixed"},
+		}},
+	})
+	if len(comments) != 2 {
+		t.Fatalf("expected system note dropped, got %d comments", len(comments))
+	}
+	if in := comments[0].Inline; in == nil || in.Path != "a.go" || *in.To != 4 || comments[0].User.DisplayName != "Bob" {
+		t.Errorf("inline note = %+v", comments[0])
+	}
+	if comments[0].Parent != nil || comments[1].Parent == nil || comments[1].Parent.ID != 2 {
+		t.Errorf("reply should point at the thread's first note: %+v", comments[1].Parent)
+	}
+}
+
+func TestGitHubParticipants(t *testing.T) {
+	pr := &github.PullRequest{RequestedReviewers: []github.User{{Login: "carol"}}}
+	got := githubParticipants(pr, []github.Review{
+		{User: github.User{Login: "bob"}, State: "CHANGES_REQUESTED"},
+		{User: github.User{Login: "dan"}, State: "COMMENTED"},
+		{User: github.User{Login: "bob"}, State: "APPROVED"},
+	})
+	if len(got) != 2 {
+		t.Fatalf("participants = %+v", got)
+	}
+	if got[0].User.DisplayName != "carol" || got[0].State != "" {
+		t.Errorf("requested reviewer = %+v", got[0])
+	}
+	if got[1].User.DisplayName != "bob" || !got[1].Approved || got[1].State != "approved" {
+		t.Errorf("latest review should win: %+v", got[1])
+	}
+}
@@ -0,0 +1,278 @@
+package cmd
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/erickhilda/atlit/internal/bitbucket"
+	"github.com/erickhilda/atlit/internal/config"
+	"github.com/erickhilda/atlit/internal/store"
+	"github.com/spf13/cobra"
+)
+
+// defaultQueueRepos is the default --max-repos: how many of the workspace's
+// repositories 'atlit pr queue' scans when no --repos are given, most
+// recently updated first.
+const defaultQueueRepos = 50
+
+var prQueueCmd = &cobra.Command{
+	Use:   "queue",
+	Short: "List open PRs waiting on you across a Bitbucket workspace",
+	Long: `Lists open Bitbucket Cloud PRs that need your attention, oldest first:
+
+  - PRs where you are a reviewer and have not approved
+  - PRs you authored with comments from others since you last fetched them
+    with 'atlit pr' (or any comments by others, if never fetched)
+
+By default the 50 most recently updated repositories of the configured
+workspace (bitbucket_workspace) are scanned, with a warning when it has more;
+--max-repos changes the cap (0 scans them all) and --repos narrows the scan
+to a list.
+
+  atlit pr queue
+  atlit pr queue --max-repos 0
+  atlit pr queue --repos widget,gadget
+  atlit pr queue --repos acme/widget,other/tool`,
+	Args: cobra.NoArgs,
+	RunE: runPRQueue,
+}
+
+func init() {
+	prQueueCmd.Flags().StringSlice("repos", nil, "Only scan these repositories (repo or workspace/repo)")
+	prQueueCmd.Flags().Int("max-repos", defaultQueueRepos, "Without --repos, how many of the most recently updated workspace repositories to scan (0 = all)")
+	prCmd.AddCommand(prQueueCmd)
+}
+
+// queueRepo is a repository scanned by 'atlit pr queue'.
+type queueRepo struct {
+	Workspace, Repo string
+}
+
+// queueItem is a PR in the review queue and why it is there.
+type queueItem struct {
+	Workspace, Repo string
+	PR              bitbucket.PullRequest
+	Why             string
+}
+
+// reviewStatus says why pr waits on the reviewer with uuid me, or "" when
+// they are not a reviewer or have already approved.
+func reviewStatus(pr *bitbucket.PullRequest, me string) string {
+	for _, p := range pr.Participants {
+		if p.Role != "REVIEWER" || p.User.UUID != me || p.Approved {
+			continue
+		}
+		if p.State == "changes_requested" {
+			return "changes requested"
+		}
+		return "review requested"
+	}
+	return ""
+}
+
+// commentsByOthers counts the visible comments not written by me that were
+// created after since; a zero since counts them all.
+func commentsByOthers(comments []bitbucket.Comment, me string, since time.Time) int {
+	n := 0
+	for _, cm := range comments {
+		if cm.Deleted || cm.User.UUID == me || strings.TrimSpace(cm.Content.Raw) == "" {
+			continue
+		}
+		if !since.IsZero() {
+			created, err := time.Parse(time.RFC3339, cm.CreatedOn)
+			if err != nil || !created.After(since) {
+				continue
+			}
+		}
+		n++
+	}
+	return n
+}
+
+// lastFetched returns when the PR saved under key was last fetched, or false
+// when it was never saved (or saved before atlit recorded the time).
+func lastFetched(prsDir, key string) (time.Time, bool) {
+	content, err := store.Load(prsDir, key)
+	if err != nil {
+		return time.Time{}, false
+	}
+	meta := store.ParsePRMeta(content)
+	if meta == nil || meta.Fetched.IsZero() {
+		return time.Time{}, false
+	}
+	return meta.Fetched, true
+}
+
+// authoredStatus says why my own PR needs attention, or "" when nobody else
+// has commented since I last fetched it.
+func authoredStatus(comments []bitbucket.Comment, me string, fetched time.Time, saved bool) string {
+	if !saved {
+		fetched = time.Time{}
+	}
+	n := commentsByOthers(comments, me, fetched)
+	switch {
+	case n == 0:
+		return ""
+	case !saved:
+		return fmt.Sprintf("%d comment(s), not fetched", n)
+	default:
+		return fmt.Sprintf("%d new comment(s)", n)
+	}
+}
+
+// sortQueue orders items oldest PR first, by created time.
+func sortQueue(items []queueItem) {
+	sort.SliceStable(items, func(i, j int) bool {
+		return items[i].PR.CreatedOn < items[j].PR.CreatedOn
+	})
+}
+
+// queueRepos resolves --repos, or lists up to maxRepos (0 = all) of the
+// configured workspace's repositories when none were given, warning when
+// some are left out.
+func queueRepos(client *bitbucket.Client, cfg *config.Config, repos []string, maxRepos int) ([]queueRepo, error) {
+	var out []queueRepo
+	for _, r := range repos {
+		if r = strings.TrimSpace(r); r == "" {
+			continue
+		}
+		ws, repo, err := resolveRepoRef(r, cfg)
+		if err != nil {
+			return nil, err
+		}
+		out = append(out, queueRepo{Workspace: ws, Repo: repo})
+	}
+	if len(out) > 0 {
+		return out, nil
+	}
+
+	ws := cfg.BitbucketWorkspace
+	if ws == "" {
+		return nil, errors.New("no workspace: set 'bitbucket_workspace' in config or pass --repos")
+	}
+	// One more than the cap tells whether the list was cut short.
+	limit := 0
+	if maxRepos > 0 {
+		limit = maxRepos + 1
+	}
+	list, err := client.ListRepositories(ws, limit)
+	if err != nil {
+		if errors.Is(err, bitbucket.ErrNotFound) {
+			return nil, fmt.Errorf("workspace %s not found or no access", ws)
+		}
+		return nil, wrapBBListError(err, ws, "")
+	}
+	list, truncated := capRepos(list, maxRepos)
+	if truncated {
+		fmt.Fprintf(os.Stderr, "warning: scanning only the %d most recently updated repositories of %s; use --max-repos 0 to scan them all, or --repos\n", maxRepos, ws)
+	}
+	for _, r := range list {
+		out = append(out, queueRepo{Workspace: ws, Repo: r.Slug})
+	}
+	return out, nil
+}
+
+// capRepos keeps the first max repositories (0 = all), reporting whether any
+// were dropped.
+func capRepos(list []bitbucket.Repository, max int) ([]bitbucket.Repository, bool) {
+	if max > 0 && len(list) > max {
+		return list[:max], true
+	}
+	return list, false
+}
+
+func runPRQueue(cmd *cobra.Command, _ []string) error {
+	reposFlag, _ := cmd.Flags().GetStringSlice("repos")
+	maxRepos, _ := cmd.Flags().GetInt("max-repos")
+	if maxRepos < 0 {
+		return fmt.Errorf("invalid --max-repos %d", maxRepos)
+	}
+
+	cfg, err := config.Load()
+	if err != nil {
+		return err
+	}
+	token, err := config.GetBitbucketToken(cfg)
+	if err != nil {
+		return fmt.Errorf("retrieving Bitbucket token (run 'atlit auth bitbucket'): %w", err)
+	}
+	client := bitbucket.NewClient(cfg.Email, token)
+
+	me, err := client.CurrentUser()
+	if err != nil {
+		if errors.Is(err, bitbucket.ErrUnauthorized) {
+			return fmt.Errorf("authentication failed: %w (re-run 'atlit auth bitbucket')", err)
+		}
+		return fmt.Errorf("looking up your Bitbucket account: %w", err)
+	}
+	repos, err := queueRepos(client, cfg, reposFlag, maxRepos)
+	if err != nil {
+		return err
+	}
+
+	query := fmt.Sprintf(`state="OPEN" AND (author.uuid=%q OR reviewers.uuid=%q)`, me.UUID, me.UUID)
+	prsDir := cfg.PRsDirOrDefault()
+	var items []queueItem
+	failed := 0
+	for _, r := range repos {
+		prs, err := client.QueryPullRequests(r.Workspace, r.Repo, query)
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "warning: %v\n", wrapBBListError(err, r.Workspace, r.Repo))
+			failed++
+			continue
+		}
+		for i := range prs {
+			pr := &prs[i]
+			why := reviewStatus(pr, me.UUID)
+			if why == "" && pr.Author.UUID == me.UUID {
+				comments, err := client.GetPullRequestComments(r.Workspace, r.Repo, pr.ID)
+				if err != nil {
+					fmt.Fprintf(os.Stderr, "warning: %s/%s#%d: %v\n", r.Workspace, r.Repo, pr.ID, err)
+					continue
+				}
+				fetched, saved := lastFetched(prsDir, prFileKey(r.Workspace, r.Repo, pr.ID))
+				why = authoredStatus(comments, me.UUID, fetched, saved)
+			}
+			if why != "" {
+				items = append(items, queueItem{Workspace: r.Workspace, Repo: r.Repo, PR: *pr, Why: why})
+			}
+		}
+	}
+
+	sortQueue(items)
+	printPRQueue(items, len(repos), time.Now())
+	if failed > 0 && failed == len(repos) {
+		return fmt.Errorf("none of the %d repositories could be read", failed)
+	}
+	return nil
+}
+
+// printPRQueue renders the queue table (or an empty-queue line) to stdout.
+func printPRQueue(items []queueItem, scanned int, now time.Time) {
+	if len(items) == 0 {
+		fmt.Printf("Nothing waiting on you in %d repositories.\n", scanned)
+		return
+	}
+
+	fmt.Printf("Review queue: %d PR(s) in %d repositories scanned\n\n", len(items), scanned)
+	fmt.Printf("%-24s %-6s %-40s %-16s %-26s %s\n", "REPO", "#", "TITLE", "JIRA", "WHY", "AGE")
+	for i := range items {
+		it := &items[i]
+		jira := detectJiraKey(&it.PR)
+		if jira == "" {
+			jira = "-"
+		}
+		fmt.Printf("%-24s %-6d %-40s %-16s %-26s %s\n",
+			truncate(it.Workspace+"/"+it.Repo, 24),
+			it.PR.ID,
+			truncate(it.PR.Title, 40),
+			jira,
+			it.Why,
+			formatPRUpdated(now, it.PR.CreatedOn),
+		)
+	}
+}
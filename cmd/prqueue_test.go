@@ -0,0 +1,97 @@
+package cmd
+
+import (
+	"testing"
+	"time"
+
+	"github.com/erickhilda/atlit/internal/bitbucket"
+	"github.com/erickhilda/atlit/internal/store"
+)
+
+func TestReviewStatus(t *testing.T) {
+	reviewer := func(uuid string, approved bool, state string) bitbucket.Participant {
+		p := bitbucket.Participant{Role: "REVIEWER", Approved: approved, State: state}
+		p.User.UUID = uuid
+		return p
+	}
+	cases := []struct {
+		name string
+		ps   []bitbucket.Participant
+		want string
+	}{
+		{"pending", []bitbucket.Participant{reviewer("{other}", true, "approved"), reviewer("{me}", false, "")}, "review requested"},
+		{"changes", []bitbucket.Participant{reviewer("{me}", false, "changes_requested")}, "changes requested"},
+		{"approved", []bitbucket.Participant{reviewer("{me}", true, "approved")}, ""},
+		{"not reviewer", []bitbucket.Participant{{User: bitbucket.Account{UUID: "{me}"}, Role: "PARTICIPANT"}}, ""},
+	}
+	for _, tc := range cases {
+		pr := &bitbucket.PullRequest{Participants: tc.ps}
+		if got := reviewStatus(pr, "{me}"); got != tc.want {
+			t.Errorf("%s: got %q, want %q", tc.name, got, tc.want)
+		}
+	}
+}
+
+func TestAuthoredStatus(t *testing.T) {
+	mk := func(uuid, created string) bitbucket.Comment {
+		c := bitbucket.Comment{CreatedOn: created, User: bitbucket.Account{UUID: uuid}}
+		c.Content.Raw = "text"
+		return c
+	}
+	comments := []bitbucket.Comment{
+		mk("{rev}", "2026-06-09T10:00:00.000000+00:00"),
+		mk("{me}", "2026-06-09T13:00:00.000000+00:00"),
+		mk("{rev}", "2026-06-09T14:00:00.000000+00:00"),
+	}
+	fetched := time.Date(2026, 6, 9, 12, 0, 0, 0, time.UTC)
+
+	if got := authoredStatus(comments, "{me}", fetched, true); got != "1 new comment(s)" {
+		t.Errorf("fetched: %q", got)
+	}
+	if got := authoredStatus(comments, "{me}", time.Time{}, false); got != "2 comment(s), not fetched" {
+		t.Errorf("never fetched: %q", got)
+	}
+	if got := authoredStatus(comments, "{me}", fetched.Add(3*time.Hour), true); got != "" {
+		t.Errorf("nothing new: %q", got)
+	}
+}
+
+func TestLastFetched(t *testing.T) {
+	dir := t.TempDir()
+	key := prFileKey("acme", "widget", 42)
+	if _, ok := lastFetched(dir, key); ok {
+		t.Error("missing file should not count as fetched")
+	}
+	if err := store.Save(dir, key, "<!-- atlit:meta pr=acme/widget/42 fetched=2026-06-10T12:00:00Z -->\n# PR #42\n"); err != nil {
+		t.Fatal(err)
+	}
+	got, ok := lastFetched(dir, key)
+	if !ok || !got.Equal(time.Date(2026, 6, 10, 12, 0, 0, 0, time.UTC)) {
+		t.Errorf("lastFetched = %v, %v", got, ok)
+	}
+}
+
+func TestSortQueue(t *testing.T) {
+	items := []queueItem{
+		{Repo: "b", PR: bitbucket.PullRequest{ID: 2, CreatedOn: "2026-06-05T00:00:00+00:00"}},
+		{Repo: "a", PR: bitbucket.PullRequest{ID: 1, CreatedOn: "2026-05-01T00:00:00+00:00"}},
+		{Repo: "c", PR: bitbucket.PullRequest{ID: 3, CreatedOn: "2026-06-01T00:00:00+00:00"}},
+	}
+	sortQueue(items)
+	if items[0].PR.ID != 1 || items[1].PR.ID != 3 || items[2].PR.ID != 2 {
+		t.Errorf("order = %d %d %d, want oldest first", items[0].PR.ID, items[1].PR.ID, items[2].PR.ID)
+	}
+}
+
+func TestCapRepos(t *testing.T) {
+	list := []bitbucket.Repository{{Slug: "a"}, {Slug: "b"}, {Slug: "c"}}
+	if got, truncated := capRepos(list, 2); len(got) != 2 || got[1].Slug != "b" || !truncated {
+		t.Errorf("cap 2: %v, truncated %v", got, truncated)
+	}
+	if got, truncated := capRepos(list, 3); len(got) != 3 || truncated {
+		t.Errorf("cap 3: %v, truncated %v", got, truncated)
+	}
+	if got, truncated := capRepos(list, 0); len(got) != 3 || truncated {
+		t.Errorf("no cap: %v, truncated %v", got, truncated)
+	}
+}
@@ -0,0 +1,540 @@
+package cmd
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/erickhilda/atlit/internal/bitbucket"
+	"github.com/erickhilda/atlit/internal/config"
+	"github.com/erickhilda/atlit/internal/store"
+	"github.com/erickhilda/atlit/internal/unidiff"
+	"github.com/spf13/cobra"
+)
+
+// reviewGeneralHeading holds the draft's top-level (non-inline) comment.
+const reviewGeneralHeading = "General"
+
+var prReviewCmd = &cobra.Command{
+	Use:   "review",
+	Short: "Review a Bitbucket pull request offline and post it in one go",
+	Long: `A batch review workflow. 'start' saves the PR markdown (with its diff) and
+creates a review draft next to it, <prs_dir>/<pr>.review.md. Write comments in
+the draft under one heading per line:
+
+  ## internal/parse.go:118      line 118 of the new version of the file
+  ## internal/parse.go:-96      line 96 of the old version (removed or context)
+  ## General                    a comment on the PR as a whole
+
+'submit' checks every heading against the saved diff, refuses if the PR's
+source branch has moved since 'start', and then posts all comments. Posted
+comments are removed from the draft as they go, so a failed submit can be
+retried; the draft is deleted once everything is posted.
+
+  atlit pr review start 42
+  atlit pr review submit
+  atlit pr review submit acme/widget/42 --dry-run
+
+Running 'start' again keeps the comments and re-bases the draft on the PR's
+current commit (check line numbers against the new diff).`,
+}
+
+var prReviewStartCmd = &cobra.Command{
+	Use:   "start <PR-REF>",
+	Short: "Fetch a PR and create a review draft for it",
+	Args:  cobra.ExactArgs(1),
+	RunE:  runPRReviewStart,
+}
+
+var prReviewSubmitCmd = &cobra.Command{
+	Use:   "submit [PR-REF]",
+	Short: "Post the comments in a review draft",
+	Long: `Posts the comments in a review draft as inline PR comments. Without PR-REF the
+only draft in prs_dir is submitted.`,
+	Args: cobra.MaximumNArgs(1),
+	RunE: runPRReviewSubmit,
+}
+
+func init() {
+	prReviewSubmitCmd.Flags().Bool("dry-run", false, "Validate the draft and list the comments without posting")
+
+	prReviewCmd.AddCommand(prReviewStartCmd)
+	prReviewCmd.AddCommand(prReviewSubmitCmd)
+	prCmd.AddCommand(prReviewCmd)
+}
+
+// reviewMetaRe matches the draft's first line.
+var reviewMetaRe = regexp.MustCompile(`<!-- atlit:review pr=([^/\s]+)/([^/\s]+)/(\d+) commit=(\S*) started=\S+ -->`)
+
+// reviewHeadingRe matches "path:line" and "path:-line" comment headings.
+var reviewHeadingRe = regexp.MustCompile(`^(.+):(-?)([1-9]\d*)$`)
+
+// reviewDraft is a parsed review file.
+type reviewDraft struct {
+	Ref      prRef
+	Commit   string
+	General  string
+	Comments []reviewComment
+}
+
+// reviewComment is one inline comment in a draft. Old marks a line of the old
+// version of the file.
+type reviewComment struct {
+	Path string
+	Line int
+	Old  bool
+	Body string
+}
+
+// heading is the comment's draft heading, "path:line" or "path:-line".
+func (c reviewComment) heading() string {
+	if c.Old {
+		return fmt.Sprintf("%s:-%d", c.Path, c.Line)
+	}
+	return fmt.Sprintf("%s:%d", c.Path, c.Line)
+}
+
+// newComment converts c for the Bitbucket API.
+func (c reviewComment) newComment() bitbucket.NewComment {
+	nc := bitbucket.NewComment{Raw: c.Body, Path: c.Path}
+	if c.Old {
+		nc.OldLine = c.Line
+	} else {
+		nc.Line = c.Line
+	}
+	return nc
+}
+
+// reviewKey is the draft's file name (without .md) under prs_dir.
+func reviewKey(ref prRef) string {
+	return ref.fileKey() + ".review"
+}
+
+func runPRReviewStart(_ *cobra.Command, args []string) error {
+	cfg, err := config.Load()
+	if err != nil {
+		return err
+	}
+	ref, err := resolvePRRef(args[0], cfg)
+	if err != nil {
+		return err
+	}
+	if ref.Host != hostBitbucket {
+		return fmt.Errorf("%s is a %s PR; reviews are only supported for Bitbucket", ref, ref.Host)
+	}
+
+	// The saved diff is what submit maps line numbers against, so it is
+	// always refreshed together with the draft's commit.
+	prPath, d, err := savePRFile(cfg, newPRClients(cfg), ref, prFileOptions{})
+	if err != nil {
+		return err
+	}
+
+	prsDir := cfg.PRsDirOrDefault()
+	key := reviewKey(ref)
+	path, err := store.TicketPath(prsDir, key)
+	if err != nil {
+		return err
+	}
+	commit := d.PR.Source.Commit.Hash
+
+	if existing, err := store.Load(prsDir, key); err == nil {
+		draft, perr := parseReviewDraft(existing)
+		if perr != nil {
+			return fmt.Errorf("%s: %w", path, perr)
+		}
+		if sameCommit(draft.Commit, commit) {
+			fmt.Printf("Review draft %s is up to date with %s.\n", path, shortCommit(commit))
+			return nil
+		}
+		if err := store.Save(prsDir, key, restampReviewDraft(existing, ref, commit, time.Now())); err != nil {
+			return fmt.Errorf("saving review draft: %w", err)
+		}
+		fmt.Printf("Moved review draft %s from %s to %s; check its line numbers against the new diff in %s.\n",
+			path, shortCommit(draft.Commit), shortCommit(commit), prPath)
+		return nil
+	}
+
+	if err := store.Save(prsDir, key, renderReviewDraft(ref, d, time.Now())); err != nil {
+		return fmt.Errorf("saving review draft: %w", err)
+	}
+	fmt.Printf("Started review of %s: %s\nAdd comments under '## path:line' headings, then run 'atlit pr review submit'.\n", ref, path)
+	return nil
+}
+
+// reviewMetaLine is the draft's first line.
+func reviewMetaLine(ref prRef, commit string, now time.Time) string {
+	return fmt.Sprintf("<!-- atlit:review pr=%s/%s/%d commit=%s started=%s -->",
+		ref.Workspace, ref.Repo, ref.ID, commit, now.UTC().Format(time.RFC3339))
+}
+
+// renderReviewDraft builds a new, empty draft for the fetched PR.
+func renderReviewDraft(ref prRef, d *prData, now time.Time) string {
+	var b strings.Builder
+	b.WriteString(reviewMetaLine(ref, d.PR.Source.Commit.Hash, now) + "\n\n")
+	fmt.Fprintf(&b, "# Review: %s %s\n\n", ref, d.PR.Title)
+	b.WriteString("<!--\n")
+	b.WriteString("Add one heading per comment: \"## path:line\" for a line of the new file,\n")
+	b.WriteString("\"## path:-line\" for a line of the old file (removed or context). Text under\n")
+	b.WriteString("\"## General\" is posted as a PR-level comment. Empty sections are skipped.\n")
+	if len(d.Diffstat) > 0 {
+		b.WriteString("\nChanged files:\n")
+		for _, f := range d.Diffstat {
+			fmt.Fprintf(&b, "  %s (+%d -%d)\n", f.Path(), f.LinesAdded, f.LinesRemoved)
+		}
+	}
+	b.WriteString("-->\n\n")
+	b.WriteString("## " + reviewGeneralHeading + "\n\n")
+	return b.String()
+}
+
+// restampReviewDraft points an existing draft at a new commit, keeping its
+// comments.
+func restampReviewDraft(content string, ref prRef, commit string, now time.Time) string {
+	meta := reviewMetaLine(ref, commit, now)
+	if loc := reviewMetaRe.FindStringIndex(content); loc != nil {
+		return content[:loc[0]] + meta + content[loc[1]:]
+	}
+	return meta + "\n\n" + content
+}
+
+// parseReviewDraft reads a draft's meta line and comment sections. Text
+// before the first heading (title, instructions) is ignored, as are HTML
+// comments and sections left empty.
+func parseReviewDraft(content string) (*reviewDraft, error) {
+	m := reviewMetaRe.FindStringSubmatch(content)
+	if m == nil {
+		return nil, errors.New("not a review draft (missing atlit:review header)")
+	}
+	id, _ := strconv.Atoi(m[3])
+	draft := &reviewDraft{
+		Ref:    prRef{Host: hostBitbucket, Workspace: m[1], Repo: m[2], ID: id},
+		Commit: m[4],
+	}
+
+	var errs []string
+	for _, sec := range splitReviewSections(content) {
+		body := strings.TrimSpace(stripHTMLComments(sec.body))
+		if body == "" {
+			continue
+		}
+		if strings.EqualFold(sec.heading, reviewGeneralHeading) {
+			draft.General = body
+			continue
+		}
+		hm := reviewHeadingRe.FindStringSubmatch(sec.heading)
+		if hm == nil {
+			errs = append(errs, fmt.Sprintf("heading %q: use \"path:line\" or \"path:-line\"", sec.heading))
+			continue
+		}
+		line, _ := strconv.Atoi(hm[3])
+		draft.Comments = append(draft.Comments, reviewComment{
+			Path: strings.TrimSpace(hm[1]),
+			Line: line,
+			Old:  hm[2] == "-",
+			Body: body,
+		})
+	}
+	if len(errs) > 0 {
+		return nil, errors.New(strings.Join(errs, "; "))
+	}
+	return draft, nil
+}
+
+type reviewSection struct {
+	heading, body string
+}
+
+// splitReviewSections splits content on "## " headings.
+func splitReviewSections(content string) []reviewSection {
+	var out []reviewSection
+	var cur *reviewSection
+	for _, line := range strings.Split(content, "\n") {
+		if h, ok := strings.CutPrefix(line, "## "); ok {
+			out = append(out, reviewSection{heading: strings.TrimSpace(h)})
+			cur = &out[len(out)-1]
+			continue
+		}
+		if cur != nil {
+			cur.body += line + "\n"
+		}
+	}
+	return out
+}
+
+// stripHTMLComments removes <!-- ... --> blocks.
+func stripHTMLComments(s string) string {
+	for {
+		start := strings.Index(s, "<!--")
+		if start < 0 {
+			return s
+		}
+		end := strings.Index(s[start:], "-->")
+		if end < 0 {
+			return s[:start]
+		}
+		s = s[:start] + s[start+end+len("-->"):]
+	}
+}
+
+// renderReviewRemainder rewrites a draft after a partial submit, keeping only
+// the comments that were not posted.
+func renderReviewRemainder(draft *reviewDraft, general string, rest []reviewComment, now time.Time) string {
+	var b strings.Builder
+	b.WriteString(reviewMetaLine(draft.Ref, draft.Commit, now) + "\n\n")
+	fmt.Fprintf(&b, "# Review: %s (remaining comments)\n\n", draft.Ref)
+	b.WriteString("## " + reviewGeneralHeading + "\n\n")
+	if general != "" {
+		b.WriteString(general + "\n\n")
+	}
+	for _, c := range rest {
+		fmt.Fprintf(&b, "## %s\n\n%s\n\n", c.heading(), c.Body)
+	}
+	return b.String()
+}
+
+// prCommentsHeadingRe matches the "## Comments (N)" heading written after a
+// saved PR's diff.
+var prCommentsHeadingRe = regexp.MustCompile(`^## Comments \(\d+\)$`)
+
+// prDiffSection returns the "## Diff" section of a saved PR file, or "".
+// Headings inside fenced blocks are skipped, and only headings after
+// "## Description" and before the comments count, so a "## Diff" heading in
+// the PR description or a comment is not taken for the diff.
+func prDiffSection(content string) string {
+	lines := strings.Split(content, "\n")
+	start, end := -1, len(lines)
+	afterDescription, inFence := false, false
+scan:
+	for i, line := range lines {
+		line = strings.TrimRight(line, " ")
+		if strings.HasPrefix(line, "```") {
+			inFence = !inFence
+			continue
+		}
+		if inFence || !strings.HasPrefix(line, "## ") {
+			continue
+		}
+		switch {
+		case !afterDescription:
+			afterDescription = line == "## Description"
+		case line == "## Diff":
+			start, end = i, len(lines)
+		case start >= 0 && prCommentsHeadingRe.MatchString(line):
+			if end == len(lines) {
+				end = i
+			}
+			break scan
+		case start >= 0 && end == len(lines):
+			end = i
+		}
+	}
+	if start < 0 {
+		return ""
+	}
+	return strings.TrimRight(strings.Join(lines[start:end], "\n"), "\n") + "\n"
+}
+
+// diffLines is the set of commentable lines of one file in a unified diff:
+// New holds added and context lines of the new version, Old removed and
+// context lines of the old version.
+type diffLines struct {
+	New, Old map[int]bool
+}
+
+// parseDiffLines maps each file in a unified diff (as saved in the PR
+// markdown) to its commentable lines. Files are keyed by their new path, or
+// their old path when deleted.
+func parseDiffLines(diff string) map[string]*diffLines {
+	files := map[string]*diffLines{}
+	for _, f := range unidiff.Parse(diff) {
+		lines := &diffLines{New: map[int]bool{}, Old: map[int]bool{}}
+		for _, h := range f.Hunks {
+			for _, l := range h.Lines {
+				if l.New > 0 {
+					lines.New[l.New] = true
+				}
+				if l.Old > 0 {
+					lines.Old[l.Old] = true
+				}
+			}
+		}
+		files[f.Path()] = lines
+	}
+	return files
+}
+
+// validateReviewComments checks every comment against the diff and returns
+// one problem per invalid comment.
+func validateReviewComments(comments []reviewComment, files map[string]*diffLines) []string {
+	var problems []string
+	for _, c := range comments {
+		f, ok := files[c.Path]
+		switch {
+		case !ok:
+			problems = append(problems, fmt.Sprintf("%s: file is not in the diff", c.heading()))
+		case c.Old && !f.Old[c.Line]:
+			problems = append(problems, fmt.Sprintf("%s: old line %d is not in the diff (use a removed or context line)", c.heading(), c.Line))
+		case !c.Old && !f.New[c.Line]:
+			problems = append(problems, fmt.Sprintf("%s: line %d is not in the diff (use an added or context line)", c.heading(), c.Line))
+		}
+	}
+	return problems
+}
+
+// sameCommit compares commit hashes that may be abbreviated to different
+// lengths.
+func sameCommit(a, b string) bool {
+	if a == "" || b == "" {
+		return false
+	}
+	return strings.HasPrefix(a, b) || strings.HasPrefix(b, a)
+}
+
+// shortCommit abbreviates a hash for messages.
+func shortCommit(h string) string {
+	if h == "" {
+		return "(unknown)"
+	}
+	if len(h) > 12 {
+		return h[:12]
+	}
+	return h
+}
+
+// findReviewDraft returns the ref of the only draft in prsDir.
+func findReviewDraft(prsDir string) (string, error) {
+	pattern, err := store.TicketPath(prsDir, "*.review")
+	if err != nil {
+		return "", err
+	}
+	matches, err := filepath.Glob(pattern)
+	if err != nil {
+		return "", err
+	}
+	sort.Strings(matches)
+	switch len(matches) {
+	case 0:
+		return "", fmt.Errorf("no review drafts in %s; start one with 'atlit pr review start <PR-REF>'", prsDir)
+	case 1:
+		return matches[0], nil
+	default:
+		return "", fmt.Errorf("%d review drafts in %s; name the PR to submit", len(matches), prsDir)
+	}
+}
+
+func runPRReviewSubmit(cmd *cobra.Command, args []string) error {
+	dryRun, _ := cmd.Flags().GetBool("dry-run")
+
+	cfg, err := config.Load()
+	if err != nil {
+		return err
+	}
+	prsDir := cfg.PRsDirOrDefault()
+
+	var path string
+	if len(args) == 1 {
+		ref, err := resolvePRRef(args[0], cfg)
+		if err != nil {
+			return err
+		}
+		if path, err = store.TicketPath(prsDir, reviewKey(ref)); err != nil {
+			return err
+		}
+	} else if path, err = findReviewDraft(prsDir); err != nil {
+		return err
+	}
+
+	data, err := os.ReadFile(path)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return fmt.Errorf("no review draft at %s; start one with 'atlit pr review start'", path)
+		}
+		return err
+	}
+	draft, err := parseReviewDraft(string(data))
+	if err != nil {
+		return fmt.Errorf("%s: %w", path, err)
+	}
+	ref := draft.Ref
+	if draft.General == "" && len(draft.Comments) == 0 {
+		return fmt.Errorf("review draft %s has no comments", path)
+	}
+
+	prContent, err := store.Load(prsDir, ref.fileKey())
+	if err != nil {
+		return fmt.Errorf("loading %s: %w (run 'atlit pr review start' again)", ref, err)
+	}
+	diff := prDiffSection(prContent)
+	if len(draft.Comments) > 0 && diff == "" {
+		return fmt.Errorf("the saved %s has no diff to check comments against; run 'atlit pr review start' again", ref)
+	}
+	if problems := validateReviewComments(draft.Comments, parseDiffLines(diff)); len(problems) > 0 {
+		return fmt.Errorf("review draft %s has comments outside the diff; nothing was posted:\n  %s",
+			path, strings.Join(problems, "\n  "))
+	}
+
+	if dryRun {
+		fmt.Printf("Would post on %s:\n", ref)
+		if draft.General != "" {
+			fmt.Printf("  general: %s\n", truncate(firstLine(draft.General), 60))
+		}
+		for _, c := range draft.Comments {
+			fmt.Printf("  %s: %s\n", c.heading(), truncate(firstLine(c.Body), 60))
+		}
+		return nil
+	}
+
+	client, err := bitbucketWriteClient(cfg, ref)
+	if err != nil {
+		return err
+	}
+	pr, err := client.GetPullRequest(ref.Workspace, ref.Repo, ref.ID)
+	if err != nil {
+		return wrapBBError(err, ref.Workspace, ref.Repo, ref.ID)
+	}
+	if current := pr.Source.Commit.Hash; !sameCommit(current, draft.Commit) {
+		return fmt.Errorf("%s has new commits since the review started (%s, now %s); nothing was posted. "+
+			"Run 'atlit pr review start %s' to move the draft to the new diff",
+			ref, shortCommit(draft.Commit), shortCommit(current), ref.arg())
+	}
+
+	general := draft.General
+	if general != "" {
+		if _, err := client.AddPullRequestComment(ref.Workspace, ref.Repo, ref.ID, bitbucket.NewComment{Raw: general}); err != nil {
+			return wrapBBWriteError(err, ref)
+		}
+		general = ""
+	}
+	for i, c := range draft.Comments {
+		if _, err := client.AddPullRequestComment(ref.Workspace, ref.Repo, ref.ID, c.newComment()); err != nil {
+			if serr := store.Save(prsDir, reviewKey(ref), renderReviewRemainder(draft, general, draft.Comments[i:], time.Now())); serr != nil {
+				fmt.Fprintf(os.Stderr, "warning: updating review draft: %v\n", serr)
+			}
+			return fmt.Errorf("posted %d of %d comments; the rest are still in %s: %w",
+				i, len(draft.Comments), path, wrapBBWriteError(err, ref))
+		}
+	}
+
+	if err := os.Remove(path); err != nil {
+		return fmt.Errorf("removing review draft: %w", err)
+	}
+	n := len(draft.Comments)
+	if draft.General != "" {
+		n++
+	}
+	fmt.Printf("Submitted %d comment(s) on %s.\n", n, ref)
+	return nil
+}
+
+// firstLine returns s up to its first newline.
+func firstLine(s string) string {
+	line, _, _ := strings.Cut(s, "\n")
+	return line
+}
@@ -0,0 +1,176 @@
+package cmd
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/erickhilda/atlit/internal/bitbucket"
+)
+
+const reviewTestDiff = "```diff\n" +
+	"diff --git a/parse.go b/parse.go\n" +
+	"index 1111111..2222222 100644\n" +
+	"--- a/parse.go\n" +
+	"+++ b/parse.go\n" +
+	"@@ -10,4 +10,5 @@ func parse() {\n" +
+	" \tx := 1\n" +
+	"-\ty := 2\n" +
+	"+\ty := 3\n" +
+	"+\tz := 4\n" +
+	" \treturn\n" +
+	"diff --git a/old.go b/old.go\n" +
+	"deleted file mode 100644\n" +
+	"--- a/old.go\n" +
+	"+++ /dev/null\n" +
+	"@@ -1,2 +0,0 @@\n" +
+	"-package old\n" +
+	"-\n" +
+	"```\n"
+
+func TestParseDiffLines(t *testing.T) {
+	files := parseDiffLines(reviewTestDiff)
+
+	p := files["parse.go"]
+	if p == nil {
+		t.Fatalf("parse.go missing: %v", files)
+	}
+	for _, n := range []int{10, 11, 12, 13} {
+		if !p.New[n] {
+			t.Errorf("new line %d should be commentable", n)
+		}
+	}
+	if p.New[14] || p.New[9] {
+		t.Errorf("new lines outside the hunk: %v", p.New)
+	}
+	for _, n := range []int{10, 11, 12} {
+		if !p.Old[n] {
+			t.Errorf("old line %d should be commentable", n)
+		}
+	}
+	if p.Old[13] || p.Old[9] {
+		t.Errorf("old lines outside the hunk: %v", p.Old)
+	}
+
+	o := files["old.go"]
+	if o == nil || !o.Old[1] || !o.Old[2] || len(o.New) != 0 {
+		t.Errorf("deleted file lines = %+v", o)
+	}
+}
+
+func TestParseReviewDraft(t *testing.T) {
+	ref := prRef{Host: hostBitbucket, Workspace: "acme", Repo: "widget", ID: 42}
+	d := &prData{PR: &bitbucket.PullRequest{Title: "Retries"}}
+	d.PR.Source.Commit.Hash = "abc123def456"
+	content := renderReviewDraft(ref, d, time.Date(2026, 3, 2, 10, 0, 0, 0, time.UTC)) +
+		"Looks close.\n\n" +
+		"## parse.go:12\n\nWhy 3?\n\n" +
+		"## parse.go:-11\n\n<!-- draft note -->\nWas 2 wrong?\n\n" +
+		"## parse.go:13\n\n"
+
+	draft, err := parseReviewDraft(content)
+	if err != nil {
+		t.Fatalf("parseReviewDraft: %v", err)
+	}
+	if draft.Ref != ref || draft.Commit != "abc123def456" {
+		t.Errorf("ref/commit = %+v %q", draft.Ref, draft.Commit)
+	}
+	if draft.General != "Looks close." {
+		t.Errorf("general = %q", draft.General)
+	}
+	want := []reviewComment{
+		{Path: "parse.go", Line: 12, Body: "Why 3?"},
+		{Path: "parse.go", Line: 11, Old: true, Body: "Was 2 wrong?"},
+	}
+	if len(draft.Comments) != len(want) {
+		t.Fatalf("comments = %+v", draft.Comments)
+	}
+	for i := range want {
+		if draft.Comments[i] != want[i] {
+			t.Errorf("comment %d = %+v, want %+v", i, draft.Comments[i], want[i])
+		}
+	}
+	if nc := draft.Comments[1].newComment(); nc.OldLine != 11 || nc.Line != 0 {
+		t.Errorf("old-line comment = %+v", nc)
+	}
+
+	if _, err := parseReviewDraft(content + "## parse.go\n\nno line\n"); err == nil {
+		t.Error("heading without a line should be rejected")
+	}
+	if _, err := parseReviewDraft("## parse.go:1\n\nhi\n"); err == nil {
+		t.Error("draft without header should be rejected")
+	}
+}
+
+func TestRestampReviewDraftKeepsComments(t *testing.T) {
+	ref := prRef{Host: hostBitbucket, Workspace: "acme", Repo: "widget", ID: 42}
+	content := reviewMetaLine(ref, "aaa", time.Now()) + "\n\n## parse.go:12\n\nWhy 3?\n"
+	out := restampReviewDraft(content, ref, "bbb", time.Now())
+	draft, err := parseReviewDraft(out)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if draft.Commit != "bbb" || len(draft.Comments) != 1 || strings.Count(out, "atlit:review") != 1 {
+		t.Errorf("restamped draft:\n%s", out)
+	}
+}
+
+func TestPRDiffSectionSkipsDescriptionHeadings(t *testing.T) {
+	content := "# PR #42: Retries\n\n" +
+		"## Description\n\nPlan:\n\n## Diff\n\nOnly parse.go:40 changes.\n\n" +
+		"```md\n## Diff\n```\n\n" +
+		"## Diffstat\n\n- parse.go (+2 -1)\n\n" +
+		"## Diff\n\n" + reviewTestDiff + "\n" +
+		"## Comments (1)\n\n#### Ann -- 2026-03-02\n\n## Diff\n\nnit\n"
+
+	diff := prDiffSection(content)
+	if !strings.HasPrefix(diff, "## Diff\n\n```diff\n") || strings.Contains(diff, "nit") {
+		t.Fatalf("diff section = %q", diff)
+	}
+	files := parseDiffLines(diff)
+	if p := files["parse.go"]; p == nil || !p.New[12] || p.New[40] {
+		t.Errorf("parsed lines = %+v", p)
+	}
+	if got := prDiffSection("# PR #42: Retries\n\n## Description\n\nText\n"); got != "" {
+		t.Errorf("no diff: %q", got)
+	}
+}
+
+func TestValidateReviewComments(t *testing.T) {
+	files := parseDiffLines(reviewTestDiff)
+	comments := []reviewComment{
+		{Path: "parse.go", Line: 12},
+		{Path: "parse.go", Line: 11, Old: true},
+		{Path: "old.go", Line: 2, Old: true},
+		{Path: "parse.go", Line: 40},
+		{Path: "parse.go", Line: 12, Old: true},
+		{Path: "parse.go", Line: 13, Old: true},
+		{Path: "missing.go", Line: 1},
+	}
+	problems := validateReviewComments(comments, files)
+	if len(problems) != 3 {
+		t.Fatalf("problems = %v", problems)
+	}
+	for i, prefix := range []string{"parse.go:40:", "parse.go:-13:", "missing.go:1:"} {
+		if !strings.HasPrefix(problems[i], prefix) {
+			t.Errorf("problem %d = %q, want prefix %q", i, problems[i], prefix)
+		}
+	}
+}
+
+func TestSameCommit(t *testing.T) {
+	cases := []struct {
+		a, b string
+		want bool
+	}{
+		{"abc123def456", "abc123def456", true},
+		{"abc123def456", "abc123def4567890", true},
+		{"abc123def456", "fff123def456", false},
+		{"", "abc", false},
+	}
+	for _, tc := range cases {
+		if got := sameCommit(tc.a, tc.b); got != tc.want {
+			t.Errorf("sameCommit(%q, %q) = %v, want %v", tc.a, tc.b, got, tc.want)
+		}
+	}
+}
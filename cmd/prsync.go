@@ -0,0 +1,203 @@
+package cmd
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"regexp"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/erickhilda/atlit/internal/bitbucket"
+	"github.com/erickhilda/atlit/internal/config"
+	"github.com/erickhilda/atlit/internal/renderer"
+	"github.com/erickhilda/atlit/internal/store"
+	"github.com/spf13/cobra"
+)
+
+var prSyncCmd = &cobra.Command{
+	Use:   "sync",
+	Short: "Refresh every saved PR that changed since it was fetched",
+	Long: `Checks each PR saved under prs_dir against its host and re-fetches the ones
+whose updated time moved (new commits, comments, approvals, state changes).
+My Notes are kept, as with 'atlit pr'. Files saved before atlit recorded the
+updated time are always refreshed.
+
+  atlit pr sync
+  atlit pr sync --since-last   # add an interdiff and new comments to each refreshed PR
+  atlit pr sync --dry-run      # only list what would be refreshed`,
+	Args: cobra.NoArgs,
+	RunE: runPRSync,
+}
+
+func init() {
+	prSyncCmd.Flags().Bool("since-last", false, "Add the interdiff and new comments since the previous fetch")
+	prSyncCmd.Flags().Bool("dry-run", false, "List the PRs that would be refreshed without fetching them")
+	prCmd.AddCommand(prSyncCmd)
+}
+
+// sinceLastSection renders what changed between the previous fetch (prev) and
+// the freshly fetched d: the interdiff of the source branch and the comments
+// created after the previous fetch.
+func sinceLastSection(clients *prClients, ref prRef, prev *store.PRMeta, d *prData) (string, error) {
+	cur := d.PR.Source.Commit.Hash
+	from, to := prev.Commit, cur
+	var interdiff string
+	if sameCommit(from, to) {
+		to = from
+	} else {
+		var err error
+		if interdiff, err = fetchInterdiff(clients, ref, from, to); err != nil {
+			return "", err
+		}
+	}
+	return renderer.RenderPullRequestChanges(from, to, prev.Fetched, interdiff, commentsSince(d.Comments, prev.Fetched)), nil
+}
+
+// commentsSince returns the visible comments created after t.
+func commentsSince(comments []bitbucket.Comment, t time.Time) []bitbucket.Comment {
+	var out []bitbucket.Comment
+	for _, cm := range comments {
+		if cm.Deleted || strings.TrimSpace(cm.Content.Raw) == "" {
+			continue
+		}
+		created, err := time.Parse(time.RFC3339, cm.CreatedOn)
+		if err == nil && created.After(t) {
+			out = append(out, cm)
+		}
+	}
+	return out
+}
+
+// insertBeforeSection inserts section before the "## " heading, or appends it
+// when the heading is missing.
+func insertBeforeSection(content, heading, section string) string {
+	if idx := strings.Index(content, "\n"+heading+"\n"); idx >= 0 {
+		return content[:idx+1] + section + content[idx+1:]
+	}
+	return strings.TrimRight(content, "\n") + "\n\n" + section
+}
+
+// prURLRowRe finds the URL row of a saved PR's metadata table.
+var prURLRowRe = regexp.MustCompile(`(?m)^\| URL \| (\S+) \|$`)
+
+// savedPRRef works out which PR a saved file is from the URL in its table
+// (any host), or from its meta line (Bitbucket) when there is no URL.
+func savedPRRef(content string, cfg *config.Config) (prRef, error) {
+	if m := prURLRowRe.FindStringSubmatch(content); m != nil {
+		return parsePRURL(m[1], cfg)
+	}
+	meta := store.ParsePRMeta(content)
+	if meta == nil {
+		return prRef{}, errors.New("no atlit:meta header")
+	}
+	return prRef{Host: hostBitbucket, Server: "bitbucket.org", Workspace: meta.Workspace, Repo: meta.Repo, ID: meta.ID}, nil
+}
+
+// savedPRFiles lists the PR markdown files in prsDir, leaving out review
+// drafts and saved pipeline logs.
+func savedPRFiles(prsDir string) ([]string, error) {
+	dir, err := config.ExpandPath(prsDir)
+	if err != nil {
+		return nil, err
+	}
+	files, err := filepath.Glob(filepath.Join(dir, "*.md"))
+	if err != nil {
+		return nil, err
+	}
+	var out []string
+	for _, f := range files {
+		if !strings.HasSuffix(f, ".review.md") && !strings.Contains(filepath.Base(f), ".pipeline-") {
+			out = append(out, f)
+		}
+	}
+	sort.Strings(out)
+	return out, nil
+}
+
+// savedPRFileOptions re-creates the layout a PR was saved with: split into
+// per-file diffs, without a diff (--no-diff), or with the whole diff, and
+// with or without context files. Include/exclude filters are not recorded and
+// so are not reapplied.
+func savedPRFileOptions(content string, ref prRef, sinceLast bool) prFileOptions {
+	opts := prFileOptions{sinceLast: sinceLast}
+	opts.contextFiles = strings.Contains(content, "]("+contextDirName(ref.fileKey())+"/")
+	switch {
+	case strings.Contains(content, "]("+splitDirName(ref.fileKey())+"/"):
+		opts.diff.split = true
+	case !strings.Contains(content, "\n## Diff\n"):
+		opts.noDiff = true
+	}
+	return opts
+}
+
+func runPRSync(cmd *cobra.Command, _ []string) error {
+	sinceLast, _ := cmd.Flags().GetBool("since-last")
+	dryRun, _ := cmd.Flags().GetBool("dry-run")
+
+	cfg, err := config.Load()
+	if err != nil {
+		return err
+	}
+	prsDir := cfg.PRsDirOrDefault()
+	files, err := savedPRFiles(prsDir)
+	if err != nil {
+		return err
+	}
+	if len(files) == 0 {
+		fmt.Printf("No saved PRs in %s\n", prsDir)
+		return nil
+	}
+
+	clients := newPRClients(cfg)
+	var refreshed, current, failed int
+	for _, f := range files {
+		data, err := os.ReadFile(f)
+		if err != nil {
+			return err
+		}
+		content := string(data)
+		ref, err := savedPRRef(content, cfg)
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "warning: skipping %s: %v\n", filepath.Base(f), err)
+			continue
+		}
+
+		pr, err := fetchPRMeta(clients, ref)
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "warning: %s: %v\n", ref, err)
+			failed++
+			continue
+		}
+		meta := store.ParsePRMeta(content)
+		if meta != nil && meta.Updated != "" && meta.Updated == pr.UpdatedOn {
+			current++
+			continue
+		}
+
+		if dryRun {
+			fmt.Printf("Would refresh %s (%s)\n", ref, f)
+			refreshed++
+			continue
+		}
+		_, _, err = savePRFile(cfg, clients, ref, savedPRFileOptions(content, ref, sinceLast && meta != nil && meta.Commit != ""))
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "warning: %s: %v\n", ref, err)
+			failed++
+			continue
+		}
+		refreshed++
+	}
+
+	verb := "Refreshed"
+	if dryRun {
+		verb = "Would refresh"
+	}
+	fmt.Printf("%s %d PR(s); %d up to date.\n", verb, refreshed, current)
+	if failed > 0 {
+		return fmt.Errorf("%d PR(s) could not be synced", failed)
+	}
+	return nil
+}
@@ -0,0 +1,95 @@
+package cmd
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/erickhilda/atlit/internal/bitbucket"
+	"github.com/erickhilda/atlit/internal/config"
+)
+
+func TestInsertBeforeSection(t *testing.T) {
+	content := "# PR #1\n\n| Field | Value |\n\n## Description\n\nText\n"
+	got := insertBeforeSection(content, "## Description", "## Since Last Fetch\n\nx\n\n")
+	want := "# PR #1\n\n| Field | Value |\n\n## Since Last Fetch\n\nx\n\n## Description\n\nText\n"
+	if got != want {
+		t.Errorf("got:\n%s\nwant:\n%s", got, want)
+	}
+	if got := insertBeforeSection("# PR\n", "## Description", "## S\n"); got != "# PR\n\n## S\n" {
+		t.Errorf("missing heading: %q", got)
+	}
+}
+
+func TestCommentsSince(t *testing.T) {
+	mk := func(created, body string, deleted bool) bitbucket.Comment {
+		c := bitbucket.Comment{CreatedOn: created, Deleted: deleted}
+		c.Content.Raw = body
+		return c
+	}
+	since := time.Date(2026, 6, 9, 12, 0, 0, 0, time.UTC)
+	got := commentsSince([]bitbucket.Comment{
+		mk("2026-06-09T10:00:00.000000+00:00", "old", false),
+		mk("2026-06-09T13:00:00.123456+00:00", "new", false),
+		mk("2026-06-09T14:00:00Z", "gone", true),
+	}, since)
+	if len(got) != 1 || got[0].Content.Raw != "new" {
+		t.Errorf("comments since = %+v", got)
+	}
+}
+
+func TestSavedPRRef(t *testing.T) {
+	cfg := &config.Config{}
+	gh := "<!-- atlit:meta pr=acme/widget/5 fetched=2026-06-10T12:00:00Z -->\n# PR #5: T\n\n| Field | Value |\n|-------|-------|\n| URL | https://github.com/acme/widget/pull/5 |\n"
+	ref, err := savedPRRef(gh, cfg)
+	if err != nil || ref.Host != hostGitHub || ref.Workspace != "acme" || ref.Repo != "widget" || ref.ID != 5 {
+		t.Errorf("github ref = %+v, %v", ref, err)
+	}
+
+	bb := "<!-- atlit:meta pr=acme/widget/42 fetched=2026-06-10T12:00:00Z -->\n# PR #42: T\n"
+	ref, err = savedPRRef(bb, cfg)
+	if err != nil || ref.Host != hostBitbucket || ref.String() != "acme/widget#42" {
+		t.Errorf("meta-only ref = %+v, %v", ref, err)
+	}
+
+	if _, err := savedPRRef("# notes\n", cfg); err == nil {
+		t.Error("file without meta should be rejected")
+	}
+}
+
+func TestSavedPRFileOptions(t *testing.T) {
+	ref := prRef{Host: hostBitbucket, Workspace: "acme", Repo: "widget", ID: 42}
+	cases := []struct {
+		name, content          string
+		noDiff, split, context bool
+	}{
+		{"full", "## Diffstat\n\n- a.go (+1 -0)\n\n## Diff\n\n```diff\n```\n", false, false, false},
+		{"no-diff", "## Diffstat\n\n- a.go (+1 -0)\n\n## Comments (0)\n", true, false, false},
+		{"split", "## Diffstat\n\n- a.go (+1 -0) -- [diff](acme__widget__42.files/a.go.md)\n", false, true, false},
+		{"context", "## Diffstat\n\n- a.go (+1 -0) -- context: [new](acme__widget__42.context/new/a.go)\n\n## Diff\n", false, false, true},
+	}
+	for _, tc := range cases {
+		opts := savedPRFileOptions(tc.content, ref, true)
+		if opts.noDiff != tc.noDiff || opts.diff.split != tc.split || opts.contextFiles != tc.context || !opts.sinceLast {
+			t.Errorf("%s: options = %+v", tc.name, opts)
+		}
+	}
+}
+
+func TestSavedPRFilesSkipsReviewDrafts(t *testing.T) {
+	dir := t.TempDir()
+	for _, name := range []string{"acme__widget__1.md", "acme__widget__1.review.md", "acme__widget__1.pipeline-12.md", "notes.txt"} {
+		if err := os.WriteFile(filepath.Join(dir, name), []byte("x"), 0644); err != nil {
+			t.Fatal(err)
+		}
+	}
+	files, err := savedPRFiles(dir)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(files) != 1 || !strings.HasSuffix(files[0], "acme__widget__1.md") {
+		t.Errorf("files = %v", files)
+	}
+}
@@ -0,0 +1,264 @@
+package cmd
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/erickhilda/atlit/internal/bitbucket"
+	"github.com/erickhilda/atlit/internal/config"
+	"github.com/spf13/cobra"
+)
+
+var prCommentCmd = &cobra.Command{
+	Use:   "comment <PR-REF>",
+	Short: "Comment on a Bitbucket pull request",
+	Long: `Posts a comment on a Bitbucket Cloud pull request. With --file and --line the
+comment is attached to that line of the new version of the file; --reply-to
+answers an existing comment (its id is shown by the Bitbucket UI and API).
+
+  atlit pr comment acme/widget/42 -m "Looks good overall"
+  atlit pr comment 42 -m "Off by one?" --file internal/parse.go --line 118
+  atlit pr comment widget/42 -m "Fixed in 3f2c1" --reply-to 901
+
+Write actions need a token with the write:pullrequest:bitbucket scope.`,
+	Args: cobra.ExactArgs(1),
+	RunE: runPRComment,
+}
+
+var prApproveCmd = &cobra.Command{
+	Use:   "approve <PR-REF>",
+	Short: "Approve a Bitbucket pull request",
+	Args:  cobra.ExactArgs(1),
+	RunE:  runPRReviewAction,
+}
+
+var prUnapproveCmd = &cobra.Command{
+	Use:   "unapprove <PR-REF>",
+	Short: "Withdraw your approval of a Bitbucket pull request",
+	Args:  cobra.ExactArgs(1),
+	RunE:  runPRReviewAction,
+}
+
+var prRequestChangesCmd = &cobra.Command{
+	Use:   "request-changes <PR-REF>",
+	Short: "Request changes on a Bitbucket pull request",
+	Args:  cobra.ExactArgs(1),
+	RunE:  runPRReviewAction,
+}
+
+var prMergeCmd = &cobra.Command{
+	Use:   "merge <PR-REF>",
+	Short: "Merge a Bitbucket pull request",
+	Long: `Merges a Bitbucket Cloud pull request. Without --strategy the repository's
+default merge strategy is used.
+
+  atlit pr merge acme/widget/42 --strategy squash -m "PROJ-1: importer retries"
+  atlit pr merge 42 --close-source-branch`,
+	Args: cobra.ExactArgs(1),
+	RunE: runPRMerge,
+}
+
+// mergeStrategies maps --strategy values to Bitbucket's merge_strategy.
+var mergeStrategies = map[string]string{
+	"merge":        bitbucket.MergeCommit,
+	"merge-commit": bitbucket.MergeCommit,
+	"squash":       bitbucket.MergeSquash,
+	"fast-forward": bitbucket.MergeFastForward,
+}
+
+func init() {
+	prCommentCmd.Flags().StringP("message", "m", "", "Comment text (markdown)")
+	prCommentCmd.Flags().String("file", "", "Attach the comment to this file (path in the repo)")
+	prCommentCmd.Flags().Int("line", 0, "Line in the new version of --file")
+	prCommentCmd.Flags().Int("reply-to", 0, "Reply to the comment with this id")
+
+	prMergeCmd.Flags().String("strategy", "", "Merge strategy: merge-commit, squash or fast-forward (default: repository setting)")
+	prMergeCmd.Flags().StringP("message", "m", "", "Merge commit message")
+	prMergeCmd.Flags().Bool("close-source-branch", false, "Delete the source branch after merging")
+
+	prCmd.AddCommand(prCommentCmd)
+	prCmd.AddCommand(prApproveCmd)
+	prCmd.AddCommand(prUnapproveCmd)
+	prCmd.AddCommand(prRequestChangesCmd)
+	prCmd.AddCommand(prMergeCmd)
+}
+
+// prepareBitbucketWrite resolves a PR reference for a write action, builds the
+// client and checks the user's access to the repository before anything is
+// changed (see checkBitbucketAccess). needWrite is set for merges, which need
+// write access; comments and reviews need only read access. Only Bitbucket
+// PRs support writes.
+func prepareBitbucketWrite(arg string, needWrite bool) (*bitbucket.Client, prRef, error) {
+	cfg, err := config.Load()
+	if err != nil {
+		return nil, prRef{}, err
+	}
+	ref, err := resolvePRRef(arg, cfg)
+	if err != nil {
+		return nil, prRef{}, err
+	}
+	client, err := bitbucketWriteClient(cfg, ref)
+	if err != nil {
+		return nil, prRef{}, err
+	}
+	if err := checkBitbucketAccess(client, ref, needWrite); err != nil {
+		return nil, prRef{}, err
+	}
+	return client, ref, nil
+}
+
+// bitbucketWriteClient returns a client for writing to ref. Bitbucket API
+// tokens do not report their scopes, so a missing write scope only shows when
+// the write is refused (ErrWriteForbidden, which names the scope).
+func bitbucketWriteClient(cfg *config.Config, ref prRef) (*bitbucket.Client, error) {
+	if ref.Host != hostBitbucket {
+		return nil, fmt.Errorf("%s is a %s PR; write actions are only supported for Bitbucket", ref, ref.Host)
+	}
+	client, err := newPRClients(cfg).bitbucket()
+	if err != nil {
+		return nil, err
+	}
+	return client, nil
+}
+
+// checkBitbucketAccess looks up the user's permission on ref's repository, a
+// read-only call, so bad credentials fail before any write and a merge without
+// write access is refused locally. Token scopes cannot be read back: when the
+// token may not list permissions the check is skipped, and a missing write
+// scope shows when Bitbucket refuses the write (ErrWriteForbidden).
+func checkBitbucketAccess(client *bitbucket.Client, ref prRef, needWrite bool) error {
+	perm, err := client.RepositoryPermission(ref.Workspace, ref.Repo)
+	if errors.Is(err, bitbucket.ErrForbidden) {
+		return nil
+	}
+	if err != nil {
+		return fmt.Errorf("checking your access to %s/%s: %w", ref.Workspace, ref.Repo, err)
+	}
+	return accessProblem(ref, perm, needWrite)
+}
+
+// accessProblem reports a repository permission too low for the action.
+// Without needWrite any permission will do: an unlisted one may still be a
+// public repository, and Bitbucket decides.
+func accessProblem(ref prRef, perm string, needWrite bool) error {
+	if !needWrite || perm == "write" || perm == "admin" {
+		return nil
+	}
+	if perm == "" {
+		perm = "no"
+	}
+	return fmt.Errorf("you have %s access to %s/%s; merging needs write access", perm, ref.Workspace, ref.Repo)
+}
+
+// wrapBBWriteError is wrapBBError for write actions.
+func wrapBBWriteError(err error, ref prRef) error {
+	if errors.Is(err, bitbucket.ErrWriteForbidden) {
+		return err
+	}
+	return wrapBBError(err, ref.Workspace, ref.Repo, ref.ID)
+}
+
+func runPRComment(cmd *cobra.Command, args []string) error {
+	message, _ := cmd.Flags().GetString("message")
+	file, _ := cmd.Flags().GetString("file")
+	line, _ := cmd.Flags().GetInt("line")
+	replyTo, _ := cmd.Flags().GetInt("reply-to")
+
+	nc, err := buildPRComment(message, file, line, replyTo)
+	if err != nil {
+		return err
+	}
+
+	client, ref, err := prepareBitbucketWrite(args[0], false)
+	if err != nil {
+		return err
+	}
+	cm, err := client.AddPullRequestComment(ref.Workspace, ref.Repo, ref.ID, nc)
+	if err != nil {
+		return wrapBBWriteError(err, ref)
+	}
+
+	where := ""
+	switch {
+	case nc.Path != "":
+		where = fmt.Sprintf(" on %s:%d", nc.Path, nc.Line)
+	case nc.ParentID > 0:
+		where = fmt.Sprintf(" in reply to #%d", nc.ParentID)
+	}
+	fmt.Printf("Posted comment #%d%s on %s.\n", cm.ID, where, ref)
+	return nil
+}
+
+// buildPRComment validates the comment flags.
+func buildPRComment(message, file string, line, replyTo int) (bitbucket.NewComment, error) {
+	nc := bitbucket.NewComment{Raw: strings.TrimSpace(message), Path: strings.TrimSpace(file), Line: line, ParentID: replyTo}
+	switch {
+	case nc.Raw == "":
+		return nc, errors.New("comment text is required (-m)")
+	case nc.Path != "" && nc.Line <= 0:
+		return nc, errors.New("--file needs --line (a line number in the new version of the file)")
+	case nc.Path == "" && nc.Line != 0:
+		return nc, errors.New("--line needs --file")
+	case nc.ParentID < 0:
+		return nc, fmt.Errorf("invalid --reply-to %d", nc.ParentID)
+	}
+	return nc, nil
+}
+
+// runPRReviewAction handles approve, unapprove and request-changes.
+func runPRReviewAction(cmd *cobra.Command, args []string) error {
+	client, ref, err := prepareBitbucketWrite(args[0], false)
+	if err != nil {
+		return err
+	}
+
+	var done string
+	switch cmd.Name() {
+	case "approve":
+		err, done = client.Approve(ref.Workspace, ref.Repo, ref.ID), "Approved"
+	case "unapprove":
+		err, done = client.Unapprove(ref.Workspace, ref.Repo, ref.ID), "Withdrew approval of"
+	default:
+		err, done = client.RequestChanges(ref.Workspace, ref.Repo, ref.ID), "Requested changes on"
+	}
+	if err != nil {
+		return wrapBBWriteError(err, ref)
+	}
+	fmt.Printf("%s %s.\n", done, ref)
+	return nil
+}
+
+func runPRMerge(cmd *cobra.Command, args []string) error {
+	strategyFlag, _ := cmd.Flags().GetString("strategy")
+	message, _ := cmd.Flags().GetString("message")
+	closeSource, _ := cmd.Flags().GetBool("close-source-branch")
+
+	strategy := ""
+	if s := strings.ToLower(strings.TrimSpace(strategyFlag)); s != "" {
+		var ok bool
+		if strategy, ok = mergeStrategies[s]; !ok {
+			return fmt.Errorf("invalid --strategy %q: use merge-commit, squash or fast-forward", strategyFlag)
+		}
+	}
+
+	client, ref, err := prepareBitbucketWrite(args[0], true)
+	if err != nil {
+		return err
+	}
+	pr, err := client.Merge(ref.Workspace, ref.Repo, ref.ID, strategy, strings.TrimSpace(message), closeSource)
+	if errors.Is(err, bitbucket.ErrMergePending) {
+		fmt.Printf("Merge of %s queued: Bitbucket is still merging it. Check with 'atlit pr %s'.\n", ref, ref.arg())
+		return nil
+	}
+	if err != nil {
+		return wrapBBWriteError(err, ref)
+	}
+
+	how := ""
+	if strategyFlag != "" {
+		how = " (" + strings.ToLower(strategyFlag) + ")"
+	}
+	fmt.Printf("Merged %s%s: %s -> %s, state %s.\n", ref, how, pr.Source.Branch.Name, pr.Destination.Branch.Name, pr.State)
+	return nil
+}
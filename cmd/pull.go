@@ -16,14 +16,19 @@ import (
 var pullCmd = &cobra.Command{
 	Use:   "pull <TICKET-KEY>",
 	Short: "Fetch a Jira ticket and save as markdown",
-	Long:  "Fetches a Jira issue via REST API, converts it to markdown, and saves it locally.",
-	Args:  cobra.ExactArgs(1),
-	RunE:  runPull,
+	Long: `Fetches a Jira issue via REST API, converts it to markdown, and saves it locally.
+
+With --with-prs, every pull request linked in the development panel is also
+fetched (as with 'atlit pr') into prs_dir, and the ticket's
+"## Pull Requests" entries point at the local PR files.`,
+	Args: cobra.ExactArgs(1),
+	RunE: runPull,
 }
 
 func init() {
 	pullCmd.Flags().Bool("comments-only", false, "Only update the comments section")
 	pullCmd.Flags().Bool("dry-run", false, "Show what would change without saving")
+	pullCmd.Flags().Bool("with-prs", false, "Also fetch the ticket's linked PRs into prs_dir and link them")
 	rootCmd.AddCommand(pullCmd)
 }
 
@@ -31,11 +36,22 @@ func runPull(cmd *cobra.Command, args []string) error {
 	ticketKey := strings.ToUpper(strings.TrimSpace(args[0]))
 	commentsOnly, _ := cmd.Flags().GetBool("comments-only")
 	dryRun, _ := cmd.Flags().GetBool("dry-run")
+	withPRs, _ := cmd.Flags().GetBool("with-prs")
+
+	if commentsOnly && withPRs {
+		return errors.New("--comments-only and --with-prs are mutually exclusive")
+	}
 
 	cfg, err := config.Load()
 	if err != nil {
 		return err
 	}
+	// --with-prs needs the linked PRs, so it overrides fetch_pull_requests
+	// the way --comments-only overrides fetch_comments.
+	if withPRs && !cfg.ShouldFetchPullRequests() {
+		fetch := true
+		cfg.FetchPullRequests = &fetch
+	}
 
 	token, err := config.GetToken(cfg)
 	if err != nil {
@@ -63,39 +79,166 @@ func runPull(cmd *cobra.Command, args []string) error {
 		return pullCommentsOnly(cfg, issue, canonicalKey, dryRun)
 	}
 
-	// Fetch development-panel pull requests via the dev-status API. This is an
-	// unofficial endpoint and may be unavailable, so treat failures as
-	// non-fatal: warn and fall back to preserving any existing PR section.
-	prFetched := false
-	if cfg.ShouldFetchPullRequests() {
-		prs, err := client.GetPullRequests(issue.ID)
+	content, prFetched := renderPulledIssue(cfg, client, issue, fetchComments)
+	if withPRs {
+		content = pullLinkedPRs(cfg, issue, content, prFetched, dryRun)
+	}
+
+	if dryRun {
+		return showDryRun(cfg, canonicalKey, content)
+	}
+
+	if err := store.Save(cfg.TicketsDir, canonicalKey, content); err != nil {
+		return fmt.Errorf("saving ticket: %w", err)
+	}
+
+	path, _ := store.TicketPath(cfg.TicketsDir, canonicalKey)
+	fmt.Printf("Saved %s to %s\n", canonicalKey, path)
+	return nil
+}
+
+// renderPulledIssue renders a freshly fetched issue the way `atlit pull` saves
+// it: development-panel pull requests are fetched and attached, and the local
+// file's user-owned sections are carried over. prFetched reports whether the
+// linked pull requests could be fetched.
+func renderPulledIssue(cfg *config.Config, client *jira.Client, issue *jira.Issue, fetchComments bool) (content string, prFetched bool) {
+	prFetched, devFetched := fetchDevelopment(client, issue, devDataTypes(cfg))
+
+	// The issue payload embeds only the first 20 worklogs; fetch the rest so
+	// the Work Log section is complete. On failure the partial log is kept.
+	if wl := issue.Fields.Worklog; wl != nil && wl.Total > len(wl.Worklogs) {
+		all, err := client.GetWorklogs(issue.Key)
 		if err != nil {
-			fmt.Fprintf(os.Stderr, "warning: could not fetch linked pull requests for %s: %v\n", canonicalKey, err)
+			fmt.Fprintf(os.Stderr, "warning: could not fetch the full work log for %s: %v\n", issue.Key, err)
 		} else {
-			issue.PullRequests = prs
-			prFetched = true
+			wl.Worklogs, wl.Total = all, len(all)
 		}
 	}
 
-	content := renderer.RenderIssue(issue)
+	content = renderer.RenderIssue(issue)
 
 	// Preserve existing "## My Notes" (and "## Comments"/"## Pull Requests" when
 	// we didn't fetch them) so local history survives re-pulls.
-	if existing, err := store.Load(cfg.TicketsDir, canonicalKey); err == nil {
-		content = preserveSections(existing, content, fetchComments, prFetched)
+	if existing, err := store.Load(cfg.TicketsDir, issue.Key); err == nil {
+		content = preserveSections(existing, content, fetchComments, prFetched, devFetched)
 	}
+	return content, prFetched
+}
 
-	if dryRun {
-		return showDryRun(cfg, canonicalKey, content)
+// fetchDevelopment fetches development-panel data (pull requests, branches,
+// commits, builds, deployments) via the dev-status API into issue. This is an
+// unofficial endpoint and may be unavailable, so failures are non-fatal: they
+// are warned about and the caller keeps the existing Pull Requests /
+// Development sections for what was not fetched. Pull requests stand apart
+// from the other types, which share "## Development" and so are only used
+// when all of them were fetched.
+func fetchDevelopment(client *jira.Client, issue *jira.Issue, dataTypes []string) (prFetched, devFetched bool) {
+	if len(dataTypes) == 0 {
+		return false, false
+	}
+	dev, err := client.GetDevelopment(issue.ID, dataTypes...)
+	var partial *jira.DevStatusError
+	switch {
+	case errors.As(err, &partial):
+		fmt.Fprintf(os.Stderr, "warning: could not fetch some development info for %s: %v\n", issue.Key, err)
+	case err != nil:
+		fmt.Fprintf(os.Stderr, "warning: could not fetch development info for %s: %v\n", issue.Key, err)
+		return false, false
+	}
+	if dev == nil {
+		return false, false
+	}
+	failed := func(dataType string) bool { return partial != nil && partial.Failed[dataType] != nil }
+
+	devTypes, devFailed := 0, false
+	for _, t := range dataTypes {
+		switch {
+		case t == jira.DevPullRequests:
+			prFetched = !failed(t)
+		case failed(t):
+			devFailed = true
+		default:
+			devTypes++
+		}
 	}
+	if prFetched {
+		issue.PullRequests = dev.PullRequests
+	}
+	devFetched = devTypes > 0 && !devFailed
+	if devFetched {
+		issue.Development = dev
+	}
+	return prFetched, devFetched
+}
 
-	if err := store.Save(cfg.TicketsDir, canonicalKey, content); err != nil {
-		return fmt.Errorf("saving ticket: %w", err)
+// pullLinkedPRs fetches the issue's pull requests (Bitbucket, GitHub or
+// GitLab, as with `atlit pr`) into prs_dir and returns content with its
+// "## Pull Requests" section linking the local files. PRs on unknown hosts,
+// and PRs that fail to fetch, are reported on stderr and keep their plain entry.
+// prFetched is false when the PR list itself could not be fetched (already
+// warned about); the saved section is then left as it is.
+func pullLinkedPRs(cfg *config.Config, issue *jira.Issue, content string, prFetched, dryRun bool) string {
+	if !prFetched {
+		fmt.Fprintf(os.Stderr, "%s: pull request list unavailable; no linked pull requests pulled\n", issue.Key)
+		return content
+	}
+	if len(issue.PullRequests) == 0 {
+		fmt.Fprintf(os.Stderr, "%s has no linked pull requests\n", issue.Key)
+		return content
 	}
 
-	path, _ := store.TicketPath(cfg.TicketsDir, canonicalKey)
-	fmt.Printf("Saved %s to %s\n", canonicalKey, path)
-	return nil
+	clients := newPRClients(cfg)
+
+	// The ticket file may not exist yet on a first pull; link it anyway since
+	// it is saved right after.
+	ticketPath, _ := store.TicketPath(cfg.TicketsDir, issue.Key)
+
+	linked := 0
+	for i := range issue.PullRequests {
+		pr := &issue.PullRequests[i]
+		ref, perr := parsePRURL(pr.URL, cfg)
+		if perr != nil {
+			fmt.Fprintf(os.Stderr, "warning: skipping %s: %v\n", firstNonEmpty(pr.ID, pr.Name), perr)
+			continue
+		}
+		path, _, serr := savePRFile(cfg, clients, ref, prFileOptions{
+			dryRun:     dryRun,
+			jiraKey:    issue.Key,
+			ticketPath: ticketPath,
+		})
+		if serr != nil {
+			fmt.Fprintf(os.Stderr, "warning: could not fetch %s: %v\n", ref, serr)
+			continue
+		}
+		pr.LocalPath = path
+		linked++
+	}
+
+	if linked == 0 {
+		return content
+	}
+	return store.ReplaceSection(content, "## Pull Requests", renderer.RenderPullRequests(issue.PullRequests))
+}
+
+// devDataTypes lists the dev-status data types enabled in config.
+func devDataTypes(cfg *config.Config) []string {
+	var types []string
+	if cfg.ShouldFetchPullRequests() {
+		types = append(types, jira.DevPullRequests)
+	}
+	if cfg.ShouldFetchBranches() {
+		types = append(types, jira.DevBranches)
+	}
+	if cfg.ShouldFetchCommits() {
+		types = append(types, jira.DevCommits)
+	}
+	if cfg.ShouldFetchBuilds() {
+		types = append(types, jira.DevBuilds)
+	}
+	if cfg.ShouldFetchDeployments() {
+		types = append(types, jira.DevDeployments)
+	}
+	return types
 }
 
 // preserveNotes appends the "## My Notes" section from oldContent into newContent.
@@ -112,14 +255,20 @@ func preserveNotes(oldContent, newContent string) string {
 // false the remote issue has no comments, so we also preserve any existing
 // "## Comments" block rather than dropping it from the file. Likewise, when
 // prFetched is false (PR fetch disabled or failed) we keep any existing
-// "## Pull Requests" block instead of silently dropping it. Order: Pull
-// Requests before Comments before Notes (matching how RenderIssue emits them).
-func preserveSections(oldContent, newContent string, fetchComments, prFetched bool) string {
+// "## Pull Requests" block instead of silently dropping it, and the same for
+// "## Development" when devFetched is false. Order: Pull Requests, then
+// Development, then Comments, then Notes (matching how RenderIssue emits them).
+func preserveSections(oldContent, newContent string, fetchComments, prFetched, devFetched bool) string {
 	if !prFetched {
 		if prs := store.ExtractSection(oldContent, "## Pull Requests"); prs != "" {
 			newContent = strings.TrimRight(newContent, "\n") + "\n\n" + prs
 		}
 	}
+	if !devFetched {
+		if dev := store.ExtractSection(oldContent, "## Development"); dev != "" {
+			newContent = strings.TrimRight(newContent, "\n") + "\n\n" + dev
+		}
+	}
 	if !fetchComments {
 		if comments := store.ExtractSection(oldContent, "## Comments"); comments != "" {
 			newContent = strings.TrimRight(newContent, "\n") + "\n\n" + comments
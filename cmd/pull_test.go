@@ -0,0 +1,84 @@
+package cmd
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/erickhilda/atlit/internal/config"
+	"github.com/erickhilda/atlit/internal/jira"
+)
+
+func TestDevDataTypes(t *testing.T) {
+	off := false
+	cfg := &config.Config{}
+	want := []string{jira.DevPullRequests, jira.DevBranches, jira.DevCommits, jira.DevBuilds, jira.DevDeployments}
+	if got := devDataTypes(cfg); strings.Join(got, ",") != strings.Join(want, ",") {
+		t.Errorf("defaults = %v, want %v", got, want)
+	}
+
+	cfg.FetchPullRequests = &off
+	cfg.FetchCommits = &off
+	want = []string{jira.DevBranches, jira.DevBuilds, jira.DevDeployments}
+	if got := devDataTypes(cfg); strings.Join(got, ",") != strings.Join(want, ",") {
+		t.Errorf("toggled = %v, want %v", got, want)
+	}
+}
+
+func TestPreserveSectionsDevelopment(t *testing.T) {
+	old := "# X-1: t\n\n## Development\n\n### Builds (1)\n\n- [FAILED] CI\n\n## My Notes\n\nmine\n"
+	fresh := "# X-1: t\n\n## Description\n\nbody\n"
+
+	got := preserveSections(old, fresh, true, true, false)
+	if !strings.Contains(got, "## Development\n\n### Builds (1)") {
+		t.Errorf("Development not preserved when not fetched:\n%s", got)
+	}
+	if strings.Index(got, "## Development") > strings.Index(got, "## My Notes") {
+		t.Errorf("Development should come before My Notes:\n%s", got)
+	}
+
+	if got := preserveSections(old, fresh, true, true, true); strings.Contains(got, "## Development") {
+		t.Errorf("Development kept although freshly fetched:\n%s", got)
+	}
+}
+
+func TestFetchDevelopmentKeepsPRsWhenBuildsFail(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/rest/dev-status/latest/issue/summary":
+			_, _ = w.Write([]byte(`{"summary":{
+				"pullrequest":{"overall":{"count":1},"byInstanceType":{"bitbucket":{"count":1}}},
+				"branch":{"overall":{"count":1},"byInstanceType":{"bitbucket":{"count":1}}},
+				"build":{"overall":{"count":1},"byInstanceType":{"cloud-providers":{"count":1}}}
+			}}`))
+		case "/rest/dev-status/latest/issue/detail":
+			switch r.URL.Query().Get("dataType") {
+			case jira.DevBuilds:
+				w.WriteHeader(http.StatusInternalServerError)
+			case jira.DevBranches:
+				_, _ = w.Write([]byte(`{"detail":[{"branches":[{"name":"feature/X-1"}]}]}`))
+			default:
+				_, _ = w.Write([]byte(`{"detail":[{"pullRequests":[{"id":"#9"}]}]}`))
+			}
+		}
+	}))
+	defer srv.Close()
+	client := jira.NewClient(srv.URL, "test@example.com", "token123")
+
+	issue := &jira.Issue{ID: "10001", Key: "X-1"}
+	prFetched, devFetched := fetchDevelopment(client, issue, []string{jira.DevPullRequests, jira.DevBranches, jira.DevBuilds})
+	if !prFetched || len(issue.PullRequests) != 1 {
+		t.Errorf("prFetched = %v, PRs = %+v; want the PRs despite the builds failure", prFetched, issue.PullRequests)
+	}
+	// Development is one section: with builds missing, the saved one is kept.
+	if devFetched || issue.Development != nil {
+		t.Errorf("devFetched = %v, Development = %+v", devFetched, issue.Development)
+	}
+
+	issue = &jira.Issue{ID: "10001", Key: "X-1"}
+	prFetched, devFetched = fetchDevelopment(client, issue, []string{jira.DevPullRequests, jira.DevBranches})
+	if !prFetched || !devFetched || issue.Development == nil || len(issue.Development.Branches) != 1 {
+		t.Errorf("no failures: prFetched = %v, devFetched = %v, Development = %+v", prFetched, devFetched, issue.Development)
+	}
+}
@@ -0,0 +1,291 @@
+package cmd
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"sort"
+	"strings"
+
+	"github.com/erickhilda/atlit/internal/config"
+	"github.com/erickhilda/atlit/internal/jira"
+	"github.com/erickhilda/atlit/internal/store"
+	"github.com/spf13/cobra"
+)
+
+// releaseNotesHeading is the ticket section aggregated by `atlit release-notes`;
+// it is also one of the sections `atlit push` sends by default.
+const releaseNotesHeading = "## Release Notes"
+
+var releaseNotesCmd = &cobra.Command{
+	Use:   "release-notes",
+	Short: "Aggregate the Release Notes sections of a release's tickets",
+	Long: `Collects the "## Release Notes" section from every ticket in a fix version
+(or matching a JQL query), groups them by issue type or epic, and prints
+markdown (or JSON) suitable for a CHANGELOG. Tickets without the section are
+listed on stderr and left out.
+
+Notes are read from the Jira description. With --local, a ticket's local file
+in tickets_dir is used instead when it exists, so unpushed edits are included.
+
+  atlit release-notes --fix-version 4.2.0
+  atlit release-notes --fix-version 4.2.0 --group-by epic >> CHANGELOG.md
+  atlit release-notes --jql "project = FOO AND fixVersion in unreleasedVersions()" --format json`,
+	Args: cobra.NoArgs,
+	RunE: runReleaseNotes,
+}
+
+func init() {
+	releaseNotesCmd.Flags().String("fix-version", "", "Fix version name, e.g. 4.2.0")
+	releaseNotesCmd.Flags().String("jql", "", "Raw JQL query (instead of --fix-version)")
+	releaseNotesCmd.Flags().String("project", "", "Restrict --fix-version to this project key (overrides default_project)")
+	releaseNotesCmd.Flags().Bool("all-projects", false, "Do not restrict --fix-version to a project")
+	releaseNotesCmd.Flags().String("group-by", "type", "Group notes by: type or epic")
+	releaseNotesCmd.Flags().String("format", "markdown", "Output format: markdown or json")
+	releaseNotesCmd.Flags().Bool("local", false, "Prefer local ticket files over the Jira description when present")
+	rootCmd.AddCommand(releaseNotesCmd)
+}
+
+// releaseNote is one ticket's contribution to the release notes.
+type releaseNote struct {
+	Key         string `json:"key"`
+	Summary     string `json:"summary"`
+	Type        string `json:"type"`
+	EpicKey     string `json:"epic_key,omitempty"`
+	EpicSummary string `json:"epic_summary,omitempty"`
+	Notes       string `json:"notes"`
+	URL         string `json:"url"`
+}
+
+// releaseGroup is a heading in the output and the notes under it.
+type releaseGroup struct {
+	Name  string        `json:"name"`
+	Notes []releaseNote `json:"notes"`
+}
+
+func runReleaseNotes(cmd *cobra.Command, _ []string) error {
+	fixVersion, _ := cmd.Flags().GetString("fix-version")
+	rawJQL, _ := cmd.Flags().GetString("jql")
+	project, _ := cmd.Flags().GetString("project")
+	allProjects, _ := cmd.Flags().GetBool("all-projects")
+	groupBy, _ := cmd.Flags().GetString("group-by")
+	format, _ := cmd.Flags().GetString("format")
+	local, _ := cmd.Flags().GetBool("local")
+
+	fixVersion = strings.TrimSpace(fixVersion)
+	rawJQL = strings.TrimSpace(rawJQL)
+	project = strings.TrimSpace(project)
+
+	switch {
+	case fixVersion == "" && rawJQL == "":
+		return errors.New("pass --fix-version or --jql")
+	case fixVersion != "" && rawJQL != "":
+		return errors.New("--fix-version and --jql are mutually exclusive")
+	case rawJQL != "" && (project != "" || allProjects):
+		return errors.New("--project/--all-projects only apply to --fix-version")
+	case project != "" && allProjects:
+		return errors.New("--project and --all-projects are mutually exclusive")
+	}
+	if groupBy != "type" && groupBy != "epic" {
+		return fmt.Errorf("invalid --group-by %q: use type or epic", groupBy)
+	}
+	if format != "markdown" && format != "json" {
+		return fmt.Errorf("invalid --format %q: use markdown or json", format)
+	}
+
+	cfg, err := config.Load()
+	if err != nil {
+		return err
+	}
+
+	jql := rawJQL
+	if jql == "" {
+		if project == "" && !allProjects {
+			project = cfg.DefaultProject
+		}
+		jql = fixVersionJQL(project, fixVersion)
+	}
+
+	token, err := config.GetToken(cfg)
+	if err != nil {
+		return fmt.Errorf("retrieving token: %w", err)
+	}
+	client := jira.NewClient(cfg.Instance, cfg.Email, token)
+
+	fields := []string{"summary", "issuetype", "description", "parent"}
+	if groupBy == "epic" {
+		// Company-managed projects link epics through the "Epic Link" custom
+		// field; team-managed ones use the parent. Without the field names
+		// only the parent is available, which is still useful.
+		if names, nerr := client.GetFieldNames(); nerr == nil {
+			for id, name := range names {
+				if strings.EqualFold(name, "epic link") {
+					fields = append(fields, id)
+				}
+			}
+		}
+	}
+
+	result, err := client.SearchIssues(jql, fields, 0)
+	if err != nil {
+		if errors.Is(err, jira.ErrUnauthorized) {
+			return fmt.Errorf("authentication failed: %w", err)
+		}
+		return fmt.Errorf("searching: %w", err)
+	}
+
+	var localSection func(key string) string
+	if local {
+		localSection = func(key string) string {
+			content, lerr := store.Load(cfg.TicketsDir, key)
+			if lerr != nil {
+				return ""
+			}
+			return store.ExtractSection(content, releaseNotesHeading)
+		}
+	}
+	notes, missing := collectReleaseNotes(result.Issues, cfg.Instance, localSection)
+	if len(missing) > 0 {
+		fmt.Fprintf(os.Stderr, "%d ticket(s) without a Release Notes section: %s\n", len(missing), strings.Join(missing, ", "))
+	}
+	groups := groupReleaseNotes(notes, groupBy)
+
+	if format == "json" {
+		out := struct {
+			Version string         `json:"version,omitempty"`
+			JQL     string         `json:"jql"`
+			Groups  []releaseGroup `json:"groups"`
+			Missing []string       `json:"missing"`
+		}{fixVersion, jql, groups, missing}
+		if out.Groups == nil {
+			out.Groups = []releaseGroup{}
+		}
+		if out.Missing == nil {
+			out.Missing = []string{}
+		}
+		data, err := json.MarshalIndent(out, "", "  ")
+		if err != nil {
+			return err
+		}
+		fmt.Println(string(data))
+		return nil
+	}
+
+	title := "Release Notes"
+	if fixVersion != "" {
+		title = fixVersion
+	}
+	fmt.Print(renderReleaseNotes(title, groups))
+	return nil
+}
+
+// fixVersionJQL builds the query for a fix version, scoped to projectKey when
+// set. Issues are ordered by key so the output is stable between runs.
+func fixVersionJQL(projectKey, version string) string {
+	var clauses []string
+	if projectKey != "" {
+		clauses = append(clauses, "project = "+quoteJQL(projectKey))
+	}
+	clauses = append(clauses, "fixVersion = "+quoteJQL(version))
+	return strings.Join(clauses, " AND ") + " ORDER BY key ASC"
+}
+
+// collectReleaseNotes extracts each issue's Release Notes section, preferring
+// localSection (when non-nil and non-empty) over the remote description.
+// Issues with an empty section are returned in missing.
+func collectReleaseNotes(issues []jira.Issue, instance string, localSection func(key string) string) (notes []releaseNote, missing []string) {
+	base := strings.TrimRight(instance, "/")
+	for _, is := range issues {
+		section := ""
+		if localSection != nil {
+			section = localSection(is.Key)
+		}
+		if section == "" && is.Fields.Description != nil {
+			section = store.ExtractSection(jira.RenderADF(is.Fields.Description), releaseNotesHeading)
+		}
+		body := strings.TrimSpace(sectionBody(section, releaseNotesHeading))
+		if body == "" {
+			missing = append(missing, is.Key)
+			continue
+		}
+
+		n := releaseNote{
+			Key:     is.Key,
+			Summary: is.Fields.Summary,
+			Type:    "Other",
+			Notes:   body,
+			URL:     base + "/browse/" + is.Key,
+		}
+		if is.Fields.IssueType != nil && is.Fields.IssueType.Name != "" {
+			n.Type = is.Fields.IssueType.Name
+		}
+		switch {
+		case is.Epic != nil && is.Epic.Key != "":
+			n.EpicKey, n.EpicSummary = is.Epic.Key, is.Epic.Summary
+		case is.Fields.Parent != nil && is.Fields.Parent.Fields.IssueType != nil &&
+			strings.EqualFold(is.Fields.Parent.Fields.IssueType.Name, "epic"):
+			n.EpicKey, n.EpicSummary = is.Fields.Parent.Key, is.Fields.Parent.Fields.Summary
+		}
+		notes = append(notes, n)
+	}
+	return notes, missing
+}
+
+// groupReleaseNotes buckets notes by issue type or epic. Groups are sorted by
+// name (epics by key) with the catch-all group last; notes keep query order.
+func groupReleaseNotes(notes []releaseNote, by string) []releaseGroup {
+	const noEpic = "No Epic"
+	var groups []releaseGroup
+	index := map[string]int{}
+	sortKey := map[string]string{}
+	for _, n := range notes {
+		name, key := n.Type, strings.ToLower(n.Type)
+		if by == "epic" {
+			switch {
+			case n.EpicKey == "":
+				name, key = noEpic, ""
+			case n.EpicSummary != "":
+				name, key = n.EpicKey+": "+n.EpicSummary, n.EpicKey
+			default:
+				name, key = n.EpicKey, n.EpicKey
+			}
+		}
+		i, ok := index[name]
+		if !ok {
+			i = len(groups)
+			index[name] = i
+			sortKey[name] = key
+			groups = append(groups, releaseGroup{Name: name})
+		}
+		groups[i].Notes = append(groups[i].Notes, n)
+	}
+	sort.SliceStable(groups, func(i, j int) bool {
+		a, b := groups[i].Name, groups[j].Name
+		// The catch-all groups sort after every named one.
+		if last := (a == noEpic || a == "Other"); last != (b == noEpic || b == "Other") {
+			return !last
+		}
+		return sortKey[a] < sortKey[b]
+	})
+	return groups
+}
+
+// renderReleaseNotes renders groups as a CHANGELOG-style markdown block.
+func renderReleaseNotes(title string, groups []releaseGroup) string {
+	var b strings.Builder
+	fmt.Fprintf(&b, "## %s\n\n", title)
+	if len(groups) == 0 {
+		b.WriteString("*No release notes.*\n")
+		return b.String()
+	}
+	for _, g := range groups {
+		fmt.Fprintf(&b, "### %s\n\n", g.Name)
+		for _, n := range g.Notes {
+			fmt.Fprintf(&b, "#### [%s](%s): %s\n\n", n.Key, n.URL, n.Summary)
+			b.WriteString(n.Notes)
+			b.WriteString("\n\n")
+		}
+	}
+	return b.String()
+}
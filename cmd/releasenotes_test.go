@@ -0,0 +1,104 @@
+package cmd
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/erickhilda/atlit/internal/jira"
+)
+
+func TestFixVersionJQL(t *testing.T) {
+	if got, want := fixVersionJQL("FOO", "4.2.0"), `project = "FOO" AND fixVersion = "4.2.0" ORDER BY key ASC`; got != want {
+		t.Errorf("got %q, want %q", got, want)
+	}
+	if got, want := fixVersionJQL("", "4.2.0"), `fixVersion = "4.2.0" ORDER BY key ASC`; got != want {
+		t.Errorf("got %q, want %q", got, want)
+	}
+}
+
+func releaseIssue(key, typ, notes string) jira.Issue {
+	is := jira.Issue{Key: key}
+	is.Fields.Summary = "Summary " + key
+	is.Fields.IssueType = &jira.IssueType{Name: typ}
+	if notes != "" {
+		is.Fields.Description = jira.MarkdownToADF("## Description\n\nText.\n\n## Release Notes\n\n" + notes + "\n")
+	}
+	return is
+}
+
+func TestCollectReleaseNotes(t *testing.T) {
+	withEpic := releaseIssue("FOO-2", "Story", "Added SSO.")
+	withEpic.Fields.Parent = &jira.ParentIssue{Key: "FOO-10"}
+	withEpic.Fields.Parent.Fields.Summary = "Auth"
+	withEpic.Fields.Parent.Fields.IssueType = &jira.IssueType{Name: "Epic"}
+
+	issues := []jira.Issue{
+		releaseIssue("FOO-1", "Bug", "Fixed login."),
+		withEpic,
+		releaseIssue("FOO-3", "Task", ""),
+		releaseIssue("FOO-4", "Bug", "Remote text."),
+	}
+	local := func(key string) string {
+		if key == "FOO-4" {
+			return "## Release Notes\n\nLocal text.\n"
+		}
+		return ""
+	}
+
+	notes, missing := collectReleaseNotes(issues, "https://x.atlassian.net/", local)
+	if len(missing) != 1 || missing[0] != "FOO-3" {
+		t.Errorf("missing = %v, want [FOO-3]", missing)
+	}
+	if len(notes) != 3 {
+		t.Fatalf("notes = %d, want 3", len(notes))
+	}
+	if notes[0].Notes != "Fixed login." || notes[0].URL != "https://x.atlassian.net/browse/FOO-1" {
+		t.Errorf("notes[0] = %+v", notes[0])
+	}
+	if notes[1].EpicKey != "FOO-10" || notes[1].EpicSummary != "Auth" {
+		t.Errorf("notes[1] epic = %q/%q", notes[1].EpicKey, notes[1].EpicSummary)
+	}
+	if notes[2].Notes != "Local text." {
+		t.Errorf("local notes = %q, want Local text.", notes[2].Notes)
+	}
+}
+
+func TestGroupReleaseNotes(t *testing.T) {
+	notes := []releaseNote{
+		{Key: "FOO-1", Type: "Story", EpicKey: "FOO-20"},
+		{Key: "FOO-2", Type: "Bug"},
+		{Key: "FOO-3", Type: "Other"},
+		{Key: "FOO-4", Type: "Story", EpicKey: "FOO-10", EpicSummary: "Auth"},
+		{Key: "FOO-5", Type: "Bug", EpicKey: "FOO-20"},
+	}
+
+	names := func(gs []releaseGroup) string {
+		var out []string
+		for _, g := range gs {
+			var keys []string
+			for _, n := range g.Notes {
+				keys = append(keys, n.Key)
+			}
+			out = append(out, g.Name+"="+strings.Join(keys, ","))
+		}
+		return strings.Join(out, " | ")
+	}
+
+	if got, want := names(groupReleaseNotes(notes, "type")), "Bug=FOO-2,FOO-5 | Story=FOO-1,FOO-4 | Other=FOO-3"; got != want {
+		t.Errorf("by type = %q, want %q", got, want)
+	}
+	if got, want := names(groupReleaseNotes(notes, "epic")), "FOO-10: Auth=FOO-4 | FOO-20=FOO-1,FOO-5 | No Epic=FOO-2,FOO-3"; got != want {
+		t.Errorf("by epic = %q, want %q", got, want)
+	}
+}
+
+func TestRenderReleaseNotes(t *testing.T) {
+	groups := []releaseGroup{{Name: "Bug", Notes: []releaseNote{{Key: "FOO-1", Summary: "Login", URL: "u", Notes: "Fixed."}}}}
+	want := "## 4.2.0\n\n### Bug\n\n#### [FOO-1](u): Login\n\nFixed.\n\n"
+	if got := renderReleaseNotes("4.2.0", groups); got != want {
+		t.Errorf("got %q, want %q", got, want)
+	}
+	if got := renderReleaseNotes("x", nil); !strings.Contains(got, "No release notes") {
+		t.Errorf("empty = %q", got)
+	}
+}
@@ -3,20 +3,28 @@ package cmd
 import (
 	"errors"
 	"fmt"
+	"regexp"
+	"sort"
 	"strings"
 	"time"
 
 	"github.com/erickhilda/atlit/internal/config"
 	"github.com/erickhilda/atlit/internal/jira"
+	"github.com/erickhilda/atlit/internal/renderer"
 	"github.com/spf13/cobra"
 )
 
-// searchFields are the issue fields requested for the list view. Keeping this
-// narrow makes the JQL search payload small and the decode cheap.
-var searchFields = []string{"summary", "status", "assignee", "issuetype", "updated"}
+// defaultSearchColumns is the table layout when --columns is not given.
+const defaultSearchColumns = "key,summary,status,assignee,updated"
+
+// savedQueryNameRe restricts saved query names to something easy to type after "@".
+var savedQueryNameRe = regexp.MustCompile(`^[A-Za-z0-9_-]+$`)
+
+// orderByRe matches a trailing ORDER BY clause so --sort can replace it.
+var orderByRe = regexp.MustCompile(`(?is)\s*\bORDER\s+BY\b.*$`)
 
 var searchCmd = &cobra.Command{
-	Use:   "search",
+	Use:   "search [@saved-query]",
 	Short: "Search Jira tickets with preset filters or raw JQL",
 	Long: `Searches Jira and lists matching tickets as a table on stdout. Nothing is
 written to disk; run 'atlit pull <KEY>' to fetch a chosen ticket.
@@ -31,11 +39,41 @@ Preset filters (composed with AND, scoped to default_project unless overridden):
   atlit search --mine --all-projects                 # drop the project scope
 
 Advanced (raw JQL escape hatch; cannot be combined with preset filters):
-  atlit search --jql "project = FOO AND sprint in openSprints() ORDER BY updated DESC"`,
-	Args: cobra.NoArgs,
+  atlit search --jql "project = FOO AND sprint in openSprints() ORDER BY updated DESC"
+
+Saved queries (see 'atlit search save'):
+  atlit search @triage                               # run the query saved as "triage"
+
+Output:
+  atlit search --mine --all                          # every match, not just --limit rows
+  atlit search --mine --columns key,status,sprint,points
+  atlit search @triage --sort -priority,created      # "-" sorts descending
+
+Columns: key, summary, status, assignee, type, priority, created, updated,
+labels, sprint, points, epic.`,
+	Args: validateSearchArgs,
 	RunE: runSearch,
 }
 
+var searchSaveCmd = &cobra.Command{
+	Use:   "save <name>",
+	Short: "Save a named JQL query for 'atlit search @<name>'",
+	Long: `Saves a raw JQL query under a name in config, so it can be run later with
+'atlit search @<name>'. Saving an existing name replaces it.
+
+  atlit search save triage --jql "project = FOO AND status = Triage ORDER BY created"
+  atlit search save triage --delete`,
+	Args: cobra.ExactArgs(1),
+	RunE: runSearchSave,
+}
+
+var searchQueriesCmd = &cobra.Command{
+	Use:   "queries",
+	Short: "List saved search queries",
+	Args:  cobra.NoArgs,
+	RunE:  runSearchQueries,
+}
+
 func init() {
 	searchCmd.Flags().String("status", "", "Filter by status name; comma-separate for multiple (e.g. \"code review,stage test\")")
 	searchCmd.Flags().String("assignee", "", "Filter by assignee; a name or email resolved to a Jira account")
@@ -45,10 +83,19 @@ func init() {
 	searchCmd.Flags().String("project", "", "Restrict to this project key (overrides default_project)")
 	searchCmd.Flags().Bool("all-projects", false, "Do not restrict to a project")
 	searchCmd.Flags().Int("limit", 30, "Maximum number of tickets to list (rows shown, not the query total)")
+	searchCmd.Flags().Bool("all", false, "List every matching ticket, following pagination (ignores --limit)")
+	searchCmd.Flags().String("columns", defaultSearchColumns, "Comma-separated table columns")
+	searchCmd.Flags().String("sort", "", "Comma-separated sort fields, \"-\" prefix for descending (replaces any ORDER BY)")
+
+	searchSaveCmd.Flags().String("jql", "", "JQL query to save")
+	searchSaveCmd.Flags().Bool("delete", false, "Delete the saved query instead of saving")
+	searchCmd.AddCommand(searchSaveCmd)
+	searchCmd.AddCommand(searchQueriesCmd)
+
 	rootCmd.AddCommand(searchCmd)
 }
 
-func runSearch(cmd *cobra.Command, _ []string) error {
+func runSearch(cmd *cobra.Command, args []string) error {
 	status, _ := cmd.Flags().GetString("status")
 	assignee, _ := cmd.Flags().GetString("assignee")
 	mine, _ := cmd.Flags().GetBool("mine")
@@ -57,13 +104,24 @@ func runSearch(cmd *cobra.Command, _ []string) error {
 	project, _ := cmd.Flags().GetString("project")
 	allProjects, _ := cmd.Flags().GetBool("all-projects")
 	limit, _ := cmd.Flags().GetInt("limit")
+	all, _ := cmd.Flags().GetBool("all")
+	columnsFlag, _ := cmd.Flags().GetString("columns")
+	sortFlag, _ := cmd.Flags().GetString("sort")
 
 	status = strings.TrimSpace(status)
 	assignee = strings.TrimSpace(assignee)
 	rawJQL = strings.TrimSpace(rawJQL)
 	project = strings.TrimSpace(project)
 
-	if err := validateSearchFlags(status, assignee, mine, active, rawJQL, project, allProjects); err != nil {
+	if all && cmd.Flags().Changed("limit") {
+		return errors.New("--all and --limit are mutually exclusive")
+	}
+	if all {
+		limit = 0
+	}
+
+	columns, err := parseSearchColumns(columnsFlag)
+	if err != nil {
 		return err
 	}
 
@@ -71,6 +129,20 @@ func runSearch(cmd *cobra.Command, _ []string) error {
 	if err != nil {
 		return err
 	}
+
+	// A saved query stands in for --jql, so it follows the same contract.
+	if len(args) == 1 {
+		if status != "" || assignee != "" || mine || active || rawJQL != "" || project != "" || allProjects {
+			return errors.New("a saved query cannot be combined with filter flags (--status/--assignee/--mine/--active/--jql/--project/--all-projects)")
+		}
+		rawJQL, err = lookupSavedQuery(cfg, args[0])
+		if err != nil {
+			return err
+		}
+	} else if err := validateSearchFlags(status, assignee, mine, active, rawJQL, project, allProjects); err != nil {
+		return err
+	}
+
 	token, err := config.GetToken(cfg)
 	if err != nil {
 		return fmt.Errorf("retrieving token: %w", err)
@@ -97,8 +169,25 @@ func runSearch(cmd *cobra.Command, _ []string) error {
 		}
 		jql = buildJQL(projectKey, status, assigneeClause, active)
 	}
+	if sortFlag != "" {
+		if jql, err = applySort(jql, sortFlag); err != nil {
+			return err
+		}
+	}
 
-	result, err := client.SearchIssues(jql, searchFields, limit)
+	// Sprint, points and epic live in custom fields whose ids vary per site;
+	// look them up only when one of those columns is requested.
+	var names map[string]string
+	if needsCustomFields(columns) {
+		if names, err = client.GetFieldNames(); err != nil {
+			if errors.Is(err, jira.ErrUnauthorized) {
+				return fmt.Errorf("authentication failed: %w", err)
+			}
+			return fmt.Errorf("looking up custom fields: %w", err)
+		}
+	}
+
+	result, err := client.SearchIssues(jql, searchFieldsFor(columns, names), limit)
 	if err != nil {
 		if errors.Is(err, jira.ErrUnauthorized) {
 			return fmt.Errorf("authentication failed: %w", err)
@@ -106,7 +195,99 @@ func runSearch(cmd *cobra.Command, _ []string) error {
 		return fmt.Errorf("searching: %w", err)
 	}
 
-	printSearchResults(jql, result.Issues, limit)
+	printSearchResults(jql, result, columns, limit)
+	return nil
+}
+
+// validateSearchArgs accepts at most one positional argument, which must be a
+// saved query reference of the form "@name".
+func validateSearchArgs(_ *cobra.Command, args []string) error {
+	if len(args) > 1 {
+		return fmt.Errorf("accepts at most one saved query (@name), received %d arguments", len(args))
+	}
+	if len(args) == 1 && !strings.HasPrefix(args[0], "@") {
+		return fmt.Errorf("unexpected argument %q: saved queries are referenced as @name (see 'atlit search queries')", args[0])
+	}
+	return nil
+}
+
+// lookupSavedQuery resolves an "@name" reference against the saved queries.
+func lookupSavedQuery(cfg *config.Config, ref string) (string, error) {
+	name := strings.TrimPrefix(ref, "@")
+	jql, ok := cfg.Queries[name]
+	if !ok || strings.TrimSpace(jql) == "" {
+		return "", fmt.Errorf("no saved query %q; save one with 'atlit search save %s --jql \"...\"'", name, name)
+	}
+	return jql, nil
+}
+
+func runSearchSave(cmd *cobra.Command, args []string) error {
+	jql, _ := cmd.Flags().GetString("jql")
+	del, _ := cmd.Flags().GetBool("delete")
+	jql = strings.TrimSpace(jql)
+
+	name := strings.TrimPrefix(strings.TrimSpace(args[0]), "@")
+	if !savedQueryNameRe.MatchString(name) {
+		return fmt.Errorf("invalid query name %q: use letters, digits, '-' or '_'", name)
+	}
+	if del && jql != "" {
+		return errors.New("--delete and --jql are mutually exclusive")
+	}
+	if !del && jql == "" {
+		return errors.New("--jql is required (or --delete to remove the query)")
+	}
+
+	cfg, err := config.Load()
+	if err != nil {
+		return err
+	}
+
+	if del {
+		if _, ok := cfg.Queries[name]; !ok {
+			return fmt.Errorf("no saved query %q", name)
+		}
+		delete(cfg.Queries, name)
+		if err := config.Save(cfg); err != nil {
+			return err
+		}
+		fmt.Printf("Deleted saved query @%s\n", name)
+		return nil
+	}
+
+	if cfg.Queries == nil {
+		cfg.Queries = map[string]string{}
+	}
+	_, existed := cfg.Queries[name]
+	cfg.Queries[name] = jql
+	if err := config.Save(cfg); err != nil {
+		return err
+	}
+	verb := "Saved"
+	if existed {
+		verb = "Updated"
+	}
+	fmt.Printf("%s @%s. Run 'atlit search @%s'.\n", verb, name, name)
+	return nil
+}
+
+func runSearchQueries(_ *cobra.Command, _ []string) error {
+	cfg, err := config.Load()
+	if err != nil {
+		return err
+	}
+	if len(cfg.Queries) == 0 {
+		fmt.Println("No saved queries. Save one with 'atlit search save <name> --jql \"...\"'.")
+		return nil
+	}
+	names := make([]string, 0, len(cfg.Queries))
+	for n := range cfg.Queries {
+		names = append(names, n)
+	}
+	sort.Strings(names)
+	fmt.Printf("%-20s %s\n", "NAME", "JQL")
+	for _, n := range names {
+		fmt.Printf("%-20s %s\n", "@"+n, cfg.Queries[n])
+	}
 	return nil
 }
 
@@ -244,41 +425,227 @@ func pickUser(name string, users []jira.User) (string, error) {
 	return "", errors.New(b.String())
 }
 
+// applySort replaces any ORDER BY clause in jql with one built from spec, a
+// comma-separated list of fields where a leading "-" means descending (e.g.
+// "-priority,created"). "type" is accepted as an alias for issuetype.
+func applySort(jql, spec string) (string, error) {
+	var terms []string
+	for _, f := range splitCSV(spec) {
+		dir := "ASC"
+		if strings.HasPrefix(f, "-") {
+			dir = "DESC"
+			f = strings.TrimSpace(f[1:])
+		}
+		if f == "" {
+			return "", fmt.Errorf("invalid --sort %q: empty field name", spec)
+		}
+		if strings.EqualFold(f, "type") {
+			f = "issuetype"
+		}
+		terms = append(terms, f+" "+dir)
+	}
+	if len(terms) == 0 {
+		return "", fmt.Errorf("invalid --sort %q", spec)
+	}
+	base := strings.TrimSpace(orderByRe.ReplaceAllString(jql, ""))
+	if base == "" {
+		return "ORDER BY " + strings.Join(terms, ", "), nil
+	}
+	return base + " ORDER BY " + strings.Join(terms, ", "), nil
+}
+
+// searchColumn describes one selectable table column.
+type searchColumn struct {
+	header string
+	width  int      // display width; the last column is never padded
+	fields []string // standard issue fields the column needs
+	custom string   // custom field the column needs: "sprint", "points" or "epic"
+	value  func(now time.Time, is *jira.Issue) string
+}
+
+// searchColumns are the columns accepted by --columns.
+var searchColumns = map[string]searchColumn{
+	"key":     {header: "KEY", width: 16, value: func(_ time.Time, is *jira.Issue) string { return is.Key }},
+	"summary": {header: "SUMMARY", width: 45, fields: []string{"summary"}, value: func(_ time.Time, is *jira.Issue) string { return is.Fields.Summary }},
+	"status": {header: "STATUS", width: 16, fields: []string{"status"}, value: func(_ time.Time, is *jira.Issue) string {
+		if is.Fields.Status == nil {
+			return "-"
+		}
+		return is.Fields.Status.Name
+	}},
+	"assignee": {header: "ASSIGNEE", width: 16, fields: []string{"assignee"}, value: func(_ time.Time, is *jira.Issue) string {
+		if is.Fields.Assignee == nil || is.Fields.Assignee.DisplayName == "" {
+			return "Unassigned"
+		}
+		return is.Fields.Assignee.DisplayName
+	}},
+	"type": {header: "TYPE", width: 12, fields: []string{"issuetype"}, value: func(_ time.Time, is *jira.Issue) string {
+		if is.Fields.IssueType == nil {
+			return "-"
+		}
+		return is.Fields.IssueType.Name
+	}},
+	"priority": {header: "PRIORITY", width: 10, fields: []string{"priority"}, value: func(_ time.Time, is *jira.Issue) string {
+		if is.Fields.Priority == nil {
+			return "-"
+		}
+		return is.Fields.Priority.Name
+	}},
+	"created": {header: "CREATED", width: 12, fields: []string{"created"}, value: func(now time.Time, is *jira.Issue) string {
+		return formatSearchUpdated(now, is.Fields.Created)
+	}},
+	"updated": {header: "UPDATED", width: 12, fields: []string{"updated"}, value: func(now time.Time, is *jira.Issue) string {
+		return formatSearchUpdated(now, is.Fields.Updated)
+	}},
+	"labels": {header: "LABELS", width: 24, fields: []string{"labels"}, value: func(_ time.Time, is *jira.Issue) string {
+		if len(is.Fields.Labels) == 0 {
+			return "-"
+		}
+		return strings.Join(is.Fields.Labels, ",")
+	}},
+	"sprint": {header: "SPRINT", width: 20, custom: "sprint", value: func(_ time.Time, is *jira.Issue) string {
+		if is.Sprint == nil || is.Sprint.Name == "" {
+			return "-"
+		}
+		return is.Sprint.Name
+	}},
+	"points": {header: "POINTS", width: 6, custom: "points", value: func(_ time.Time, is *jira.Issue) string {
+		if is.StoryPoints == nil {
+			return "-"
+		}
+		return renderer.FormatPoints(*is.StoryPoints)
+	}},
+	"epic": {header: "EPIC", width: 16, custom: "epic", value: func(_ time.Time, is *jira.Issue) string {
+		if is.Epic == nil || is.Epic.Key == "" {
+			return "-"
+		}
+		return is.Epic.Key
+	}},
+}
+
+// parseSearchColumns validates the --columns value, returning the column names
+// in order. Duplicates are dropped.
+func parseSearchColumns(flag string) ([]string, error) {
+	var cols []string
+	seen := map[string]bool{}
+	for _, c := range splitCSV(strings.ToLower(flag)) {
+		if _, ok := searchColumns[c]; !ok {
+			valid := make([]string, 0, len(searchColumns))
+			for name := range searchColumns {
+				valid = append(valid, name)
+			}
+			sort.Strings(valid)
+			return nil, fmt.Errorf("unknown column %q; valid columns: %s", c, strings.Join(valid, ", "))
+		}
+		if !seen[c] {
+			seen[c] = true
+			cols = append(cols, c)
+		}
+	}
+	if len(cols) == 0 {
+		return nil, errors.New("--columns must list at least one column")
+	}
+	return cols, nil
+}
+
+// needsCustomFields reports whether any column reads a custom field.
+func needsCustomFields(cols []string) bool {
+	for _, c := range cols {
+		if searchColumns[c].custom != "" {
+			return true
+		}
+	}
+	return false
+}
+
+// searchFieldsFor returns the issue fields to request for cols. Keeping this
+// narrow makes the JQL search payload small and the decode cheap. Custom
+// columns resolve their field ids through names (id -> display name); a
+// column whose field the site lacks simply renders "-".
+func searchFieldsFor(cols []string, names map[string]string) []string {
+	var fields []string
+	seen := map[string]bool{}
+	add := func(f string) {
+		if !seen[f] {
+			seen[f] = true
+			fields = append(fields, f)
+		}
+	}
+	for _, c := range cols {
+		col := searchColumns[c]
+		for _, f := range col.fields {
+			add(f)
+		}
+		switch col.custom {
+		case "points":
+			for _, id := range jira.StoryPointsFieldIDs(names) {
+				add(id)
+			}
+		case "sprint", "epic":
+			want := "sprint"
+			if col.custom == "epic" {
+				want = "epic link"
+			}
+			for id, name := range names {
+				if strings.EqualFold(name, want) {
+					add(id)
+				}
+			}
+		}
+	}
+	if len(fields) == 0 {
+		// The key is always returned; ask for a cheap field so the server does
+		// not fall back to its (large) default field set.
+		add("summary")
+	}
+	return fields
+}
+
 // printSearchResults renders the result table (or an empty-result line) to
 // stdout, echoing the effective JQL for transparency.
-func printSearchResults(jql string, issues []jira.Issue, limit int) {
+func printSearchResults(jql string, result *jira.SearchResult, cols []string, limit int) {
 	fmt.Printf("JQL: %s\n", jql)
-	if len(issues) == 0 {
+	if len(result.Issues) == 0 {
 		fmt.Println("\nNo tickets match.")
 		return
 	}
 
-	fmt.Printf("\n%-16s %-45s %-16s %-16s %s\n", "KEY", "SUMMARY", "STATUS", "ASSIGNEE", "UPDATED")
+	headers := make([]string, len(cols))
+	for i, c := range cols {
+		headers[i] = searchColumns[c].header
+	}
+	fmt.Printf("\n%s\n", formatSearchRow(cols, headers))
+
 	now := time.Now()
-	for i := range issues {
-		is := &issues[i]
-		status := "-"
-		if is.Fields.Status != nil {
-			status = is.Fields.Status.Name
-		}
-		assignee := "Unassigned"
-		if is.Fields.Assignee != nil && is.Fields.Assignee.DisplayName != "" {
-			assignee = is.Fields.Assignee.DisplayName
-		}
-		fmt.Printf("%-16s %-45s %-16s %-16s %s\n",
-			is.Key,
-			truncate(is.Fields.Summary, 45),
-			truncate(status, 16),
-			truncate(assignee, 16),
-			formatSearchUpdated(now, is.Fields.Updated),
-		)
-	}
-
-	if limit > 0 && len(issues) >= limit {
-		fmt.Printf("\nShowing first %d (raise --limit for more). Run 'atlit pull <KEY>' to fetch one.\n", limit)
+	for i := range result.Issues {
+		is := &result.Issues[i]
+		cells := make([]string, len(cols))
+		for j, c := range cols {
+			cells[j] = searchColumns[c].value(now, is)
+		}
+		fmt.Println(formatSearchRow(cols, cells))
+	}
+
+	if !result.IsLast && limit > 0 {
+		fmt.Printf("\nShowing first %d (raise --limit or use --all for more). Run 'atlit pull <KEY>' to fetch one.\n", limit)
 	} else {
-		fmt.Printf("\n%d ticket(s). Run 'atlit pull <KEY>' to fetch one.\n", len(issues))
+		fmt.Printf("\n%d ticket(s). Run 'atlit pull <KEY>' to fetch one.\n", len(result.Issues))
+	}
+}
+
+// formatSearchRow pads and truncates each cell to its column width, leaving
+// the last cell unpadded.
+func formatSearchRow(cols, cells []string) string {
+	var b strings.Builder
+	for i, c := range cols {
+		if i == len(cols)-1 {
+			b.WriteString(cells[i])
+			break
+		}
+		w := searchColumns[c].width
+		fmt.Fprintf(&b, "%-*s ", w, truncate(cells[i], w))
 	}
+	return b.String()
 }
 
 // formatSearchUpdated renders a Jira timestamp as a relative age, falling back
@@ -1,9 +1,11 @@
 package cmd
 
 import (
+	"fmt"
 	"strings"
 	"testing"
 
+	"github.com/erickhilda/atlit/internal/config"
 	"github.com/erickhilda/atlit/internal/jira"
 )
 
@@ -202,3 +204,106 @@ func TestStatusClause(t *testing.T) {
 		t.Errorf("trailing comma+space = %q", got)
 	}
 }
+
+func TestApplySort(t *testing.T) {
+	tests := []struct {
+		name    string
+		jql     string
+		spec    string
+		want    string
+		wantErr bool
+	}{
+		{"appends", "project = FOO", "created", "project = FOO ORDER BY created ASC", false},
+		{"descending and alias", "project = FOO", "-priority,type", "project = FOO ORDER BY priority DESC, issuetype ASC", false},
+		{"replaces existing", "project = FOO ORDER BY updated DESC", "-created", "project = FOO ORDER BY created DESC", false},
+		{"replaces lowercase", "status = Open order by rank", "key", "status = Open ORDER BY key ASC", false},
+		{"order only", "ORDER BY updated DESC", "created", "ORDER BY created ASC", false},
+		{"empty field", "project = FOO", "-", "", true},
+		{"empty spec", "project = FOO", " , ", "", true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := applySort(tt.jql, tt.spec)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("err = %v, wantErr %v", err, tt.wantErr)
+			}
+			if got != tt.want {
+				t.Errorf("applySort() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestParseSearchColumns(t *testing.T) {
+	got, err := parseSearchColumns("Key, status,points,key")
+	if err != nil {
+		t.Fatalf("parseSearchColumns: %v", err)
+	}
+	want := []string{"key", "status", "points"}
+	if strings.Join(got, ",") != strings.Join(want, ",") {
+		t.Errorf("columns = %v, want %v", got, want)
+	}
+
+	if _, err := parseSearchColumns("key,bogus"); err == nil || !strings.Contains(err.Error(), "bogus") {
+		t.Errorf("expected unknown-column error, got %v", err)
+	}
+	if _, err := parseSearchColumns(" , "); err == nil {
+		t.Error("expected error for empty column list")
+	}
+}
+
+func TestSearchFieldsFor(t *testing.T) {
+	names := map[string]string{
+		"customfield_10020": "Sprint",
+		"customfield_10016": "Story point estimate",
+		"customfield_10014": "Epic Link",
+		"summary":           "Summary",
+	}
+	got := searchFieldsFor([]string{"key", "summary", "sprint", "points", "epic", "summary"}, names)
+	want := []string{"summary", "customfield_10020", "customfield_10016", "customfield_10014"}
+	if strings.Join(got, ",") != strings.Join(want, ",") {
+		t.Errorf("fields = %v, want %v", got, want)
+	}
+
+	if got := searchFieldsFor([]string{"key"}, nil); strings.Join(got, ",") != "summary" {
+		t.Errorf("key-only fields = %v, want [summary]", got)
+	}
+}
+
+func TestFormatSearchRowDefaultLayout(t *testing.T) {
+	cols, err := parseSearchColumns(defaultSearchColumns)
+	if err != nil {
+		t.Fatal(err)
+	}
+	cells := []string{"PROJ-1", "Fix it", "To Do", "Alice", "2h ago"}
+	want := fmt.Sprintf("%-16s %-45s %-16s %-16s %s", "PROJ-1", "Fix it", "To Do", "Alice", "2h ago")
+	if got := formatSearchRow(cols, cells); got != want {
+		t.Errorf("row = %q, want %q", got, want)
+	}
+}
+
+func TestValidateSearchArgs(t *testing.T) {
+	if err := validateSearchArgs(nil, nil); err != nil {
+		t.Errorf("no args: %v", err)
+	}
+	if err := validateSearchArgs(nil, []string{"@triage"}); err != nil {
+		t.Errorf("@triage: %v", err)
+	}
+	if err := validateSearchArgs(nil, []string{"triage"}); err == nil {
+		t.Error("expected error for bare name")
+	}
+	if err := validateSearchArgs(nil, []string{"@a", "@b"}); err == nil {
+		t.Error("expected error for two args")
+	}
+}
+
+func TestLookupSavedQuery(t *testing.T) {
+	cfg := &config.Config{Queries: map[string]string{"triage": "status = Triage"}}
+	got, err := lookupSavedQuery(cfg, "@triage")
+	if err != nil || got != "status = Triage" {
+		t.Errorf("lookupSavedQuery = %q, %v", got, err)
+	}
+	if _, err := lookupSavedQuery(cfg, "@missing"); err == nil {
+		t.Error("expected error for unknown query")
+	}
+}
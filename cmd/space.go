@@ -0,0 +1,103 @@
+package cmd
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/erickhilda/atlit/internal/config"
+	"github.com/erickhilda/atlit/internal/confluence"
+	"github.com/erickhilda/atlit/internal/renderer"
+	"github.com/spf13/cobra"
+)
+
+var spaceCmd = &cobra.Command{
+	Use:   "space",
+	Short: "Work with whole Confluence spaces",
+}
+
+var spaceExportCmd = &cobra.Command{
+	Use:   "export <SPACE-KEY>",
+	Short: "Save every page of a Confluence space as markdown",
+	Long: `Saves the pages of a Confluence space under <pages_dir>/<SPACE-KEY>/ in a
+directory tree mirroring the page hierarchy: each page is <key>.md and its
+children live in a directory named <key>/. An index.md lists the hierarchy.
+My Notes sections of previously saved pages are kept.
+
+  atlit space export ENG
+  atlit space export ENG --depth 2     # top-level pages and two levels below
+  atlit space export ENG --dry-run`,
+	Args: cobra.ExactArgs(1),
+	RunE: runSpaceExport,
+}
+
+func init() {
+	spaceExportCmd.Flags().Int("depth", 0, "Levels of children to save below the top-level pages (0 = all)")
+	spaceExportCmd.Flags().Bool("dry-run", false, "List the pages that would be saved without writing")
+	spaceCmd.AddCommand(spaceExportCmd)
+	rootCmd.AddCommand(spaceCmd)
+}
+
+func runSpaceExport(cmd *cobra.Command, args []string) error {
+	depth, _ := cmd.Flags().GetInt("depth")
+	dryRun, _ := cmd.Flags().GetBool("dry-run")
+	if depth < 0 {
+		return fmt.Errorf("invalid --depth %d", depth)
+	}
+	spaceKey := strings.TrimSpace(args[0])
+	if spaceKey == "" {
+		return errors.New("space key is required")
+	}
+
+	cfg, err := config.Load()
+	if err != nil {
+		return err
+	}
+	token, err := config.GetToken(cfg)
+	if err != nil {
+		return fmt.Errorf("retrieving token: %w", err)
+	}
+	fetcher := newPageFetcher(cfg, token)
+	client := fetcher.client
+
+	space, err := client.GetSpaceByKey(spaceKey)
+	if err != nil {
+		if errors.Is(err, confluence.ErrNotFound) {
+			return fmt.Errorf("space %s not found or no access", spaceKey)
+		}
+		return wrapConfluenceError(err, spaceKey)
+	}
+	fetcher.spaces[space.ID] = space
+	roots, err := client.GetSpaceRootPages(space.ID)
+	if err != nil {
+		return wrapConfluenceError(err, spaceKey)
+	}
+
+	dir, err := config.ExpandPath(cfg.PagesDirOrDefault())
+	if err != nil {
+		return err
+	}
+	dir = filepath.Join(dir, space.Key)
+	t, err := newPageTree(fetcher, dir, depth, dryRun)
+	if err != nil {
+		return err
+	}
+	var entries []renderer.PageIndexEntry
+	for _, r := range roots {
+		doc, err := fetcher.fetch(r.ID)
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "warning: %v\n", err)
+			t.failed++
+			continue
+		}
+		entries = append(entries, t.save(doc, dir, 0))
+	}
+
+	title := space.Key
+	if space.Name != "" {
+		title = space.Key + ": " + space.Name
+	}
+	return t.finish(title, "space:"+space.Key, entries)
+}
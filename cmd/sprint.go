@@ -0,0 +1,240 @@
+package cmd
+
+import (
+	"fmt"
+	"sort"
+	"time"
+
+	"github.com/erickhilda/atlit/internal/config"
+	"github.com/erickhilda/atlit/internal/jira"
+	"github.com/erickhilda/atlit/internal/renderer"
+	"github.com/erickhilda/atlit/internal/store"
+	"github.com/spf13/cobra"
+)
+
+// sprintFields are the issue fields requested for the sprint view; story-point
+// field ids are appended at runtime once discovered.
+var sprintFields = []string{"summary", "status", "assignee", "issuetype"}
+
+var sprintCmd = &cobra.Command{
+	Use:   "sprint",
+	Short: "Show the active sprint of a Jira board",
+	Long: `Shows the active sprint of a Jira Software board: name, goal, dates, and its
+issues grouped by status (To Do, then In Progress, then Done) with story points.
+Nothing is written to disk unless --pull is given.
+
+The board defaults to 'board_id' from config; --board overrides it.
+
+  atlit sprint                 active sprint of the configured board
+  atlit sprint --board 42      another board
+  atlit sprint --pull          also fetch every ticket in the sprint into tickets_dir`,
+	Args: cobra.NoArgs,
+	RunE: runSprint,
+}
+
+func init() {
+	sprintCmd.Flags().Int("board", 0, "Board id (overrides board_id from config)")
+	sprintCmd.Flags().Bool("pull", false, "Fetch every ticket in the sprint into tickets_dir")
+	rootCmd.AddCommand(sprintCmd)
+}
+
+func runSprint(cmd *cobra.Command, _ []string) error {
+	boardFlag, _ := cmd.Flags().GetInt("board")
+	pull, _ := cmd.Flags().GetBool("pull")
+
+	cfg, err := config.Load()
+	if err != nil {
+		return err
+	}
+	boardID, err := resolveBoardID(boardFlag, cfg)
+	if err != nil {
+		return err
+	}
+
+	token, err := config.GetToken(cfg)
+	if err != nil {
+		return fmt.Errorf("retrieving token: %w", err)
+	}
+	client := jira.NewClient(cfg.Instance, cfg.Email, token)
+
+	sprints, err := client.ListSprints(boardID, "active")
+	if err != nil {
+		return wrapBoardError(err, boardID)
+	}
+	if len(sprints) == 0 {
+		fmt.Printf("No active sprint on board %d.\n", boardID)
+		return nil
+	}
+
+	// The Agile issue endpoint ignores expand=names, so look the field names up
+	// once to locate story points. Without them the view simply omits points.
+	fields := sprintFields
+	names, nerr := client.GetFieldNames()
+	if nerr == nil {
+		fields = append(append([]string{}, sprintFields...), jira.StoryPointsFieldIDs(names)...)
+	}
+
+	now := time.Now()
+	for i, sp := range sprints {
+		issues, err := client.GetSprintIssues(sp.ID, fields, names)
+		if err != nil {
+			return wrapBoardError(err, boardID)
+		}
+		if i > 0 {
+			fmt.Println()
+		}
+		printSprint(now, sp, issues)
+
+		if pull {
+			pullSprintIssues(cfg, client, issues)
+		}
+	}
+	return nil
+}
+
+// statusGroup is one status bucket of the sprint view.
+type statusGroup struct {
+	Status string
+	Issues []jira.Issue
+}
+
+// statusCategoryRank orders groups To Do -> In Progress -> Done; statuses
+// without a category sort last.
+func statusCategoryRank(s *jira.Status) int {
+	if s == nil || s.StatusCategory == nil {
+		return 3
+	}
+	switch s.StatusCategory.Key {
+	case "new":
+		return 0
+	case "indeterminate":
+		return 1
+	case "done":
+		return 2
+	default:
+		return 3
+	}
+}
+
+// groupByStatus buckets issues by status name, ordering buckets by status
+// category and then by first appearance (the Agile API returns issues in rank
+// order, so that is also the order within each bucket).
+func groupByStatus(issues []jira.Issue) []statusGroup {
+	var groups []statusGroup
+	rank := map[string]int{}
+	index := map[string]int{}
+	for _, is := range issues {
+		name := "No status"
+		if is.Fields.Status != nil && is.Fields.Status.Name != "" {
+			name = is.Fields.Status.Name
+		}
+		i, ok := index[name]
+		if !ok {
+			i = len(groups)
+			index[name] = i
+			rank[name] = statusCategoryRank(is.Fields.Status)
+			groups = append(groups, statusGroup{Status: name})
+		}
+		groups[i].Issues = append(groups[i].Issues, is)
+	}
+	// A stable sort keeps first-appearance order within a category.
+	sort.SliceStable(groups, func(i, j int) bool {
+		return rank[groups[i].Status] < rank[groups[j].Status]
+	})
+	return groups
+}
+
+// sumPoints totals the story points of issues, skipping unestimated ones.
+func sumPoints(issues []jira.Issue) float64 {
+	var total float64
+	for _, is := range issues {
+		if is.StoryPoints != nil {
+			total += *is.StoryPoints
+		}
+	}
+	return total
+}
+
+// sprintDates renders "start -> end" plus the days remaining (or "ended")
+// relative to now; missing dates render as "-".
+func sprintDates(now time.Time, sp jira.Sprint) string {
+	start, end := "-", "-"
+	if t, err := parseJiraTime(sp.StartDate); err == nil {
+		start = t.Format("2006-01-02")
+	}
+	endT, err := parseJiraTime(sp.EndDate)
+	if err != nil {
+		return start + " -> " + end
+	}
+	end = endT.Format("2006-01-02")
+	left := endT.Sub(now)
+	switch {
+	case left < 0:
+		return fmt.Sprintf("%s -> %s (ended)", start, end)
+	case left < 24*time.Hour:
+		return fmt.Sprintf("%s -> %s (ends today)", start, end)
+	default:
+		return fmt.Sprintf("%s -> %s (%dd left)", start, end, int(left.Hours()/24))
+	}
+}
+
+// printSprint renders the sprint header and the per-status issue tables.
+func printSprint(now time.Time, sp jira.Sprint, issues []jira.Issue) {
+	fmt.Printf("Sprint: %s (%s)\n", sp.Name, sp.State)
+	if sp.Goal != "" {
+		fmt.Printf("Goal:   %s\n", sp.Goal)
+	}
+	fmt.Printf("Dates:  %s\n", sprintDates(now, sp))
+
+	if len(issues) == 0 {
+		fmt.Println("\nNo issues in this sprint.")
+		return
+	}
+
+	var done []jira.Issue
+	for _, g := range groupByStatus(issues) {
+		fmt.Printf("\n%s (%d, %s pts)\n", g.Status, len(g.Issues), renderer.FormatPoints(sumPoints(g.Issues)))
+		for _, is := range g.Issues {
+			assignee := "Unassigned"
+			if is.Fields.Assignee != nil && is.Fields.Assignee.DisplayName != "" {
+				assignee = is.Fields.Assignee.DisplayName
+			}
+			points := "-"
+			if is.StoryPoints != nil {
+				points = renderer.FormatPoints(*is.StoryPoints)
+			}
+			fmt.Printf("  %-16s %-45s %-16s %s\n",
+				is.Key, truncate(is.Fields.Summary, 45), truncate(assignee, 16), points)
+			if statusCategoryRank(is.Fields.Status) == 2 {
+				done = append(done, is)
+			}
+		}
+	}
+
+	fmt.Printf("\n%d issue(s), %s pts (%s done).\n",
+		len(issues), renderer.FormatPoints(sumPoints(issues)), renderer.FormatPoints(sumPoints(done)))
+}
+
+// pullSprintIssues fetches and saves every sprint issue exactly as `atlit pull`
+// would, reporting per-ticket progress like `atlit sync`. Failures are
+// reported and skipped so one bad ticket does not abort the batch.
+func pullSprintIssues(cfg *config.Config, client *jira.Client, issues []jira.Issue) {
+	fmt.Printf("\nPulling %d ticket(s) into %s\n", len(issues), cfg.TicketsDir)
+	fetchComments := cfg.ShouldFetchComments()
+	pulled := 0
+	for _, is := range issues {
+		issue, err := client.GetIssueWithFields(is.Key, issueFieldsFor(fetchComments))
+		if err != nil {
+			fmt.Printf("  %s: error: %v\n", is.Key, err)
+			continue
+		}
+		content, _ := renderPulledIssue(cfg, client, issue, fetchComments)
+		if err := store.Save(cfg.TicketsDir, issue.Key, content); err != nil {
+			fmt.Printf("  %s: save error: %v\n", issue.Key, err)
+			continue
+		}
+		fmt.Printf("  %s: saved\n", issue.Key)
+		pulled++
+	}
+	fmt.Printf("Pulled %d/%d tickets.\n", pulled, len(issues))
+}
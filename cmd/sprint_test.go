@@ -0,0 +1,68 @@
+package cmd
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/erickhilda/atlit/internal/jira"
+)
+
+func sprintIssue(key, status, category string, points *float64) jira.Issue {
+	is := jira.Issue{Key: key, StoryPoints: points}
+	if status != "" {
+		is.Fields.Status = &jira.Status{Name: status, StatusCategory: &jira.StatusCategory{Key: category}}
+	}
+	return is
+}
+
+func pts(v float64) *float64 { return &v }
+
+func TestGroupByStatus(t *testing.T) {
+	issues := []jira.Issue{
+		sprintIssue("P-1", "Done", "done", pts(3)),
+		sprintIssue("P-2", "In Review", "indeterminate", nil),
+		sprintIssue("P-3", "To Do", "new", pts(1)),
+		sprintIssue("P-4", "In Progress", "indeterminate", pts(2)),
+		sprintIssue("P-5", "In Review", "indeterminate", pts(5)),
+		sprintIssue("P-6", "", "", nil),
+	}
+	groups := groupByStatus(issues)
+
+	var order []string
+	for _, g := range groups {
+		order = append(order, g.Status)
+	}
+	want := "To Do,In Review,In Progress,Done,No status"
+	if strings.Join(order, ",") != want {
+		t.Errorf("group order = %v, want %s", order, want)
+	}
+	if len(groups[1].Issues) != 2 || groups[1].Issues[0].Key != "P-2" || groups[1].Issues[1].Key != "P-5" {
+		t.Errorf("In Review group = %+v", groups[1].Issues)
+	}
+	if got := sumPoints(groups[1].Issues); got != 5 {
+		t.Errorf("In Review points = %v, want 5", got)
+	}
+	if got := sumPoints(issues); got != 11 {
+		t.Errorf("total points = %v, want 11", got)
+	}
+}
+
+func TestSprintDates(t *testing.T) {
+	now := time.Date(2026, 6, 10, 12, 0, 0, 0, time.UTC)
+	cases := []struct {
+		start, end string
+		want       string
+	}{
+		{"2026-06-01T09:00:00.000Z", "2026-06-14T17:00:00.000Z", "2026-06-01 -> 2026-06-14 (4d left)"},
+		{"2026-06-01T09:00:00.000Z", "2026-06-10T17:00:00.000Z", "2026-06-01 -> 2026-06-10 (ends today)"},
+		{"2026-05-01T09:00:00.000Z", "2026-05-14T17:00:00.000Z", "2026-05-01 -> 2026-05-14 (ended)"},
+		{"", "", "- -> -"},
+	}
+	for _, tc := range cases {
+		got := sprintDates(now, jira.Sprint{StartDate: tc.start, EndDate: tc.end})
+		if got != tc.want {
+			t.Errorf("sprintDates(%q, %q) = %q, want %q", tc.start, tc.end, got, tc.want)
+		}
+	}
+}
@@ -8,7 +8,6 @@ import (
 
 	"github.com/erickhilda/atlit/internal/config"
 	"github.com/erickhilda/atlit/internal/jira"
-	"github.com/erickhilda/atlit/internal/renderer"
 	"github.com/erickhilda/atlit/internal/store"
 	"github.com/spf13/cobra"
 )
@@ -140,23 +139,9 @@ func runSync(cmd *cobra.Command, _ []string) error {
 			continue
 		}
 
-		// Fetch development-panel pull requests (non-fatal; see runPull).
-		prFetched := false
-		if cfg.ShouldFetchPullRequests() {
-			if prs, prErr := client.GetPullRequests(issue.ID); prErr != nil {
-				fmt.Printf("  %s: warning: could not fetch pull requests: %v\n", t.Key, prErr)
-			} else {
-				issue.PullRequests = prs
-				prFetched = true
-			}
-		}
-
-		content := renderer.RenderIssue(issue)
-
-		// Preserve local notes (and Comments/Pull Requests when we didn't fetch them).
-		if existing, loadErr := store.Load(cfg.TicketsDir, t.Key); loadErr == nil {
-			content = preserveSections(existing, content, fetchComments, prFetched)
-		}
+		// Development info, the full work log and preserved local sections are
+		// handled exactly as in `atlit pull`.
+		content, _ := renderPulledIssue(cfg, client, issue, fetchComments)
 
 		if err := store.Save(cfg.TicketsDir, t.Key, content); err != nil {
 			fmt.Printf("  %s: save error: %v\n", t.Key, err)
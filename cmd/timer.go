@@ -0,0 +1,137 @@
+package cmd
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/erickhilda/atlit/internal/config"
+	"github.com/spf13/cobra"
+)
+
+var timerCmd = &cobra.Command{
+	Use:   "timer",
+	Short: "Time work on a Jira ticket and log it",
+	Long: `A local stopwatch for 'atlit log'. 'start' records the ticket and start time in
+the config dir, so the timer keeps running across shells; 'stop' logs the
+elapsed time (rounded up to the minute) as a worklog on the ticket.
+
+  atlit timer start PROJ-1
+  atlit timer status
+  atlit timer stop -m "importer retries"
+  atlit timer stop --discard`,
+}
+
+var timerStartCmd = &cobra.Command{
+	Use:   "start <TICKET-KEY>",
+	Short: "Start timing work on a ticket",
+	Args:  cobra.ExactArgs(1),
+	RunE:  runTimerStart,
+}
+
+var timerStopCmd = &cobra.Command{
+	Use:   "stop",
+	Short: "Stop the timer and log the elapsed time",
+	Args:  cobra.NoArgs,
+	RunE:  runTimerStop,
+}
+
+var timerStatusCmd = &cobra.Command{
+	Use:   "status",
+	Short: "Show the running timer",
+	Args:  cobra.NoArgs,
+	RunE:  runTimerStatus,
+}
+
+func init() {
+	timerStopCmd.Flags().StringP("message", "m", "", "Worklog comment")
+	timerStopCmd.Flags().Bool("discard", false, "Stop without logging anything")
+
+	timerCmd.AddCommand(timerStartCmd)
+	timerCmd.AddCommand(timerStopCmd)
+	timerCmd.AddCommand(timerStatusCmd)
+	rootCmd.AddCommand(timerCmd)
+}
+
+func runTimerStart(_ *cobra.Command, args []string) error {
+	key := strings.ToUpper(strings.TrimSpace(args[0]))
+	if jiraKeyRe.FindString(key) != key {
+		return fmt.Errorf("invalid ticket key %q", args[0])
+	}
+
+	running, err := config.LoadTimer()
+	if err != nil {
+		return err
+	}
+	if running != nil {
+		return fmt.Errorf("a timer is already running for %s (%s); run 'atlit timer stop' first",
+			running.Ticket, formatWorkDuration(time.Since(running.Started)))
+	}
+
+	if err := config.SaveTimer(&config.Timer{Ticket: key, Started: time.Now()}); err != nil {
+		return err
+	}
+	fmt.Printf("Timer started for %s.\n", key)
+	return nil
+}
+
+func runTimerStop(cmd *cobra.Command, _ []string) error {
+	message, _ := cmd.Flags().GetString("message")
+	discard, _ := cmd.Flags().GetBool("discard")
+
+	running, err := config.LoadTimer()
+	if err != nil {
+		return err
+	}
+	if running == nil {
+		return errors.New("no timer running; start one with 'atlit timer start <TICKET-KEY>'")
+	}
+
+	if discard {
+		if err := config.ClearTimer(); err != nil {
+			return err
+		}
+		fmt.Printf("Timer for %s discarded.\n", running.Ticket)
+		return nil
+	}
+
+	cfg, err := config.Load()
+	if err != nil {
+		return err
+	}
+	dur := timerElapsed(running.Started, time.Now())
+	// The timer is cleared only once the worklog is saved, so a failed stop
+	// can simply be retried.
+	if err := logWork(cfg, running.Ticket, running.Started, dur, strings.TrimSpace(message)); err != nil {
+		return err
+	}
+	return config.ClearTimer()
+}
+
+func runTimerStatus(_ *cobra.Command, _ []string) error {
+	running, err := config.LoadTimer()
+	if err != nil {
+		return err
+	}
+	if running == nil {
+		fmt.Println("No timer running.")
+		return nil
+	}
+	fmt.Printf("%s: %s (since %s)\n", running.Ticket,
+		formatWorkDuration(time.Since(running.Started)), running.Started.Local().Format("2006-01-02 15:04"))
+	return nil
+}
+
+// timerElapsed returns the time between start and now rounded up to a whole
+// minute, and never less than the one-minute minimum Jira accepts.
+func timerElapsed(start, now time.Time) time.Duration {
+	d := now.Sub(start)
+	if r := d % time.Minute; r != 0 {
+		d += time.Minute - r
+	}
+	if d < minWorklogSeconds*time.Second {
+		d = minWorklogSeconds * time.Second
+	}
+	return d
+}
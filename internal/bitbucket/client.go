@@ -49,7 +49,7 @@ func (c *Client) GetPullRequest(workspace, repo string, id int) (*PullRequest, e
 // GetPullRequestDiff fetches the raw unified diff (text/plain).
 func (c *Client) GetPullRequestDiff(workspace, repo string, id int) (string, error) {
 	path := fmt.Sprintf("/repositories/%s/%s/pullrequests/%d/diff", workspace, repo, id)
-	resp, err := c.do(http.MethodGet, path, "text/plain")
+	resp, err := c.do(http.MethodGet, path, "text/plain", nil)
 	if err != nil {
 		return "", err
 	}
@@ -63,6 +63,46 @@ func (c *Client) GetPullRequestDiff(workspace, repo string, id int) (string, err
 	return string(body), nil
 }
 
+// GetCommitRangeDiff fetches the plain two-way diff from commit from to commit
+// to (not relative to a merge base), e.g. between two pushes of a PR branch.
+func (c *Client) GetCommitRangeDiff(workspace, repo, from, to string) (string, error) {
+	path := fmt.Sprintf("/repositories/%s/%s/diff/%s..%s?topic=false", workspace, repo, url.PathEscape(to), url.PathEscape(from))
+	resp, err := c.do(http.MethodGet, path, "text/plain", nil)
+	if err != nil {
+		return "", err
+	}
+	body, status, err := readAndClose(resp)
+	if err != nil {
+		return "", err
+	}
+	if err := classify(status, body); err != nil {
+		return "", err
+	}
+	return string(body), nil
+}
+
+// GetFileContent returns the raw contents of a file at a commit (the src
+// endpoint). A path that does not exist at that commit is ErrNotFound.
+func (c *Client) GetFileContent(workspace, repo, commit, filePath string) ([]byte, error) {
+	segs := strings.Split(filePath, "/")
+	for i, s := range segs {
+		segs[i] = url.PathEscape(s)
+	}
+	path := fmt.Sprintf("/repositories/%s/%s/src/%s/%s", workspace, repo, url.PathEscape(commit), strings.Join(segs, "/"))
+	resp, err := c.do(http.MethodGet, path, "", nil)
+	if err != nil {
+		return nil, err
+	}
+	body, status, err := readAndClose(resp)
+	if err != nil {
+		return nil, err
+	}
+	if err := classify(status, body); err != nil {
+		return nil, err
+	}
+	return body, nil
+}
+
 // GetPullRequestDiffstat returns per-file change stats, following pagination.
 func (c *Client) GetPullRequestDiffstat(workspace, repo string, id int) ([]DiffstatEntry, error) {
 	next := fmt.Sprintf("/repositories/%s/%s/pullrequests/%d/diffstat?pagelen=100", workspace, repo, id)
@@ -107,6 +147,51 @@ func (c *Client) GetPullRequestComments(workspace, repo string, id int) ([]Comme
 	return all, nil
 }
 
+// GetPullRequestTasks returns the PR's tasks, following pagination.
+func (c *Client) GetPullRequestTasks(workspace, repo string, id int) ([]Task, error) {
+	next := fmt.Sprintf("/repositories/%s/%s/pullrequests/%d/tasks?pagelen=100", workspace, repo, id)
+	var all []Task
+	for next != "" {
+		body, err := c.getJSON(next)
+		if err != nil {
+			return nil, err
+		}
+		var page struct {
+			Values []Task `json:"values"`
+			Next   string `json:"next"`
+		}
+		if err := json.Unmarshal(body, &page); err != nil {
+			return nil, fmt.Errorf("decoding tasks: %w", err)
+		}
+		all = append(all, page.Values...)
+		next = page.Next
+	}
+	return all, nil
+}
+
+// GetCommitStatuses returns the build statuses reported for a commit,
+// following pagination.
+func (c *Client) GetCommitStatuses(workspace, repo, commit string) ([]CommitStatus, error) {
+	next := fmt.Sprintf("/repositories/%s/%s/commit/%s/statuses?pagelen=100", workspace, repo, url.PathEscape(commit))
+	var all []CommitStatus
+	for next != "" {
+		body, err := c.getJSON(next)
+		if err != nil {
+			return nil, err
+		}
+		var page struct {
+			Values []CommitStatus `json:"values"`
+			Next   string         `json:"next"`
+		}
+		if err := json.Unmarshal(body, &page); err != nil {
+			return nil, fmt.Errorf("decoding commit statuses: %w", err)
+		}
+		all = append(all, page.Values...)
+		next = page.Next
+	}
+	return all, nil
+}
+
 // ListPullRequests returns pull requests for a repo, newest-updated first,
 // following pagination up to limit results. states filters by PR state
 // ("OPEN"/"MERGED"/"DECLINED"/"SUPERSEDED"); an empty slice returns all states.
@@ -143,16 +228,139 @@ func (c *Client) ListPullRequests(workspace, repo string, states []string, limit
 	return all, nil
 }
 
+// QueryPullRequests returns the pull requests in a repo matching a BBQL query
+// (e.g. `state="OPEN" AND reviewers.uuid="{...}"`), following pagination.
+// Participants are requested explicitly since list entries leave them out.
+func (c *Client) QueryPullRequests(workspace, repo, query string) ([]PullRequest, error) {
+	q := url.Values{}
+	q.Set("pagelen", "50")
+	q.Set("q", query)
+	q.Set("fields", "+values.participants")
+	next := fmt.Sprintf("/repositories/%s/%s/pullrequests?%s", workspace, repo, q.Encode())
+	var all []PullRequest
+	for next != "" {
+		body, err := c.getJSON(next)
+		if err != nil {
+			return nil, err
+		}
+		var page struct {
+			Values []PullRequest `json:"values"`
+			Next   string        `json:"next"`
+		}
+		if err := json.Unmarshal(body, &page); err != nil {
+			return nil, fmt.Errorf("decoding pull requests: %w", err)
+		}
+		all = append(all, page.Values...)
+		next = page.Next
+	}
+	return all, nil
+}
+
+// ListRepositories returns the workspace repositories the user is a member of,
+// most recently updated first, up to limit results (<= 0 means no cap).
+func (c *Client) ListRepositories(workspace string, limit int) ([]Repository, error) {
+	q := url.Values{}
+	q.Set("pagelen", "100")
+	q.Set("role", "member")
+	q.Set("sort", "-updated_on")
+	next := fmt.Sprintf("/repositories/%s?%s", url.PathEscape(workspace), q.Encode())
+	var all []Repository
+	for next != "" {
+		body, err := c.getJSON(next)
+		if err != nil {
+			return nil, err
+		}
+		var page struct {
+			Values []Repository `json:"values"`
+			Next   string       `json:"next"`
+		}
+		if err := json.Unmarshal(body, &page); err != nil {
+			return nil, fmt.Errorf("decoding repositories: %w", err)
+		}
+		all = append(all, page.Values...)
+		if limit > 0 && len(all) >= limit {
+			all = all[:limit]
+			break
+		}
+		next = page.Next
+	}
+	return all, nil
+}
+
+// DefaultReviewers returns the repository's effective default reviewers,
+// including those inherited from the project.
+func (c *Client) DefaultReviewers(workspace, repo string) ([]Account, error) {
+	next := fmt.Sprintf("/repositories/%s/%s/effective-default-reviewers?pagelen=100", workspace, repo)
+	var all []Account
+	for next != "" {
+		body, err := c.getJSON(next)
+		if err != nil {
+			return nil, err
+		}
+		var page struct {
+			Values []struct {
+				User Account `json:"user"`
+			} `json:"values"`
+			Next string `json:"next"`
+		}
+		if err := json.Unmarshal(body, &page); err != nil {
+			return nil, fmt.Errorf("decoding default reviewers: %w", err)
+		}
+		for _, v := range page.Values {
+			all = append(all, v.User)
+		}
+		next = page.Next
+	}
+	return all, nil
+}
+
+// CurrentUser returns the account the token belongs to.
+func (c *Client) CurrentUser() (*Account, error) {
+	body, err := c.getJSON("/user")
+	if err != nil {
+		return nil, err
+	}
+	var a Account
+	if err := json.Unmarshal(body, &a); err != nil {
+		return nil, fmt.Errorf("decoding user: %w", err)
+	}
+	return &a, nil
+}
+
 // VerifyWorkspace checks the token can read the given workspace's repositories.
 func (c *Client) VerifyWorkspace(workspace string) error {
 	_, err := c.getJSON("/repositories/" + url.PathEscape(workspace) + "?pagelen=1")
 	return err
 }
 
+// RepositoryPermission returns the current user's permission on a repository:
+// "read", "write" or "admin", or "" when the user has none listed (public
+// repositories can still be read without one).
+func (c *Client) RepositoryPermission(workspace, repo string) (string, error) {
+	q := url.Values{}
+	q.Set("q", fmt.Sprintf("repository.full_name=%q", workspace+"/"+repo))
+	body, err := c.getJSON("/user/permissions/repositories?" + q.Encode())
+	if err != nil {
+		return "", err
+	}
+	var page struct {
+		Values []struct {
+			Permission string `json:"permission"`
+		} `json:"values"`
+	}
+	if err := json.Unmarshal(body, &page); err != nil {
+		return "", fmt.Errorf("decoding repository permissions: %w", err)
+	}
+	if len(page.Values) == 0 {
+		return "", nil
+	}
+	return page.Values[0].Permission, nil
+}
+
 // getJSON performs a GET expecting JSON, returning the body after status checks.
 // pathOrURL may be a path (prefixed with baseURL) or a full URL (pagination next).
 func (c *Client) getJSON(pathOrURL string) ([]byte, error) {
-	resp, err := c.do(http.MethodGet, pathOrURL, "application/json")
+	resp, err := c.do(http.MethodGet, pathOrURL, "application/json", nil)
 	if err != nil {
 		return nil, err
 	}
@@ -166,12 +374,12 @@ func (c *Client) getJSON(pathOrURL string) ([]byte, error) {
 	return body, nil
 }
 
-func (c *Client) do(method, pathOrURL, accept string) (*http.Response, error) {
+func (c *Client) do(method, pathOrURL, accept string, body io.Reader) (*http.Response, error) {
 	target := pathOrURL
 	if !strings.HasPrefix(target, "http") {
 		target = c.baseURL + pathOrURL
 	}
-	req, err := http.NewRequest(method, target, nil)
+	req, err := http.NewRequest(method, target, body)
 	if err != nil {
 		return nil, fmt.Errorf("creating request: %w", err)
 	}
@@ -179,6 +387,9 @@ func (c *Client) do(method, pathOrURL, accept string) (*http.Response, error) {
 	if accept != "" {
 		req.Header.Set("Accept", accept)
 	}
+	if body != nil {
+		req.Header.Set("Content-Type", "application/json")
+	}
 	resp, err := c.http.Do(req)
 	if err != nil {
 		return nil, fmt.Errorf("executing request: %w", err)
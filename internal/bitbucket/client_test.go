@@ -18,6 +18,30 @@ func testClient(ts *httptest.Server) *Client {
 	}
 }
 
+func TestRepositoryPermission(t *testing.T) {
+	var gotPath, gotQuery string
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		gotPath, gotQuery = r.URL.Path, r.URL.Query().Get("q")
+		if strings.Contains(gotQuery, "acme/widget") {
+			_, _ = w.Write([]byte(`{"values":[{"permission":"read","repository":{"full_name":"acme/widget"}}]}`))
+			return
+		}
+		_, _ = w.Write([]byte(`{"values":[]}`))
+	}))
+	defer ts.Close()
+
+	perm, err := testClient(ts).RepositoryPermission("acme", "widget")
+	if err != nil || perm != "read" {
+		t.Errorf("permission = %q, %v; want read", perm, err)
+	}
+	if gotPath != "/user/permissions/repositories" || gotQuery != `repository.full_name="acme/widget"` {
+		t.Errorf("request = %s q=%s", gotPath, gotQuery)
+	}
+	if perm, err := testClient(ts).RepositoryPermission("acme", "other"); err != nil || perm != "" {
+		t.Errorf("unlisted repo = %q, %v; want empty", perm, err)
+	}
+}
+
 func TestGetPullRequest(t *testing.T) {
 	var gotAuth, gotAccept, gotPath string
 	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
@@ -100,6 +124,58 @@ func TestGetPullRequestCommentsPagination(t *testing.T) {
 	}
 }
 
+func TestGetTasksAndCommitStatuses(t *testing.T) {
+	var paths []string
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		paths = append(paths, r.URL.Path)
+		if strings.HasSuffix(r.URL.Path, "/tasks") {
+			_, _ = w.Write([]byte(`{"values":[{"id":1,"state":"UNRESOLVED","content":{"raw":"Add a test"},"comment":{"id":9}}]}`))
+			return
+		}
+		_, _ = w.Write([]byte(`{"values":[{"key":"ci","name":"Build","state":"SUCCESSFUL","url":"https://ci/1"}]}`))
+	}))
+	defer ts.Close()
+
+	c := testClient(ts)
+	tasks, err := c.GetPullRequestTasks("ws", "repo", 5)
+	if err != nil {
+		t.Fatalf("GetPullRequestTasks: %v", err)
+	}
+	if len(tasks) != 1 || tasks[0].Content.Raw != "Add a test" || tasks[0].Comment == nil || tasks[0].Comment.ID != 9 {
+		t.Errorf("tasks = %+v", tasks)
+	}
+	statuses, err := c.GetCommitStatuses("ws", "repo", "abc123")
+	if err != nil {
+		t.Fatalf("GetCommitStatuses: %v", err)
+	}
+	if len(statuses) != 1 || statuses[0].State != "SUCCESSFUL" || statuses[0].Name != "Build" {
+		t.Errorf("statuses = %+v", statuses)
+	}
+	if got := strings.Join(paths, ","); got != "/repositories/ws/repo/pullrequests/5/tasks,/repositories/ws/repo/commit/abc123/statuses" {
+		t.Errorf("paths = %s", got)
+	}
+}
+
+func TestGetCommitRangeDiff(t *testing.T) {
+	var gotPath, gotQuery string
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		gotPath, gotQuery = r.URL.Path, r.URL.RawQuery
+		_, _ = w.Write([]byte("diff --git a/a.go b/a.go\n"))
+	}))
+	defer ts.Close()
+
+	diff, err := testClient(ts).GetCommitRangeDiff("ws", "repo", "old111", "new222")
+	if err != nil {
+		t.Fatalf("GetCommitRangeDiff: %v", err)
+	}
+	if !strings.HasPrefix(diff, "diff --git") {
+		t.Errorf("diff = %q", diff)
+	}
+	if gotPath != "/repositories/ws/repo/diff/new222..old111" || gotQuery != "topic=false" {
+		t.Errorf("request = %s?%s", gotPath, gotQuery)
+	}
+}
+
 func TestListPullRequestsPagination(t *testing.T) {
 	var gotStates []string
 	var gotSort, gotPagelen string
@@ -164,6 +240,103 @@ func TestListPullRequestsLimit(t *testing.T) {
 	}
 }
 
+func TestQueryPullRequests(t *testing.T) {
+	var gotQ, gotFields string
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		gotQ = r.URL.Query().Get("q")
+		gotFields = r.URL.Query().Get("fields")
+		_, _ = w.Write([]byte(`{"values":[{"id":7,"author":{"uuid":"{me}"},
+			"participants":[{"user":{"uuid":"{rev}"},"role":"REVIEWER","approved":true}]}]}`))
+	}))
+	defer ts.Close()
+
+	prs, err := testClient(ts).QueryPullRequests("ws", "repo", `state="OPEN"`)
+	if err != nil {
+		t.Fatalf("QueryPullRequests: %v", err)
+	}
+	if len(prs) != 1 || prs[0].Author.UUID != "{me}" || len(prs[0].Participants) != 1 || !prs[0].Participants[0].Approved {
+		t.Errorf("prs = %+v", prs)
+	}
+	if gotQ != `state="OPEN"` || gotFields != "+values.participants" {
+		t.Errorf("q = %q, fields = %q", gotQ, gotFields)
+	}
+}
+
+func TestListRepositoriesAndCurrentUser(t *testing.T) {
+	var gotRole string
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/user":
+			_, _ = w.Write([]byte(`{"display_name":"Me","uuid":"{me}","account_id":"557058:abc"}`))
+		case "/repositories/ws":
+			gotRole = r.URL.Query().Get("role")
+			_, _ = w.Write([]byte(`{"values":[{"slug":"a"},{"slug":"b"},{"slug":"c"}],"next":"unused"}`))
+		default:
+			http.NotFound(w, r)
+		}
+	}))
+	defer ts.Close()
+
+	c := testClient(ts)
+	me, err := c.CurrentUser()
+	if err != nil || me.UUID != "{me}" || me.AccountID != "557058:abc" {
+		t.Errorf("CurrentUser = %+v, %v", me, err)
+	}
+	repos, err := c.ListRepositories("ws", 2)
+	if err != nil {
+		t.Fatalf("ListRepositories: %v", err)
+	}
+	if len(repos) != 2 || repos[0].Slug != "a" || repos[1].Slug != "b" {
+		t.Errorf("repos = %+v, want a, b (limit)", repos)
+	}
+	if gotRole != "member" {
+		t.Errorf("role = %q", gotRole)
+	}
+}
+
+func TestGetFileContent(t *testing.T) {
+	var gotPath string
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		gotPath = r.URL.EscapedPath()
+		if strings.HasSuffix(gotPath, "/missing.go") {
+			http.NotFound(w, r)
+			return
+		}
+		_, _ = w.Write([]byte("package x\n"))
+	}))
+	defer ts.Close()
+
+	c := testClient(ts)
+	data, err := c.GetFileContent("ws", "repo", "abc123", "cmd/my file.go")
+	if err != nil || string(data) != "package x\n" {
+		t.Fatalf("GetFileContent = %q, %v", data, err)
+	}
+	if gotPath != "/repositories/ws/repo/src/abc123/cmd/my%20file.go" {
+		t.Errorf("path = %s", gotPath)
+	}
+	if _, err := c.GetFileContent("ws", "repo", "abc123", "missing.go"); !errors.Is(err, ErrNotFound) {
+		t.Errorf("missing file err = %v", err)
+	}
+}
+
+func TestDefaultReviewers(t *testing.T) {
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/repositories/ws/repo/effective-default-reviewers" {
+			t.Errorf("path = %s", r.URL.Path)
+		}
+		_, _ = w.Write([]byte(`{"values":[{"user":{"display_name":"Bob","uuid":"{bob}"},"reviewer_type":"project"}]}`))
+	}))
+	defer ts.Close()
+
+	got, err := testClient(ts).DefaultReviewers("ws", "repo")
+	if err != nil {
+		t.Fatalf("DefaultReviewers: %v", err)
+	}
+	if len(got) != 1 || got[0].UUID != "{bob}" || got[0].DisplayName != "Bob" {
+		t.Errorf("reviewers = %+v", got)
+	}
+}
+
 func TestStatusErrors(t *testing.T) {
 	cases := []struct {
 		code int
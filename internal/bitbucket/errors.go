@@ -11,6 +11,14 @@ var ErrUnauthorized = errors.New("unauthorized: check your email and Bitbucket A
 // ErrForbidden indicates the token is valid but lacks a required scope (HTTP 403).
 var ErrForbidden = errors.New("forbidden: token missing scope (need read:pullrequest:bitbucket and read:repository:bitbucket)")
 
+// ErrWriteForbidden is ErrForbidden for a write (comment, approve, merge, ...):
+// the token lacks the write scope or the user lacks write access to the repo.
+var ErrWriteForbidden = errors.New("forbidden: token missing scope (need write:pullrequest:bitbucket) or no write access to the repository")
+
+// ErrMergePending indicates Bitbucket accepted a merge but was still running
+// it when Merge stopped waiting (HTTP 202 and a pending merge task).
+var ErrMergePending = errors.New("merge queued: Bitbucket is still merging the pull request")
+
 // ErrNotFound indicates the requested resource does not exist (HTTP 404).
 var ErrNotFound = errors.New("not found")
 
@@ -0,0 +1,177 @@
+package bitbucket
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+)
+
+// Pipeline is a Bitbucket Pipelines run.
+type Pipeline struct {
+	UUID        string        `json:"uuid"`
+	BuildNumber int           `json:"build_number"`
+	State       PipelineState `json:"state"`
+	Target      struct {
+		RefName string `json:"ref_name"`
+		Commit  struct {
+			Hash string `json:"hash"`
+		} `json:"commit"`
+		PullRequest *struct {
+			ID int `json:"id"`
+		} `json:"pullrequest"`
+	} `json:"target"`
+	Trigger struct {
+		Name string `json:"name"`
+	} `json:"trigger"`
+	Creator           Account `json:"creator"`
+	CreatedOn         string  `json:"created_on"`
+	CompletedOn       string  `json:"completed_on"`
+	DurationInSeconds int     `json:"duration_in_seconds"`
+}
+
+// PipelineState is the state of a pipeline or step. Name is "PENDING",
+// "IN_PROGRESS" or "COMPLETED"; a completed run has a Result ("SUCCESSFUL",
+// "FAILED", "ERROR", "STOPPED"), a running one may have a Stage ("RUNNING",
+// "PAUSED").
+type PipelineState struct {
+	Name   string `json:"name"`
+	Result *struct {
+		Name string `json:"name"`
+	} `json:"result"`
+	Stage *struct {
+		Name string `json:"name"`
+	} `json:"stage"`
+}
+
+// String is the most specific state: the result, else the stage, else the
+// state name.
+func (s PipelineState) String() string {
+	switch {
+	case s.Result != nil && s.Result.Name != "":
+		return s.Result.Name
+	case s.Stage != nil && s.Stage.Name != "":
+		return s.Stage.Name
+	default:
+		return s.Name
+	}
+}
+
+// Failed reports whether the run completed without success (failed, errored
+// or stopped).
+func (s PipelineState) Failed() bool {
+	r := s.String()
+	return r == "FAILED" || r == "ERROR" || r == "STOPPED"
+}
+
+// PipelineStep is one step of a pipeline run.
+type PipelineStep struct {
+	UUID              string        `json:"uuid"`
+	Name              string        `json:"name"`
+	State             PipelineState `json:"state"`
+	StartedOn         string        `json:"started_on"`
+	CompletedOn       string        `json:"completed_on"`
+	DurationInSeconds int           `json:"duration_in_seconds"`
+
+	// Log is fetched separately (the step's log endpoint) and attached for
+	// rendering.
+	Log string `json:"-"`
+}
+
+// ListPipelines returns a repo's pipeline runs, newest first, up to limit
+// results (<= 0 means no cap).
+func (c *Client) ListPipelines(workspace, repo string, limit int) ([]Pipeline, error) {
+	q := url.Values{}
+	q.Set("pagelen", "50")
+	q.Set("sort", "-created_on")
+	next := fmt.Sprintf("/repositories/%s/%s/pipelines/?%s", workspace, repo, q.Encode())
+	var all []Pipeline
+	for next != "" {
+		body, err := c.getJSON(next)
+		if err != nil {
+			return nil, err
+		}
+		var page struct {
+			Values []Pipeline `json:"values"`
+			Next   string     `json:"next"`
+		}
+		if err := json.Unmarshal(body, &page); err != nil {
+			return nil, fmt.Errorf("decoding pipelines: %w", err)
+		}
+		all = append(all, page.Values...)
+		if limit > 0 && len(all) >= limit {
+			all = all[:limit]
+			break
+		}
+		next = page.Next
+	}
+	return all, nil
+}
+
+// GetPipeline returns one pipeline run by UUID ("{...}") or build number.
+func (c *Client) GetPipeline(workspace, repo, ref string) (*Pipeline, error) {
+	body, err := c.getJSON(fmt.Sprintf("/repositories/%s/%s/pipelines/%s", workspace, repo, pipelinePathRef(ref)))
+	if err != nil {
+		return nil, err
+	}
+	var p Pipeline
+	if err := json.Unmarshal(body, &p); err != nil {
+		return nil, fmt.Errorf("decoding pipeline: %w", err)
+	}
+	return &p, nil
+}
+
+// GetPipelineSteps returns the steps of a pipeline run, in run order.
+func (c *Client) GetPipelineSteps(workspace, repo, pipelineUUID string) ([]PipelineStep, error) {
+	next := fmt.Sprintf("/repositories/%s/%s/pipelines/%s/steps/?pagelen=100", workspace, repo, url.PathEscape(pipelineUUID))
+	var all []PipelineStep
+	for next != "" {
+		body, err := c.getJSON(next)
+		if err != nil {
+			return nil, err
+		}
+		var page struct {
+			Values []PipelineStep `json:"values"`
+			Next   string         `json:"next"`
+		}
+		if err := json.Unmarshal(body, &page); err != nil {
+			return nil, fmt.Errorf("decoding pipeline steps: %w", err)
+		}
+		all = append(all, page.Values...)
+		next = page.Next
+	}
+	return all, nil
+}
+
+// GetPipelineStepLog returns a step's raw log. A step that has not produced
+// a log yet is reported as ErrNotFound.
+func (c *Client) GetPipelineStepLog(workspace, repo, pipelineUUID, stepUUID string) (string, error) {
+	path := fmt.Sprintf("/repositories/%s/%s/pipelines/%s/steps/%s/log", workspace, repo, url.PathEscape(pipelineUUID), url.PathEscape(stepUUID))
+	resp, err := c.do(http.MethodGet, path, "application/octet-stream", nil)
+	if err != nil {
+		return "", err
+	}
+	body, status, err := readAndClose(resp)
+	if err != nil {
+		return "", err
+	}
+	if err := classify(status, body); err != nil {
+		return "", err
+	}
+	return string(body), nil
+}
+
+// pipelinePathRef escapes a pipeline UUID for a path; build numbers are used
+// as they are.
+func pipelinePathRef(ref string) string {
+	ref = strings.TrimPrefix(strings.TrimSpace(ref), "#")
+	if _, err := strconv.Atoi(ref); err == nil {
+		return ref
+	}
+	if !strings.HasPrefix(ref, "{") {
+		ref = "{" + ref + "}"
+	}
+	return url.PathEscape(ref)
+}
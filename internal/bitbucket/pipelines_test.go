@@ -0,0 +1,97 @@
+package bitbucket
+
+import (
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func TestPipelineStateString(t *testing.T) {
+	var s PipelineState
+	s.Name = "IN_PROGRESS"
+	if s.String() != "IN_PROGRESS" || s.Failed() {
+		t.Errorf("plain state = %q", s)
+	}
+	s.Stage = &struct {
+		Name string `json:"name"`
+	}{"RUNNING"}
+	if s.String() != "RUNNING" {
+		t.Errorf("stage = %q", s)
+	}
+	s.Name, s.Stage = "COMPLETED", nil
+	s.Result = &struct {
+		Name string `json:"name"`
+	}{"ERROR"}
+	if s.String() != "ERROR" || !s.Failed() {
+		t.Errorf("result = %q, failed = %v", s, s.Failed())
+	}
+}
+
+func TestGetPipelineByNumberAndUUID(t *testing.T) {
+	var paths []string
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		paths = append(paths, r.URL.EscapedPath())
+		_, _ = w.Write([]byte(`{"uuid":"{p-1}","build_number":12,"state":{"name":"COMPLETED","result":{"name":"FAILED"}}}`))
+	}))
+	defer ts.Close()
+
+	c := testClient(ts)
+	p, err := c.GetPipeline("ws", "repo", "#12")
+	if err != nil {
+		t.Fatalf("GetPipeline: %v", err)
+	}
+	if p.BuildNumber != 12 || p.State.String() != "FAILED" {
+		t.Errorf("pipeline = %+v", p)
+	}
+	if _, err := c.GetPipeline("ws", "repo", "p-1"); err != nil {
+		t.Fatal(err)
+	}
+	if paths[0] != "/repositories/ws/repo/pipelines/12" || paths[1] != "/repositories/ws/repo/pipelines/%7Bp-1%7D" {
+		t.Errorf("paths = %v", paths)
+	}
+}
+
+func TestPipelineStepsAndLog(t *testing.T) {
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.EscapedPath() {
+		case "/repositories/ws/repo/pipelines/%7Bp%7D/steps/":
+			_, _ = w.Write([]byte(`{"values":[{"uuid":"{s1}","name":"Test","duration_in_seconds":65}]}`))
+		case "/repositories/ws/repo/pipelines/%7Bp%7D/steps/%7Bs1%7D/log":
+			_, _ = w.Write([]byte("+ go test\nok\n"))
+		default:
+			http.NotFound(w, r)
+		}
+	}))
+	defer ts.Close()
+
+	c := testClient(ts)
+	steps, err := c.GetPipelineSteps("ws", "repo", "{p}")
+	if err != nil || len(steps) != 1 || steps[0].Name != "Test" || steps[0].DurationInSeconds != 65 {
+		t.Fatalf("steps = %+v, %v", steps, err)
+	}
+	log, err := c.GetPipelineStepLog("ws", "repo", "{p}", "{s1}")
+	if err != nil || log != "+ go test\nok\n" {
+		t.Errorf("log = %q, %v", log, err)
+	}
+	if _, err := c.GetPipelineStepLog("ws", "repo", "{p}", "{missing}"); !errors.Is(err, ErrNotFound) {
+		t.Errorf("missing log err = %v", err)
+	}
+}
+
+func TestListPipelines(t *testing.T) {
+	var gotSort string
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		gotSort = r.URL.Query().Get("sort")
+		_, _ = w.Write([]byte(`{"values":[{"build_number":3},{"build_number":2},{"build_number":1}],"next":"unused"}`))
+	}))
+	defer ts.Close()
+
+	ps, err := testClient(ts).ListPipelines("ws", "repo", 2)
+	if err != nil {
+		t.Fatalf("ListPipelines: %v", err)
+	}
+	if len(ps) != 2 || ps[0].BuildNumber != 3 || gotSort != "-created_on" {
+		t.Errorf("pipelines = %+v, sort = %q", ps, gotSort)
+	}
+}
@@ -14,18 +14,69 @@ type PullRequest struct {
 	Links       struct {
 		HTML Link `json:"html"`
 	} `json:"links"`
+	Participants []Participant `json:"participants"`
+
+	// Tasks and Statuses are fetched separately (tasks endpoint, statuses
+	// of the source commit) and attached for rendering.
+	Tasks    []Task         `json:"-"`
+	Statuses []CommitStatus `json:"-"`
+}
+
+// Participant is a user taking part in a PR. Role is "REVIEWER" or
+// "PARTICIPANT"; State is "approved", "changes_requested" or empty.
+type Participant struct {
+	User     Account `json:"user"`
+	Role     string  `json:"role"`
+	Approved bool    `json:"approved"`
+	State    string  `json:"state"`
+}
+
+// Task is a PR task (a to-do item, optionally attached to a comment). State is
+// "RESOLVED" or "UNRESOLVED".
+type Task struct {
+	ID      int `json:"id"`
+	Content struct {
+		Raw string `json:"raw"`
+	} `json:"content"`
+	State   string      `json:"state"`
+	Creator Account     `json:"creator"`
+	Comment *CommentRef `json:"comment"`
+}
+
+// CommitStatus is a build result reported against a commit. State is
+// "SUCCESSFUL", "FAILED", "INPROGRESS" or "STOPPED".
+type CommitStatus struct {
+	Key         string `json:"key"`
+	Name        string `json:"name"`
+	State       string `json:"state"`
+	URL         string `json:"url"`
+	Description string `json:"description"`
 }
 
-// Account is a Bitbucket user reference.
+// Account is a Bitbucket user reference. UUID identifies the user in BBQL
+// queries ("{...}", braces included).
 type Account struct {
 	DisplayName string `json:"display_name"`
+	UUID        string `json:"uuid"`
+	AccountID   string `json:"account_id"`
+}
+
+// Repository is the subset of a Bitbucket repository object atlit uses.
+type Repository struct {
+	Slug      string `json:"slug"`
+	FullName  string `json:"full_name"`
+	UpdatedOn string `json:"updated_on"`
 }
 
-// PREndpoint is one side (source/destination) of a pull request.
+// PREndpoint is one side (source/destination) of a pull request. Commit.Hash
+// is the abbreviated hash of the branch tip the PR currently points at.
 type PREndpoint struct {
 	Branch struct {
 		Name string `json:"name"`
 	} `json:"branch"`
+	Commit struct {
+		Hash string `json:"hash"`
+	} `json:"commit"`
 }
 
 // Link is an href wrapper used throughout the Bitbucket API.
@@ -45,6 +96,10 @@ type DiffstatEntry struct {
 	LinesRemoved int       `json:"lines_removed"`
 	Old          *DiffFile `json:"old"`
 	New          *DiffFile `json:"new"`
+
+	// Note is atlit's annotation for the rendered diffstat line (why the
+	// file's diff was left out, or where it was split to).
+	Note string `json:"-"`
 }
 
 // Path returns the entry's new path, falling back to the old path (renames,
@@ -72,11 +127,14 @@ type Comment struct {
 	Content struct {
 		Raw string `json:"raw"`
 	} `json:"content"`
-	User      Account `json:"user"`
-	CreatedOn string  `json:"created_on"`
-	Deleted   bool    `json:"deleted"`
-	Inline    *Inline `json:"inline"`
-	Parent    *struct {
-		ID int `json:"id"`
-	} `json:"parent"`
+	User      Account     `json:"user"`
+	CreatedOn string      `json:"created_on"`
+	Deleted   bool        `json:"deleted"`
+	Inline    *Inline     `json:"inline"`
+	Parent    *CommentRef `json:"parent"`
+}
+
+// CommentRef points at a comment by id (a reply's parent, a task's comment).
+type CommentRef struct {
+	ID int `json:"id"`
 }
@@ -0,0 +1,239 @@
+package bitbucket
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"time"
+)
+
+// Merge strategies accepted by Merge.
+const (
+	MergeCommit      = "merge_commit"
+	MergeSquash      = "squash"
+	MergeFastForward = "fast_forward"
+)
+
+// NewComment is a comment to post on a pull request. Path with Line (a line
+// of the new file) or OldLine (a removed line of the old file) makes it
+// inline; ParentID makes it a reply.
+type NewComment struct {
+	Raw      string
+	Path     string
+	Line     int
+	OldLine  int
+	ParentID int
+}
+
+// NewPullRequest is a pull request to open. An empty Destination targets the
+// repository's main branch; Reviewers are account UUIDs.
+type NewPullRequest struct {
+	Title             string
+	Description       string
+	Source            string
+	Destination       string
+	Reviewers         []string
+	CloseSourceBranch bool
+}
+
+// Payload is the JSON body CreatePullRequest sends for np.
+func (np NewPullRequest) Payload() map[string]any {
+	payload := map[string]any{
+		"title":               np.Title,
+		"description":         np.Description,
+		"source":              map[string]any{"branch": map[string]string{"name": np.Source}},
+		"close_source_branch": np.CloseSourceBranch,
+	}
+	if np.Destination != "" {
+		payload["destination"] = map[string]any{"branch": map[string]string{"name": np.Destination}}
+	}
+	reviewers := []map[string]string{}
+	for _, uuid := range np.Reviewers {
+		reviewers = append(reviewers, map[string]string{"uuid": uuid})
+	}
+	payload["reviewers"] = reviewers
+	return payload
+}
+
+// CreatePullRequest opens a pull request and returns it as created.
+func (c *Client) CreatePullRequest(workspace, repo string, np NewPullRequest) (*PullRequest, error) {
+	body, err := c.send(http.MethodPost, fmt.Sprintf("/repositories/%s/%s/pullrequests", workspace, repo), np.Payload())
+	if err != nil {
+		return nil, err
+	}
+	var pr PullRequest
+	if err := json.Unmarshal(body, &pr); err != nil {
+		return nil, fmt.Errorf("decoding pull request: %w", err)
+	}
+	return &pr, nil
+}
+
+// AddPullRequestComment posts a general, inline or reply comment.
+func (c *Client) AddPullRequestComment(workspace, repo string, id int, nc NewComment) (*Comment, error) {
+	payload := map[string]any{"content": map[string]string{"raw": nc.Raw}}
+	if nc.Path != "" {
+		inline := map[string]any{"path": nc.Path}
+		if nc.OldLine > 0 {
+			inline["from"] = nc.OldLine
+		} else {
+			inline["to"] = nc.Line
+		}
+		payload["inline"] = inline
+	}
+	if nc.ParentID > 0 {
+		payload["parent"] = map[string]int{"id": nc.ParentID}
+	}
+	path := fmt.Sprintf("/repositories/%s/%s/pullrequests/%d/comments", workspace, repo, id)
+	body, err := c.send(http.MethodPost, path, payload)
+	if err != nil {
+		return nil, err
+	}
+	var cm Comment
+	if err := json.Unmarshal(body, &cm); err != nil {
+		return nil, fmt.Errorf("decoding comment: %w", err)
+	}
+	return &cm, nil
+}
+
+// Approve approves the pull request as the token's user.
+func (c *Client) Approve(workspace, repo string, id int) error {
+	_, err := c.send(http.MethodPost, fmt.Sprintf("/repositories/%s/%s/pullrequests/%d/approve", workspace, repo, id), nil)
+	return err
+}
+
+// Unapprove withdraws the token user's approval.
+func (c *Client) Unapprove(workspace, repo string, id int) error {
+	_, err := c.send(http.MethodDelete, fmt.Sprintf("/repositories/%s/%s/pullrequests/%d/approve", workspace, repo, id), nil)
+	return err
+}
+
+// RequestChanges marks the pull request as needing changes.
+func (c *Client) RequestChanges(workspace, repo string, id int) error {
+	_, err := c.send(http.MethodPost, fmt.Sprintf("/repositories/%s/%s/pullrequests/%d/request-changes", workspace, repo, id), nil)
+	return err
+}
+
+// Merge polling: a merge Bitbucket runs in the background is checked every
+// mergePollInterval, up to mergePollAttempts times.
+var (
+	mergePollInterval = 2 * time.Second
+	mergePollAttempts = 15
+)
+
+// mergeTask is a merge task-status response, also the body of the 202 that
+// starts a background merge.
+type mergeTask struct {
+	TaskStatus  string       `json:"task_status"`
+	MergeResult *PullRequest `json:"merge_result"`
+	Links       struct {
+		Self struct {
+			Href string `json:"href"`
+		} `json:"self"`
+	} `json:"links"`
+}
+
+// Merge merges the pull request. An empty strategy or message uses the
+// repository defaults. Bitbucket answers long-running merges with 202 and a
+// task to poll; Merge waits for it and returns ErrMergePending if it is still
+// running when polling gives up.
+func (c *Client) Merge(workspace, repo string, id int, strategy, message string, closeSourceBranch bool) (*PullRequest, error) {
+	payload := map[string]any{"close_source_branch": closeSourceBranch}
+	if strategy != "" {
+		payload["merge_strategy"] = strategy
+	}
+	if message != "" {
+		payload["message"] = message
+	}
+	body, resp, err := c.sendResponse(http.MethodPost, fmt.Sprintf("/repositories/%s/%s/pullrequests/%d/merge", workspace, repo, id), payload)
+	if err != nil {
+		return nil, err
+	}
+	if resp.StatusCode == http.StatusAccepted {
+		var task mergeTask
+		_ = json.Unmarshal(body, &task)
+		link := resp.Header.Get("Location")
+		if link == "" {
+			link = task.Links.Self.Href
+		}
+		return c.awaitMerge(workspace, repo, id, link)
+	}
+	var pr PullRequest
+	if err := json.Unmarshal(body, &pr); err != nil {
+		return nil, fmt.Errorf("decoding pull request: %w", err)
+	}
+	return &pr, nil
+}
+
+// awaitMerge polls a merge task until it finishes, returning the merged pull
+// request.
+func (c *Client) awaitMerge(workspace, repo string, id int, link string) (*PullRequest, error) {
+	if link == "" {
+		return nil, ErrMergePending
+	}
+	for i := 0; i < mergePollAttempts; i++ {
+		time.Sleep(mergePollInterval)
+		body, err := c.getJSON(link)
+		if err != nil {
+			return nil, err
+		}
+		var task mergeTask
+		if err := json.Unmarshal(body, &task); err != nil {
+			return nil, fmt.Errorf("decoding merge status: %w", err)
+		}
+		switch task.TaskStatus {
+		case "SUCCESS":
+			if task.MergeResult != nil {
+				return task.MergeResult, nil
+			}
+			return c.GetPullRequest(workspace, repo, id)
+		case "PENDING", "":
+			// Still running.
+		default:
+			return nil, fmt.Errorf("merge task ended with status %s", task.TaskStatus)
+		}
+	}
+	return nil, ErrMergePending
+}
+
+// send performs a write with an optional JSON payload and returns the
+// response body. A 403 is reported as ErrWriteForbidden.
+func (c *Client) send(method, path string, payload any) ([]byte, error) {
+	body, _, err := c.sendResponse(method, path, payload)
+	return body, err
+}
+
+// sendResponse is send that also returns the response, for its status and
+// headers (the body is already read).
+func (c *Client) sendResponse(method, path string, payload any) ([]byte, *http.Response, error) {
+	var reqBody *bytes.Reader
+	if payload != nil {
+		data, err := json.Marshal(payload)
+		if err != nil {
+			return nil, nil, fmt.Errorf("encoding request: %w", err)
+		}
+		reqBody = bytes.NewReader(data)
+	}
+	var resp *http.Response
+	var err error
+	if reqBody != nil {
+		resp, err = c.do(method, path, "application/json", reqBody)
+	} else {
+		resp, err = c.do(method, path, "application/json", nil)
+	}
+	if err != nil {
+		return nil, nil, err
+	}
+	body, status, err := readAndClose(resp)
+	if err != nil {
+		return nil, nil, err
+	}
+	if err := classify(status, body); err != nil {
+		if errors.Is(err, ErrForbidden) {
+			return nil, nil, ErrWriteForbidden
+		}
+		return nil, nil, err
+	}
+	return body, resp, nil
+}
@@ -0,0 +1,186 @@
+package bitbucket
+
+import (
+	"encoding/json"
+	"errors"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestAddPullRequestCommentInlineReply(t *testing.T) {
+	var gotMethod, gotPath, gotType string
+	var payload map[string]any
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		gotMethod, gotPath, gotType = r.Method, r.URL.Path, r.Header.Get("Content-Type")
+		data, _ := io.ReadAll(r.Body)
+		_ = json.Unmarshal(data, &payload)
+		w.WriteHeader(http.StatusCreated)
+		_, _ = w.Write([]byte(`{"id":77,"content":{"raw":"nit"}}`))
+	}))
+	defer ts.Close()
+
+	cm, err := testClient(ts).AddPullRequestComment("ws", "repo", 5, NewComment{Raw: "nit", Path: "a.go", Line: 12, ParentID: 70})
+	if err != nil {
+		t.Fatalf("AddPullRequestComment: %v", err)
+	}
+	if cm.ID != 77 {
+		t.Errorf("comment id = %d", cm.ID)
+	}
+	if gotMethod != http.MethodPost || gotPath != "/repositories/ws/repo/pullrequests/5/comments" || gotType != "application/json" {
+		t.Errorf("request = %s %s (%s)", gotMethod, gotPath, gotType)
+	}
+	data, _ := json.Marshal(payload)
+	want := `{"content":{"raw":"nit"},"inline":{"path":"a.go","to":12},"parent":{"id":70}}`
+	if string(data) != want {
+		t.Errorf("payload = %s, want %s", data, want)
+	}
+}
+
+func TestAddPullRequestCommentOldLine(t *testing.T) {
+	var payload map[string]any
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		data, _ := io.ReadAll(r.Body)
+		_ = json.Unmarshal(data, &payload)
+		w.WriteHeader(http.StatusCreated)
+		_, _ = w.Write([]byte(`{"id":78}`))
+	}))
+	defer ts.Close()
+
+	if _, err := testClient(ts).AddPullRequestComment("ws", "repo", 5, NewComment{Raw: "why?", Path: "a.go", OldLine: 9}); err != nil {
+		t.Fatalf("AddPullRequestComment: %v", err)
+	}
+	data, _ := json.Marshal(payload["inline"])
+	if string(data) != `{"from":9,"path":"a.go"}` {
+		t.Errorf("inline = %s", data)
+	}
+}
+
+func TestReviewActions(t *testing.T) {
+	var calls []string
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		calls = append(calls, r.Method+" "+strings.TrimPrefix(r.URL.Path, "/repositories/ws/repo/pullrequests/5"))
+		_, _ = w.Write([]byte(`{}`))
+	}))
+	defer ts.Close()
+
+	c := testClient(ts)
+	if err := c.Approve("ws", "repo", 5); err != nil {
+		t.Fatal(err)
+	}
+	if err := c.Unapprove("ws", "repo", 5); err != nil {
+		t.Fatal(err)
+	}
+	if err := c.RequestChanges("ws", "repo", 5); err != nil {
+		t.Fatal(err)
+	}
+	if got := strings.Join(calls, ", "); got != "POST /approve, DELETE /approve, POST /request-changes" {
+		t.Errorf("calls = %s", got)
+	}
+}
+
+func TestMergePayload(t *testing.T) {
+	var payload map[string]any
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/repositories/ws/repo/pullrequests/5/merge" {
+			t.Errorf("path = %s", r.URL.Path)
+		}
+		data, _ := io.ReadAll(r.Body)
+		_ = json.Unmarshal(data, &payload)
+		_, _ = w.Write([]byte(`{"id":5,"state":"MERGED"}`))
+	}))
+	defer ts.Close()
+
+	pr, err := testClient(ts).Merge("ws", "repo", 5, MergeSquash, "PROJ-1: done", true)
+	if err != nil {
+		t.Fatalf("Merge: %v", err)
+	}
+	if pr.State != "MERGED" {
+		t.Errorf("state = %q", pr.State)
+	}
+	if payload["merge_strategy"] != "squash" || payload["message"] != "PROJ-1: done" || payload["close_source_branch"] != true {
+		t.Errorf("payload = %v", payload)
+	}
+}
+
+func TestMergeAccepted(t *testing.T) {
+	mergePollInterval = 0
+	defer func() { mergePollInterval = 2 * time.Second }()
+
+	polls := 0
+	var ts *httptest.Server
+	ts = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/repositories/ws/repo/pullrequests/5/merge":
+			w.Header().Set("Location", ts.URL+"/repositories/ws/repo/pullrequests/5/merge/task-status/t1")
+			w.WriteHeader(http.StatusAccepted)
+			_, _ = w.Write([]byte(`{"task_status":"PENDING"}`))
+		case "/repositories/ws/repo/pullrequests/5/merge/task-status/t1":
+			polls++
+			if polls < 3 {
+				_, _ = w.Write([]byte(`{"task_status":"PENDING"}`))
+				return
+			}
+			_, _ = w.Write([]byte(`{"task_status":"SUCCESS","merge_result":{"id":5,"state":"MERGED"}}`))
+		default:
+			t.Errorf("unexpected path %s", r.URL.Path)
+		}
+	}))
+	defer ts.Close()
+
+	pr, err := testClient(ts).Merge("ws", "repo", 5, "", "", false)
+	if err != nil {
+		t.Fatalf("Merge: %v", err)
+	}
+	if pr.State != "MERGED" || polls != 3 {
+		t.Errorf("state = %q after %d polls", pr.State, polls)
+	}
+
+	// Still running when polling gives up.
+	polls, mergePollAttempts = -100, 2
+	defer func() { mergePollAttempts = 15 }()
+	if _, err := testClient(ts).Merge("ws", "repo", 5, "", "", false); !errors.Is(err, ErrMergePending) {
+		t.Errorf("err = %v, want ErrMergePending", err)
+	}
+}
+
+func TestCreatePullRequest(t *testing.T) {
+	var gotPath string
+	var payload map[string]any
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		gotPath = r.URL.Path
+		data, _ := io.ReadAll(r.Body)
+		_ = json.Unmarshal(data, &payload)
+		w.WriteHeader(http.StatusCreated)
+		_, _ = w.Write([]byte(`{"id":9,"links":{"html":{"href":"https://bitbucket.org/ws/repo/pull-requests/9"}}}`))
+	}))
+	defer ts.Close()
+
+	np := NewPullRequest{Title: "PROJ-1: Retry", Source: "feature/PROJ-1-retry", Reviewers: []string{"{r1}"}}
+	pr, err := testClient(ts).CreatePullRequest("ws", "repo", np)
+	if err != nil {
+		t.Fatalf("CreatePullRequest: %v", err)
+	}
+	if pr.ID != 9 || pr.Links.HTML.Href == "" || gotPath != "/repositories/ws/repo/pullrequests" {
+		t.Errorf("pr = %+v, path = %s", pr, gotPath)
+	}
+	data, _ := json.Marshal(payload)
+	want := `{"close_source_branch":false,"description":"","reviewers":[{"uuid":"{r1}"}],"source":{"branch":{"name":"feature/PROJ-1-retry"}},"title":"PROJ-1: Retry"}`
+	if string(data) != want {
+		t.Errorf("payload = %s, want %s", data, want)
+	}
+}
+
+func TestWriteForbidden(t *testing.T) {
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusForbidden)
+	}))
+	defer ts.Close()
+
+	if err := testClient(ts).Approve("ws", "repo", 5); !errors.Is(err, ErrWriteForbidden) {
+		t.Errorf("err = %v, want ErrWriteForbidden", err)
+	}
+}
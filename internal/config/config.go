@@ -39,19 +39,38 @@ type Config struct {
 	DefaultProject string       `yaml:"default_project,omitempty"`
 	TicketsDir     string       `yaml:"tickets_dir"`
 	TokenStorage   TokenStorage `yaml:"token_storage"`
-	// FetchComments controls whether pull/diff/sync request and render comments.
+	// FetchComments controls whether pull/diff/sync request and render comments,
+	// and whether `atlit page` fetches Confluence footer and inline comments.
 	// Pointer so "field absent" means "default true" (backward compatible).
 	FetchComments *bool `yaml:"fetch_comments,omitempty"`
 	// FetchPullRequests controls whether `atlit pull` also fetches the development
 	// panel's linked pull requests (via the dev-status API) and renders a
 	// "## Pull Requests" section. Pointer so absent means "default true".
 	FetchPullRequests *bool `yaml:"fetch_pull_requests,omitempty"`
+	// FetchBranches, FetchCommits, FetchBuilds and FetchDeployments toggle the
+	// matching "## Development" subsections of `atlit pull` (dev-status API).
+	// Pointers so absent means "default true".
+	FetchBranches    *bool `yaml:"fetch_branches,omitempty"`
+	FetchCommits     *bool `yaml:"fetch_commits,omitempty"`
+	FetchBuilds      *bool `yaml:"fetch_builds,omitempty"`
+	FetchDeployments *bool `yaml:"fetch_deployments,omitempty"`
 	// BitbucketWorkspace is the default workspace for `atlit pr <repo>/<id>` refs.
 	BitbucketWorkspace string `yaml:"bitbucket_workspace,omitempty"`
+	// GitHubHost and GitLabHost name a GitHub Enterprise or self-managed GitLab
+	// host (e.g. github.example.com) that `atlit pr` should recognise in URLs
+	// and git remotes, besides github.com and gitlab.com.
+	GitHubHost string `yaml:"github_host,omitempty"`
+	GitLabHost string `yaml:"gitlab_host,omitempty"`
 	// PRsDir is where `atlit pr` saves pull-request markdown (default <config-dir>/prs).
 	PRsDir string `yaml:"prs_dir,omitempty"`
 	// PagesDir is where `atlit page` saves Confluence page markdown (default <config-dir>/pages).
 	PagesDir string `yaml:"pages_dir,omitempty"`
+	// Queries are named JQL queries, run with `atlit search @<name>` and managed
+	// with `atlit search save`.
+	Queries map[string]string `yaml:"queries,omitempty"`
+	// BoardID is the default Jira Software board for `atlit sprint` and
+	// `atlit board`. Zero means unset.
+	BoardID int `yaml:"board_id,omitempty"`
 }
 
 // PagesDirOrDefault returns the configured Confluence page storage directory,
@@ -102,6 +121,30 @@ func (c *Config) ShouldFetchPullRequests() bool {
 	return *c.FetchPullRequests
 }
 
+// ShouldFetchBranches returns whether `atlit pull` should fetch and render the
+// development panel's branches. Default (nil) is true.
+func (c *Config) ShouldFetchBranches() bool {
+	return c == nil || c.FetchBranches == nil || *c.FetchBranches
+}
+
+// ShouldFetchCommits returns whether `atlit pull` should fetch and render the
+// development panel's commits. Default (nil) is true.
+func (c *Config) ShouldFetchCommits() bool {
+	return c == nil || c.FetchCommits == nil || *c.FetchCommits
+}
+
+// ShouldFetchBuilds returns whether `atlit pull` should fetch and render the
+// development panel's builds. Default (nil) is true.
+func (c *Config) ShouldFetchBuilds() bool {
+	return c == nil || c.FetchBuilds == nil || *c.FetchBuilds
+}
+
+// ShouldFetchDeployments returns whether `atlit pull` should fetch and render
+// the development panel's deployments. Default (nil) is true.
+func (c *Config) ShouldFetchDeployments() bool {
+	return c == nil || c.FetchDeployments == nil || *c.FetchDeployments
+}
+
 // SetConfigDir overrides the config directory (for testing).
 func SetConfigDir(dir string) {
 	configDirOverride = dir
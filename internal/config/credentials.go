@@ -20,6 +20,11 @@ const (
 	// bitbucketCredFileName holds the Bitbucket token in the file-fallback case,
 	// kept separate from the Jira credentials file to avoid format changes.
 	bitbucketCredFileName = "credentials-bitbucket"
+	// The GitHub and GitLab tokens for `atlit pr` follow the same layout.
+	githubKeyringSuffix = "#github"
+	githubCredFileName  = "credentials-github"
+	gitlabKeyringSuffix = "#gitlab"
+	gitlabCredFileName  = "credentials-gitlab"
 )
 
 // MigrateKeyringTokens copies the Jira and Bitbucket tokens from the legacy
@@ -105,32 +110,78 @@ func DeleteToken(cfg *Config) error {
 // SetBitbucketToken stores the Bitbucket API token under a second keyring
 // account (email + suffix), falling back to a separate file.
 func SetBitbucketToken(email, token string) (TokenStorage, error) {
+	return setHostToken(email+bitbucketKeyringSuffix, bitbucketCredFileName, token)
+}
+
+// GetBitbucketToken retrieves the Bitbucket API token using the configured
+// storage method.
+func GetBitbucketToken(cfg *Config) (string, error) {
+	return getHostToken(cfg, "Bitbucket", cfg.Email+bitbucketKeyringSuffix, bitbucketCredFileName)
+}
+
+// SetGitHubToken stores a GitHub personal access token beside the Jira token.
+func SetGitHubToken(email, token string) (TokenStorage, error) {
+	return setHostToken(email+githubKeyringSuffix, githubCredFileName, token)
+}
+
+// GetGitHubToken retrieves the stored GitHub token, falling back to the
+// GITHUB_TOKEN environment variable when none was stored.
+func GetGitHubToken(cfg *Config) (string, error) {
+	token, err := getHostToken(cfg, "GitHub", cfg.Email+githubKeyringSuffix, githubCredFileName)
+	if err != nil {
+		if env := os.Getenv("GITHUB_TOKEN"); env != "" {
+			return env, nil
+		}
+	}
+	return token, err
+}
+
+// SetGitLabToken stores a GitLab personal access token beside the Jira token.
+func SetGitLabToken(email, token string) (TokenStorage, error) {
+	return setHostToken(email+gitlabKeyringSuffix, gitlabCredFileName, token)
+}
+
+// GetGitLabToken retrieves the stored GitLab token, falling back to the
+// GITLAB_TOKEN environment variable when none was stored.
+func GetGitLabToken(cfg *Config) (string, error) {
+	token, err := getHostToken(cfg, "GitLab", cfg.Email+gitlabKeyringSuffix, gitlabCredFileName)
+	if err != nil {
+		if env := os.Getenv("GITLAB_TOKEN"); env != "" {
+			return env, nil
+		}
+	}
+	return token, err
+}
+
+// setHostToken stores a code-host token under its own keyring account,
+// falling back to fileName in the config dir.
+func setHostToken(account, fileName, token string) (TokenStorage, error) {
 	if isKeyringAvailable() {
-		if err := keyring.Set(keyringService, email+bitbucketKeyringSuffix, token); err == nil {
+		if err := keyring.Set(keyringService, account, token); err == nil {
 			return TokenStorageKeyring, nil
 		}
 	}
-	return TokenStorageFile, setBitbucketTokenFile(token)
+	return TokenStorageFile, setHostTokenFile(fileName, token)
 }
 
-// GetBitbucketToken retrieves the Bitbucket API token using the configured
-// storage method.
-func GetBitbucketToken(cfg *Config) (string, error) {
+// getHostToken reads a code-host token using the configured storage method;
+// label names the host in errors.
+func getHostToken(cfg *Config, label, account, fileName string) (string, error) {
 	switch cfg.TokenStorage {
 	case TokenStorageKeyring:
-		token, err := keyringGet(cfg.Email + bitbucketKeyringSuffix)
+		token, err := keyringGet(account)
 		if err != nil {
-			return "", fmt.Errorf("reading Bitbucket token from keyring: %w", err)
+			return "", fmt.Errorf("reading %s token from keyring: %w", label, err)
 		}
 		return token, nil
 	case TokenStorageFile:
-		return getBitbucketTokenFile()
+		return getHostTokenFile(label, fileName)
 	default:
 		return "", fmt.Errorf("unknown token_storage: %q", cfg.TokenStorage)
 	}
 }
 
-func setBitbucketTokenFile(token string) error {
+func setHostTokenFile(fileName, token string) error {
 	dir, err := ConfigDir()
 	if err != nil {
 		return err
@@ -138,17 +189,17 @@ func setBitbucketTokenFile(token string) error {
 	if err := os.MkdirAll(dir, 0700); err != nil {
 		return fmt.Errorf("creating config directory: %w", err)
 	}
-	return os.WriteFile(filepath.Join(dir, bitbucketCredFileName), []byte(token), 0600)
+	return os.WriteFile(filepath.Join(dir, fileName), []byte(token), 0600)
 }
 
-func getBitbucketTokenFile() (string, error) {
+func getHostTokenFile(label, fileName string) (string, error) {
 	dir, err := ConfigDir()
 	if err != nil {
 		return "", err
 	}
-	data, err := os.ReadFile(filepath.Join(dir, bitbucketCredFileName))
+	data, err := os.ReadFile(filepath.Join(dir, fileName))
 	if err != nil {
-		return "", fmt.Errorf("reading Bitbucket credentials file: %w", err)
+		return "", fmt.Errorf("reading %s credentials file: %w", label, err)
 	}
 	return string(data), nil
 }
@@ -128,3 +128,28 @@ func TestDeleteTokenViaConfig(t *testing.T) {
 		t.Error("expected credentials file to be deleted")
 	}
 }
+
+func TestHostTokenFileAndEnvFallback(t *testing.T) {
+	dir := t.TempDir()
+	SetConfigDir(dir)
+	t.Cleanup(ResetConfigDir)
+	cfg := &Config{Email: "me@example.com", TokenStorage: TokenStorageFile}
+
+	t.Setenv("GITHUB_TOKEN", "from-env")
+	got, err := GetGitHubToken(cfg)
+	if err != nil || got != "from-env" {
+		t.Errorf("env fallback = %q, %v", got, err)
+	}
+
+	if err := setHostTokenFile(githubCredFileName, "stored"); err != nil {
+		t.Fatalf("setHostTokenFile: %v", err)
+	}
+	if got, err := GetGitHubToken(cfg); err != nil || got != "stored" {
+		t.Errorf("stored token = %q, %v; want the file to win over the environment", got, err)
+	}
+
+	t.Setenv("GITLAB_TOKEN", "")
+	if _, err := GetGitLabToken(cfg); err == nil {
+		t.Error("expected an error without a stored GitLab token or GITLAB_TOKEN")
+	}
+}
@@ -0,0 +1,76 @@
+package config
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"time"
+)
+
+// timerFileName is the running `atlit timer` state, kept in the config dir so
+// it survives shell sessions.
+const timerFileName = "timer.json"
+
+// Timer is a running work timer started by `atlit timer start`.
+type Timer struct {
+	Ticket  string    `json:"ticket"`
+	Started time.Time `json:"started"`
+}
+
+func timerPath() (string, error) {
+	dir, err := ConfigDir()
+	if err != nil {
+		return "", err
+	}
+	return filepath.Join(dir, timerFileName), nil
+}
+
+// LoadTimer returns the running timer, or nil when none is running.
+func LoadTimer() (*Timer, error) {
+	path, err := timerPath()
+	if err != nil {
+		return nil, err
+	}
+	data, err := os.ReadFile(path)
+	if errors.Is(err, os.ErrNotExist) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("reading timer: %w", err)
+	}
+	var t Timer
+	if err := json.Unmarshal(data, &t); err != nil {
+		return nil, fmt.Errorf("parsing timer: %w", err)
+	}
+	return &t, nil
+}
+
+// SaveTimer records t as the running timer, replacing any previous one.
+func SaveTimer(t *Timer) error {
+	dir, err := ConfigDir()
+	if err != nil {
+		return err
+	}
+	if err := os.MkdirAll(dir, 0700); err != nil {
+		return fmt.Errorf("creating config directory: %w", err)
+	}
+	data, err := json.Marshal(t)
+	if err != nil {
+		return fmt.Errorf("marshaling timer: %w", err)
+	}
+	return os.WriteFile(filepath.Join(dir, timerFileName), data, 0600)
+}
+
+// ClearTimer removes the running timer. Clearing when none runs is a no-op.
+func ClearTimer() error {
+	path, err := timerPath()
+	if err != nil {
+		return err
+	}
+	if err := os.Remove(path); err != nil && !errors.Is(err, os.ErrNotExist) {
+		return fmt.Errorf("removing timer: %w", err)
+	}
+	return nil
+}
@@ -0,0 +1,38 @@
+package config
+
+import (
+	"testing"
+	"time"
+)
+
+func TestTimerRoundTrip(t *testing.T) {
+	SetConfigDir(t.TempDir())
+	t.Cleanup(ResetConfigDir)
+
+	got, err := LoadTimer()
+	if err != nil || got != nil {
+		t.Fatalf("LoadTimer with no timer = %+v, %v; want nil, nil", got, err)
+	}
+
+	started := time.Date(2026, 5, 4, 9, 30, 0, 0, time.UTC)
+	if err := SaveTimer(&Timer{Ticket: "PROJ-1", Started: started}); err != nil {
+		t.Fatalf("SaveTimer: %v", err)
+	}
+	got, err = LoadTimer()
+	if err != nil {
+		t.Fatalf("LoadTimer: %v", err)
+	}
+	if got == nil || got.Ticket != "PROJ-1" || !got.Started.Equal(started) {
+		t.Errorf("LoadTimer = %+v", got)
+	}
+
+	if err := ClearTimer(); err != nil {
+		t.Fatalf("ClearTimer: %v", err)
+	}
+	if got, _ := LoadTimer(); got != nil {
+		t.Errorf("timer still present after ClearTimer: %+v", got)
+	}
+	if err := ClearTimer(); err != nil {
+		t.Errorf("ClearTimer with no timer: %v", err)
+	}
+}
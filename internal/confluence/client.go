@@ -69,10 +69,212 @@ func (c *Client) GetPageAttachments(id string) ([]Attachment, error) {
 	return all, nil
 }
 
+// GetPageVersion fetches a page as it was at version n, body in ADF.
+func (c *Client) GetPageVersion(id string, n int) (*Page, error) {
+	path := fmt.Sprintf("%s/pages/%s?body-format=atlas_doc_format&version=%d", apiPrefix, url.PathEscape(id), n)
+	body, err := c.getJSON(path)
+	if err != nil {
+		return nil, err
+	}
+	var p Page
+	if err := json.Unmarshal(body, &p); err != nil {
+		return nil, fmt.Errorf("decoding page: %w", err)
+	}
+	return &p, nil
+}
+
+// GetPageVersions lists a page's versions, newest first, following
+// pagination until limit versions are collected (limit <= 0 means all).
+func (c *Client) GetPageVersions(id string, limit int) ([]Version, error) {
+	path := apiPrefix + "/pages/" + url.PathEscape(id) + "/versions?sort=-modified-date&limit=50"
+	var all []Version
+	for path != "" {
+		body, err := c.getJSON(path)
+		if err != nil {
+			return nil, err
+		}
+		var list versionList
+		if err := json.Unmarshal(body, &list); err != nil {
+			return nil, fmt.Errorf("decoding versions: %w", err)
+		}
+		all = append(all, list.Results...)
+		if limit > 0 && len(all) >= limit {
+			return all[:limit], nil
+		}
+		path = list.Links.Next
+	}
+	return all, nil
+}
+
+// GetFooterComments lists a page's footer comments, oldest first, each with
+// its replies attached.
+func (c *Client) GetFooterComments(pageID string) ([]Comment, error) {
+	return c.commentsWithReplies(apiPrefix+"/pages/"+url.PathEscape(pageID)+"/footer-comments", "/footer-comments/")
+}
+
+// GetInlineComments lists a page's inline comments, oldest first, each with
+// its replies attached.
+func (c *Client) GetInlineComments(pageID string) ([]Comment, error) {
+	return c.commentsWithReplies(apiPrefix+"/pages/"+url.PathEscape(pageID)+"/inline-comments", "/inline-comments/")
+}
+
+// commentsWithReplies lists top-level comments from path and the replies of
+// each from <kind><id>/children.
+func (c *Client) commentsWithReplies(path, kind string) ([]Comment, error) {
+	comments, err := c.listComments(path)
+	if err != nil {
+		return nil, err
+	}
+	for i := range comments {
+		replies, err := c.listComments(apiPrefix + kind + url.PathEscape(comments[i].ID) + "/children")
+		if err != nil {
+			return nil, err
+		}
+		comments[i].Replies = replies
+	}
+	return comments, nil
+}
+
+// listComments collects a paginated comment listing with ADF bodies.
+func (c *Client) listComments(path string) ([]Comment, error) {
+	path += "?body-format=atlas_doc_format&sort=created-date&limit=100"
+	var all []Comment
+	for path != "" {
+		body, err := c.getJSON(path)
+		if err != nil {
+			return nil, err
+		}
+		var list commentList
+		if err := json.Unmarshal(body, &list); err != nil {
+			return nil, fmt.Errorf("decoding comments: %w", err)
+		}
+		all = append(all, list.Results...)
+		path = list.Links.Next
+	}
+	return all, nil
+}
+
+// GetChildPages lists a page's direct children in their tree order,
+// following pagination.
+func (c *Client) GetChildPages(id string) ([]PageSummary, error) {
+	return c.listPages(apiPrefix + "/pages/" + url.PathEscape(id) + "/children?limit=250")
+}
+
+// GetSpaceRootPages lists the top-level pages of a space (its homepage and
+// any other pages without a parent), following pagination.
+func (c *Client) GetSpaceRootPages(spaceID string) ([]PageSummary, error) {
+	return c.listPages(apiPrefix + "/spaces/" + url.PathEscape(spaceID) + "/pages?depth=root&status=current&limit=250")
+}
+
+// GetSpace fetches a space by its numeric id (Page.SpaceID).
+func (c *Client) GetSpace(id string) (*Space, error) {
+	body, err := c.getJSON(apiPrefix + "/spaces/" + url.PathEscape(id))
+	if err != nil {
+		return nil, err
+	}
+	var s Space
+	if err := json.Unmarshal(body, &s); err != nil {
+		return nil, fmt.Errorf("decoding space: %w", err)
+	}
+	return &s, nil
+}
+
+// GetPageAncestors lists a page's ancestors from the space root down to its
+// parent. It uses the v1 API, which returns their titles in one request (v2
+// only lists ids).
+func (c *Client) GetPageAncestors(id string) ([]PageSummary, error) {
+	body, err := c.getJSON("/wiki/rest/api/content/" + url.PathEscape(id) + "?expand=ancestors")
+	if err != nil {
+		return nil, err
+	}
+	var content struct {
+		Ancestors []PageSummary `json:"ancestors"`
+	}
+	if err := json.Unmarshal(body, &content); err != nil {
+		return nil, fmt.Errorf("decoding ancestors: %w", err)
+	}
+	return content.Ancestors, nil
+}
+
+// GetSpaceByKey looks a space up by its key (e.g. "ENG"). An unknown key is
+// reported as ErrNotFound.
+func (c *Client) GetSpaceByKey(key string) (*Space, error) {
+	body, err := c.getJSON(apiPrefix + "/spaces?keys=" + url.QueryEscape(key))
+	if err != nil {
+		return nil, err
+	}
+	var list struct {
+		Results []Space `json:"results"`
+	}
+	if err := json.Unmarshal(body, &list); err != nil {
+		return nil, fmt.Errorf("decoding spaces: %w", err)
+	}
+	if len(list.Results) == 0 {
+		return nil, ErrNotFound
+	}
+	return &list.Results[0], nil
+}
+
+// searchPrefix is the v1 CQL search endpoint; v2 has no CQL support.
+const searchPrefix = "/wiki/rest/api/content/search"
+
+// SearchContent runs a CQL query, following pagination until limit results
+// are collected (limit <= 0 means all of them).
+func (c *Client) SearchContent(cql string, limit int) ([]SearchResult, error) {
+	pageSize := 50
+	if limit > 0 && limit < pageSize {
+		pageSize = limit
+	}
+	q := url.Values{}
+	q.Set("cql", cql)
+	q.Set("limit", fmt.Sprint(pageSize))
+	q.Set("expand", "space,version")
+	path := searchPrefix + "?" + q.Encode()
+
+	var all []SearchResult
+	for path != "" {
+		body, err := c.getJSON(path)
+		if err != nil {
+			return nil, err
+		}
+		var list searchResultList
+		if err := json.Unmarshal(body, &list); err != nil {
+			return nil, fmt.Errorf("decoding search results: %w", err)
+		}
+		all = append(all, list.Results...)
+		if limit > 0 && len(all) >= limit {
+			return all[:limit], nil
+		}
+		path = list.Links.Next
+		if path != "" && !strings.HasPrefix(path, "http") && !strings.HasPrefix(path, "/wiki/") {
+			path = "/wiki" + path
+		}
+	}
+	return all, nil
+}
+
+// listPages collects a paginated page listing.
+func (c *Client) listPages(path string) ([]PageSummary, error) {
+	var all []PageSummary
+	for path != "" {
+		body, err := c.getJSON(path)
+		if err != nil {
+			return nil, err
+		}
+		var list pageSummaryList
+		if err := json.Unmarshal(body, &list); err != nil {
+			return nil, fmt.Errorf("decoding pages: %w", err)
+		}
+		all = append(all, list.Results...)
+		path = list.Links.Next
+	}
+	return all, nil
+}
+
 // getJSON performs a GET expecting JSON, returning the body after status checks.
 // pathOrURL may be a path (prefixed with baseURL) or a full URL (pagination next).
 func (c *Client) getJSON(pathOrURL string) ([]byte, error) {
-	resp, err := c.do(http.MethodGet, pathOrURL)
+	resp, err := c.do(http.MethodGet, pathOrURL, nil)
 	if err != nil {
 		return nil, err
 	}
@@ -86,17 +288,20 @@ func (c *Client) getJSON(pathOrURL string) ([]byte, error) {
 	return body, nil
 }
 
-func (c *Client) do(method, pathOrURL string) (*http.Response, error) {
+func (c *Client) do(method, pathOrURL string, body io.Reader) (*http.Response, error) {
 	target := pathOrURL
 	if !strings.HasPrefix(target, "http") {
 		target = c.baseURL + pathOrURL
 	}
-	req, err := http.NewRequest(method, target, nil)
+	req, err := http.NewRequest(method, target, body)
 	if err != nil {
 		return nil, fmt.Errorf("creating request: %w", err)
 	}
 	req.Header.Set("Authorization", c.authHeader)
 	req.Header.Set("Accept", "application/json")
+	if body != nil {
+		req.Header.Set("Content-Type", "application/json")
+	}
 	resp, err := c.http.Do(req)
 	if err != nil {
 		return nil, fmt.Errorf("executing request: %w", err)
@@ -121,6 +326,8 @@ func classify(status int, body []byte) error {
 		return ErrForbidden
 	case http.StatusNotFound:
 		return ErrNotFound
+	case http.StatusConflict:
+		return ErrConflict
 	}
 	if status < 200 || status >= 300 {
 		return &APIError{StatusCode: status, Message: string(body)}
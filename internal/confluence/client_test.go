@@ -136,3 +136,255 @@ func TestStatusErrors(t *testing.T) {
 		ts.Close()
 	}
 }
+
+func TestGetChildPages(t *testing.T) {
+	var paths []string
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		paths = append(paths, r.URL.Path+"?"+r.URL.RawQuery)
+		if r.URL.Query().Get("cursor") == "" {
+			_, _ = w.Write([]byte(`{
+				"results":[{"id":"2","status":"current","title":"Child A","spaceId":"98765","parentId":"1"}],
+				"_links":{"next":"/wiki/api/v2/pages/1/children?cursor=NEXT&limit=250"}
+			}`))
+			return
+		}
+		_, _ = w.Write([]byte(`{"results":[{"id":"3","title":"Child B","parentId":"1"}],"_links":{}}`))
+	}))
+	defer ts.Close()
+
+	pages, err := testClient(ts).GetChildPages("1")
+	if err != nil {
+		t.Fatalf("GetChildPages: %v", err)
+	}
+	if len(pages) != 2 || pages[0].ID != "2" || pages[1].Title != "Child B" {
+		t.Fatalf("unexpected pages: %+v", pages)
+	}
+	if pages[0].ParentID != "1" || pages[0].SpaceID != "98765" {
+		t.Errorf("unexpected summary: %+v", pages[0])
+	}
+	if len(paths) != 2 || !strings.HasPrefix(paths[0], "/wiki/api/v2/pages/1/children?") {
+		t.Errorf("requests = %v", paths)
+	}
+}
+
+func TestGetSpaceRootPages(t *testing.T) {
+	var gotPath, gotDepth string
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		gotPath = r.URL.Path
+		gotDepth = r.URL.Query().Get("depth")
+		_, _ = w.Write([]byte(`{"results":[{"id":"10","title":"ENG Home"}],"_links":{}}`))
+	}))
+	defer ts.Close()
+
+	pages, err := testClient(ts).GetSpaceRootPages("98765")
+	if err != nil {
+		t.Fatalf("GetSpaceRootPages: %v", err)
+	}
+	if len(pages) != 1 || pages[0].ID != "10" {
+		t.Errorf("unexpected pages: %+v", pages)
+	}
+	if gotPath != "/wiki/api/v2/spaces/98765/pages" || gotDepth != "root" {
+		t.Errorf("path = %q, depth = %q", gotPath, gotDepth)
+	}
+}
+
+func TestGetSpaceByKey(t *testing.T) {
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Query().Get("keys") == "ENG" {
+			_, _ = w.Write([]byte(`{"results":[{"id":"98765","key":"ENG","name":"Engineering","homepageId":"10"}]}`))
+			return
+		}
+		_, _ = w.Write([]byte(`{"results":[]}`))
+	}))
+	defer ts.Close()
+
+	space, err := testClient(ts).GetSpaceByKey("ENG")
+	if err != nil {
+		t.Fatalf("GetSpaceByKey: %v", err)
+	}
+	if space.ID != "98765" || space.Name != "Engineering" || space.HomepageID != "10" {
+		t.Errorf("unexpected space: %+v", space)
+	}
+	if _, err := testClient(ts).GetSpaceByKey("NOPE"); !errors.Is(err, ErrNotFound) {
+		t.Errorf("unknown key: got %v, want ErrNotFound", err)
+	}
+}
+
+func TestGetSpace(t *testing.T) {
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/wiki/api/v2/spaces/98765" {
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+		_, _ = w.Write([]byte(`{"id":"98765","key":"ENG","name":"Engineering"}`))
+	}))
+	defer ts.Close()
+
+	space, err := testClient(ts).GetSpace("98765")
+	if err != nil {
+		t.Fatalf("GetSpace: %v", err)
+	}
+	if space.Key != "ENG" || space.Name != "Engineering" {
+		t.Errorf("unexpected space: %+v", space)
+	}
+	if _, err := testClient(ts).GetSpace("1"); !errors.Is(err, ErrNotFound) {
+		t.Errorf("unknown id: got %v, want ErrNotFound", err)
+	}
+}
+
+func TestGetPageAncestors(t *testing.T) {
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/wiki/rest/api/content/12" || r.URL.Query().Get("expand") != "ancestors" {
+			t.Errorf("unexpected request: %s", r.URL)
+		}
+		_, _ = w.Write([]byte(`{"id":"12","title":"API","ancestors":[
+			{"id":"10","title":"Home"},{"id":"11","title":"Design"}]}`))
+	}))
+	defer ts.Close()
+
+	ancestors, err := testClient(ts).GetPageAncestors("12")
+	if err != nil {
+		t.Fatalf("GetPageAncestors: %v", err)
+	}
+	if len(ancestors) != 2 || ancestors[0].Title != "Home" || ancestors[1].ID != "11" {
+		t.Errorf("unexpected ancestors: %+v", ancestors)
+	}
+}
+
+func TestSearchContent(t *testing.T) {
+	var queries []string
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/wiki/rest/api/content/search" {
+			t.Errorf("path = %q", r.URL.Path)
+		}
+		queries = append(queries, r.URL.Query().Get("cql"))
+		if r.URL.Query().Get("cursor") == "" {
+			_, _ = w.Write([]byte(`{
+				"results":[{"id":"1","type":"page","title":"Runbook","space":{"key":"OPS"},"version":{"number":3,"when":"2026-06-15T09:00:00.000Z"}}],
+				"_links":{"next":"/rest/api/content/search?cql=x&cursor=NEXT"}
+			}`))
+			return
+		}
+		_, _ = w.Write([]byte(`{"results":[{"id":"2","type":"page","title":"Oncall"},{"id":"3","type":"page","title":"Extra"}],"_links":{}}`))
+	}))
+	defer ts.Close()
+
+	results, err := testClient(ts).SearchContent(`space = OPS`, 2)
+	if err != nil {
+		t.Fatalf("SearchContent: %v", err)
+	}
+	if len(results) != 2 || results[0].Space.Key != "OPS" || results[1].ID != "2" {
+		t.Errorf("unexpected results: %+v", results)
+	}
+	if results[0].Version.When != "2026-06-15T09:00:00.000Z" {
+		t.Errorf("version = %+v", results[0].Version)
+	}
+	if len(queries) != 2 || queries[0] != "space = OPS" {
+		t.Errorf("queries = %v", queries)
+	}
+}
+
+func TestGetFooterComments(t *testing.T) {
+	var paths []string
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		paths = append(paths, r.URL.Path)
+		if r.URL.Query().Get("body-format") != "atlas_doc_format" {
+			t.Errorf("body-format = %q", r.URL.Query().Get("body-format"))
+		}
+		switch r.URL.Path {
+		case "/wiki/api/v2/pages/1/footer-comments":
+			_, _ = w.Write([]byte(`{"results":[{"id":"c1","version":{"authorId":"acc-1","createdAt":"2026-06-15T09:00:00.000Z"},
+				"body":{"atlas_doc_format":{"value":"{\"type\":\"doc\"}"}}}],"_links":{}}`))
+		case "/wiki/api/v2/footer-comments/c1/children":
+			_, _ = w.Write([]byte(`{"results":[{"id":"c2","version":{"authorId":"acc-2"}}],"_links":{}}`))
+		default:
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	defer ts.Close()
+
+	comments, err := testClient(ts).GetFooterComments("1")
+	if err != nil {
+		t.Fatalf("GetFooterComments: %v", err)
+	}
+	if len(comments) != 1 || comments[0].Version.AuthorID != "acc-1" {
+		t.Fatalf("comments = %+v", comments)
+	}
+	if len(comments[0].Replies) != 1 || comments[0].Replies[0].ID != "c2" {
+		t.Errorf("replies = %+v", comments[0].Replies)
+	}
+	if len(paths) != 2 {
+		t.Errorf("requests = %v", paths)
+	}
+}
+
+func TestGetInlineComments(t *testing.T) {
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path == "/wiki/api/v2/pages/1/inline-comments" {
+			_, _ = w.Write([]byte(`{"results":[
+				{"id":"i1","resolutionStatus":"open","properties":{"inlineOriginalSelection":"retry budget"}},
+				{"id":"i2","resolutionStatus":"resolved","properties":{"inline-original-selection":"old key"}}
+			],"_links":{}}`))
+			return
+		}
+		_, _ = w.Write([]byte(`{"results":[],"_links":{}}`))
+	}))
+	defer ts.Close()
+
+	comments, err := testClient(ts).GetInlineComments("1")
+	if err != nil {
+		t.Fatalf("GetInlineComments: %v", err)
+	}
+	if len(comments) != 2 {
+		t.Fatalf("comments = %+v", comments)
+	}
+	if comments[0].Selection() != "retry budget" || comments[1].Selection() != "old key" || comments[1].ResolutionStatus != "resolved" {
+		t.Errorf("comments = %+v", comments)
+	}
+}
+
+func TestGetPageVersions(t *testing.T) {
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/wiki/api/v2/pages/1/versions" {
+			t.Errorf("path = %q", r.URL.Path)
+		}
+		if r.URL.Query().Get("cursor") == "" {
+			_, _ = w.Write([]byte(`{"results":[
+				{"number":3,"message":"Fix typo","minorEdit":true,"authorId":"acc-1","createdAt":"2026-06-15T09:00:00.000Z"},
+				{"number":2,"authorId":"acc-2"}
+			],"_links":{"next":"/wiki/api/v2/pages/1/versions?cursor=NEXT"}}`))
+			return
+		}
+		_, _ = w.Write([]byte(`{"results":[{"number":1}],"_links":{}}`))
+	}))
+	defer ts.Close()
+
+	all, err := testClient(ts).GetPageVersions("1", 0)
+	if err != nil {
+		t.Fatalf("GetPageVersions: %v", err)
+	}
+	if len(all) != 3 || all[0].Number != 3 || !all[0].MinorEdit || all[0].Message != "Fix typo" || all[2].Number != 1 {
+		t.Errorf("versions = %+v", all)
+	}
+	limited, err := testClient(ts).GetPageVersions("1", 2)
+	if err != nil || len(limited) != 2 {
+		t.Errorf("limited = %+v, %v", limited, err)
+	}
+}
+
+func TestGetPageVersion(t *testing.T) {
+	var gotVersion string
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		gotVersion = r.URL.Query().Get("version")
+		_, _ = w.Write([]byte(`{"id":"1","title":"Old title","version":{"number":12}}`))
+	}))
+	defer ts.Close()
+
+	p, err := testClient(ts).GetPageVersion("1", 12)
+	if err != nil {
+		t.Fatalf("GetPageVersion: %v", err)
+	}
+	if gotVersion != "12" || p.Title != "Old title" || p.Version.Number != 12 {
+		t.Errorf("version param %q, page %+v", gotVersion, p)
+	}
+}
@@ -14,6 +14,10 @@ var ErrForbidden = errors.New("forbidden: token lacks Confluence access (use an
 // ErrNotFound indicates the requested resource does not exist (HTTP 404).
 var ErrNotFound = errors.New("not found")
 
+// ErrConflict indicates a write was rejected because the page changed in the
+// meantime (HTTP 409), e.g. the version number sent is no longer the next one.
+var ErrConflict = errors.New("conflict: the page was changed by someone else")
+
 // APIError represents a non-success HTTP response from Confluence.
 type APIError struct {
 	StatusCode int
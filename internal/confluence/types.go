@@ -11,6 +11,11 @@ type Page struct {
 	Version   *Version `json:"version"`
 	Body      Body     `json:"body"`
 	Links     Links    `json:"_links"`
+
+	// FooterComments and InlineComments are attached by the caller when
+	// comments are fetched (separate endpoints); nil otherwise.
+	FooterComments []Comment `json:"-"`
+	InlineComments []Comment `json:"-"`
 }
 
 // Version holds the page version metadata.
@@ -19,6 +24,15 @@ type Version struct {
 	CreatedAt string `json:"createdAt"`
 	AuthorID  string `json:"authorId"`
 	Message   string `json:"message"`
+	MinorEdit bool   `json:"minorEdit"`
+}
+
+// versionList is one page of a v2 page version listing.
+type versionList struct {
+	Results []Version `json:"results"`
+	Links   struct {
+		Next string `json:"next"`
+	} `json:"_links"`
 }
 
 // Body wraps the requested body representation(s).
@@ -57,3 +71,102 @@ type attachmentList struct {
 		Next string `json:"next"`
 	} `json:"_links"`
 }
+
+// PageSummary is a page as listed by the children and space-pages endpoints
+// (no body).
+type PageSummary struct {
+	ID       string `json:"id"`
+	Status   string `json:"status"`
+	Title    string `json:"title"`
+	SpaceID  string `json:"spaceId"`
+	ParentID string `json:"parentId"`
+}
+
+// pageSummaryList is one page of a v2 page listing.
+type pageSummaryList struct {
+	Results []PageSummary `json:"results"`
+	Links   struct {
+		Next string `json:"next"`
+	} `json:"_links"`
+}
+
+// Space is the subset of the v2 space object atlit uses.
+type Space struct {
+	ID         string `json:"id"`
+	Key        string `json:"key"`
+	Name       string `json:"name"`
+	HomepageID string `json:"homepageId"`
+}
+
+// SearchResult is a piece of content matched by a CQL search (v1 API, with
+// space and version expanded).
+type SearchResult struct {
+	ID    string `json:"id"`
+	Type  string `json:"type"`
+	Title string `json:"title"`
+	Space struct {
+		Key  string `json:"key"`
+		Name string `json:"name"`
+	} `json:"space"`
+	Version struct {
+		Number int    `json:"number"`
+		When   string `json:"when"`
+	} `json:"version"`
+	Links struct {
+		WebUI string `json:"webui"`
+	} `json:"_links"`
+}
+
+// searchResultList is one page of v1 CQL search results. Next is relative to
+// the /wiki context path.
+type searchResultList struct {
+	Results []SearchResult `json:"results"`
+	Links   struct {
+		Next string `json:"next"`
+	} `json:"_links"`
+}
+
+// UploadedAttachment is an attachment as returned by the v1 upload endpoint.
+type UploadedAttachment struct {
+	ID         string `json:"id"`
+	Title      string `json:"title"`
+	Extensions struct {
+		MediaType string `json:"mediaType"`
+		FileID    string `json:"fileId"`
+	} `json:"extensions"`
+}
+
+// Comment is a footer or inline comment on a page. Version carries the author
+// and time of the comment's latest edit. Inline comments also carry the page
+// text they are anchored to and whether they were resolved.
+type Comment struct {
+	ID               string   `json:"id"`
+	Status           string   `json:"status"`
+	Version          *Version `json:"version"`
+	Body             Body     `json:"body"`
+	ResolutionStatus string   `json:"resolutionStatus"`
+	Properties       struct {
+		InlineOriginalSelection string `json:"inlineOriginalSelection"`
+		// Older responses use the hyphenated key.
+		InlineOriginalSelectionLegacy string `json:"inline-original-selection"`
+	} `json:"properties"`
+
+	// Replies are attached by GetFooterComments/GetInlineComments.
+	Replies []Comment `json:"-"`
+}
+
+// Selection is the page text an inline comment is anchored to, or "".
+func (c *Comment) Selection() string {
+	if c.Properties.InlineOriginalSelection != "" {
+		return c.Properties.InlineOriginalSelection
+	}
+	return c.Properties.InlineOriginalSelectionLegacy
+}
+
+// commentList is one page of a v2 comment listing.
+type commentList struct {
+	Results []Comment `json:"results"`
+	Links   struct {
+		Next string `json:"next"`
+	} `json:"_links"`
+}
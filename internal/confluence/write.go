@@ -0,0 +1,161 @@
+package confluence
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"mime/multipart"
+	"net/http"
+	"net/url"
+)
+
+// PageUpdate is a new version of a page. Version is the number the new
+// version gets (the current one plus one); Confluence rejects anything else
+// with a conflict. Body is an ADF document encoded as a JSON string.
+type PageUpdate struct {
+	ID      string
+	Status  string
+	Title   string
+	Body    string
+	Version int
+	Message string
+}
+
+// Payload is the JSON body UpdatePage sends for u.
+func (u PageUpdate) Payload() map[string]any {
+	status := u.Status
+	if status == "" {
+		status = "current"
+	}
+	version := map[string]any{"number": u.Version}
+	if u.Message != "" {
+		version["message"] = u.Message
+	}
+	return map[string]any{
+		"id":      u.ID,
+		"status":  status,
+		"title":   u.Title,
+		"body":    map[string]string{"representation": "atlas_doc_format", "value": u.Body},
+		"version": version,
+	}
+}
+
+// UpdatePage publishes a new version of a page and returns it as updated.
+func (c *Client) UpdatePage(u PageUpdate) (*Page, error) {
+	body, err := c.send(http.MethodPut, apiPrefix+"/pages/"+url.PathEscape(u.ID), u.Payload())
+	if err != nil {
+		return nil, err
+	}
+	var p Page
+	if err := json.Unmarshal(body, &p); err != nil {
+		return nil, fmt.Errorf("decoding page: %w", err)
+	}
+	return &p, nil
+}
+
+// NewPage is a page to create. ParentID is optional (the page then sits at
+// the space's top level); Body is an ADF document encoded as a JSON string.
+type NewPage struct {
+	SpaceID  string
+	ParentID string
+	Title    string
+	Body     string
+}
+
+// Payload is the JSON body CreatePage sends for np.
+func (np NewPage) Payload() map[string]any {
+	payload := map[string]any{
+		"spaceId": np.SpaceID,
+		"status":  "current",
+		"title":   np.Title,
+		"body":    map[string]string{"representation": "atlas_doc_format", "value": np.Body},
+	}
+	if np.ParentID != "" {
+		payload["parentId"] = np.ParentID
+	}
+	return payload
+}
+
+// CreatePage publishes a new page and returns it as created.
+func (c *Client) CreatePage(np NewPage) (*Page, error) {
+	body, err := c.send(http.MethodPost, apiPrefix+"/pages", np.Payload())
+	if err != nil {
+		return nil, err
+	}
+	var p Page
+	if err := json.Unmarshal(body, &p); err != nil {
+		return nil, fmt.Errorf("decoding page: %w", err)
+	}
+	return &p, nil
+}
+
+// UploadAttachment attaches a file to a page through the v1 API (v2 has no
+// upload endpoint). FileID of the result is what ADF media nodes reference.
+func (c *Client) UploadAttachment(pageID, filename string, data []byte) (*UploadedAttachment, error) {
+	var buf bytes.Buffer
+	mw := multipart.NewWriter(&buf)
+	part, err := mw.CreateFormFile("file", filename)
+	if err != nil {
+		return nil, fmt.Errorf("encoding upload: %w", err)
+	}
+	if _, err := part.Write(data); err != nil {
+		return nil, fmt.Errorf("encoding upload: %w", err)
+	}
+	if err := mw.Close(); err != nil {
+		return nil, fmt.Errorf("encoding upload: %w", err)
+	}
+
+	target := c.baseURL + "/wiki/rest/api/content/" + url.PathEscape(pageID) + "/child/attachment"
+	req, err := http.NewRequest(http.MethodPost, target, &buf)
+	if err != nil {
+		return nil, fmt.Errorf("creating request: %w", err)
+	}
+	req.Header.Set("Authorization", c.authHeader)
+	req.Header.Set("Accept", "application/json")
+	req.Header.Set("Content-Type", mw.FormDataContentType())
+	// Required by Confluence for multipart requests (XSRF protection).
+	req.Header.Set("X-Atlassian-Token", "no-check")
+	resp, err := c.http.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("executing request: %w", err)
+	}
+	body, status, err := readAndClose(resp)
+	if err != nil {
+		return nil, err
+	}
+	if err := classify(status, body); err != nil {
+		return nil, err
+	}
+
+	var list struct {
+		Results []UploadedAttachment `json:"results"`
+	}
+	if err := json.Unmarshal(body, &list); err != nil {
+		return nil, fmt.Errorf("decoding attachment: %w", err)
+	}
+	if len(list.Results) == 0 {
+		return nil, fmt.Errorf("uploading %s: no attachment in response", filename)
+	}
+	return &list.Results[0], nil
+}
+
+// send performs a write with a JSON payload, returning the response body
+// after status checks.
+func (c *Client) send(method, path string, payload any) ([]byte, error) {
+	data, err := json.Marshal(payload)
+	if err != nil {
+		return nil, fmt.Errorf("encoding request: %w", err)
+	}
+	resp, err := c.do(method, path, bytes.NewReader(data))
+	if err != nil {
+		return nil, err
+	}
+	body, status, err := readAndClose(resp)
+	if err != nil {
+		return nil, err
+	}
+	if err := classify(status, body); err != nil {
+		return nil, err
+	}
+	return body, nil
+}
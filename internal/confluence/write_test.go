@@ -0,0 +1,117 @@
+package confluence
+
+import (
+	"encoding/json"
+	"errors"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func TestUpdatePage(t *testing.T) {
+	var gotMethod, gotPath, gotContentType string
+	var got map[string]any
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		gotMethod, gotPath, gotContentType = r.Method, r.URL.Path, r.Header.Get("Content-Type")
+		data, _ := io.ReadAll(r.Body)
+		if err := json.Unmarshal(data, &got); err != nil {
+			t.Errorf("request body: %v", err)
+		}
+		_, _ = w.Write([]byte(`{"id":"12345","title":"Design Doc","version":{"number":8}}`))
+	}))
+	defer ts.Close()
+
+	page, err := testClient(ts).UpdatePage(PageUpdate{
+		ID: "12345", Title: "Design Doc", Body: `{"type":"doc"}`, Version: 8, Message: "Fix steps",
+	})
+	if err != nil {
+		t.Fatalf("UpdatePage: %v", err)
+	}
+	if page.Version == nil || page.Version.Number != 8 {
+		t.Errorf("version = %+v", page.Version)
+	}
+	if gotMethod != http.MethodPut || gotPath != "/wiki/api/v2/pages/12345" || gotContentType != "application/json" {
+		t.Errorf("request = %s %s (%s)", gotMethod, gotPath, gotContentType)
+	}
+	if got["status"] != "current" || got["title"] != "Design Doc" {
+		t.Errorf("payload = %v", got)
+	}
+	body, _ := got["body"].(map[string]any)
+	if body["representation"] != "atlas_doc_format" || body["value"] != `{"type":"doc"}` {
+		t.Errorf("body = %v", body)
+	}
+	version, _ := got["version"].(map[string]any)
+	if version["number"] != float64(8) || version["message"] != "Fix steps" {
+		t.Errorf("version = %v", version)
+	}
+}
+
+func TestUpdatePageConflict(t *testing.T) {
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusConflict)
+	}))
+	defer ts.Close()
+
+	_, err := testClient(ts).UpdatePage(PageUpdate{ID: "1", Title: "T", Version: 2})
+	if !errors.Is(err, ErrConflict) {
+		t.Errorf("got %v, want ErrConflict", err)
+	}
+}
+
+func TestCreatePage(t *testing.T) {
+	var got map[string]any
+	var gotPath string
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		gotPath = r.URL.Path
+		data, _ := io.ReadAll(r.Body)
+		_ = json.Unmarshal(data, &got)
+		_, _ = w.Write([]byte(`{"id":"777","title":"Payment Retries","status":"current","version":{"number":1}}`))
+	}))
+	defer ts.Close()
+
+	page, err := testClient(ts).CreatePage(NewPage{SpaceID: "98765", ParentID: "12345", Title: "Payment Retries", Body: `{"type":"doc"}`})
+	if err != nil {
+		t.Fatalf("CreatePage: %v", err)
+	}
+	if page.ID != "777" || page.Version.Number != 1 {
+		t.Errorf("page = %+v", page)
+	}
+	if gotPath != "/wiki/api/v2/pages" || got["spaceId"] != "98765" || got["parentId"] != "12345" || got["title"] != "Payment Retries" {
+		t.Errorf("request %s: %v", gotPath, got)
+	}
+
+	if _, ok := (NewPage{SpaceID: "1", Title: "T"}).Payload()["parentId"]; ok {
+		t.Error("parentId sent without a parent")
+	}
+}
+
+func TestUploadAttachment(t *testing.T) {
+	var gotToken, gotName, gotData string
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodPost || r.URL.Path != "/wiki/rest/api/content/777/child/attachment" {
+			t.Errorf("request = %s %s", r.Method, r.URL.Path)
+		}
+		gotToken = r.Header.Get("X-Atlassian-Token")
+		f, hdr, err := r.FormFile("file")
+		if err != nil {
+			t.Errorf("form file: %v", err)
+		} else {
+			data, _ := io.ReadAll(f)
+			gotName, gotData = hdr.Filename, string(data)
+		}
+		_, _ = w.Write([]byte(`{"results":[{"id":"att9","title":"flow.png","extensions":{"mediaType":"image/png","fileId":"file-uuid"}}]}`))
+	}))
+	defer ts.Close()
+
+	att, err := testClient(ts).UploadAttachment("777", "flow.png", []byte("png-bytes"))
+	if err != nil {
+		t.Fatalf("UploadAttachment: %v", err)
+	}
+	if att.ID != "att9" || att.Extensions.FileID != "file-uuid" {
+		t.Errorf("attachment = %+v", att)
+	}
+	if gotToken != "no-check" || gotName != "flow.png" || gotData != "png-bytes" {
+		t.Errorf("token = %q, name = %q, data = %q", gotToken, gotName, gotData)
+	}
+}
@@ -0,0 +1,246 @@
+package github
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+)
+
+// PublicHost is github.com, whose API lives on a separate host.
+const PublicHost = "github.com"
+
+// Client is an authenticated GitHub (or GitHub Enterprise Server) REST API
+// client.
+type Client struct {
+	baseURL    string
+	authHeader string
+	http       *http.Client
+}
+
+// NewClient creates a client for host using a personal access token. host is
+// "github.com" or a GitHub Enterprise Server host, whose API is served under
+// /api/v3.
+func NewClient(host, token string) *Client {
+	return &Client{
+		baseURL:    APIBaseURL(host),
+		authHeader: "Bearer " + token,
+		http:       &http.Client{Timeout: 30 * time.Second},
+	}
+}
+
+// APIBaseURL returns the REST API root for a GitHub host.
+func APIBaseURL(host string) string {
+	if host == "" || strings.EqualFold(host, PublicHost) {
+		return "https://api.github.com"
+	}
+	return "https://" + host + "/api/v3"
+}
+
+// CurrentUser returns the token owner's login; used to verify a token.
+func (c *Client) CurrentUser() (string, error) {
+	body, _, err := c.getJSON("/user")
+	if err != nil {
+		return "", err
+	}
+	var u User
+	if err := json.Unmarshal(body, &u); err != nil {
+		return "", fmt.Errorf("decoding user: %w", err)
+	}
+	return u.Login, nil
+}
+
+// GetPullRequest fetches a pull request's core fields.
+func (c *Client) GetPullRequest(owner, repo string, number int) (*PullRequest, error) {
+	body, _, err := c.getJSON(fmt.Sprintf("/repos/%s/%s/pulls/%d", owner, repo, number))
+	if err != nil {
+		return nil, err
+	}
+	var pr PullRequest
+	if err := json.Unmarshal(body, &pr); err != nil {
+		return nil, fmt.Errorf("decoding pull request: %w", err)
+	}
+	return &pr, nil
+}
+
+// GetPullRequestDiff fetches the unified diff via the diff media type.
+func (c *Client) GetPullRequestDiff(owner, repo string, number int) (string, error) {
+	resp, err := c.do(http.MethodGet, fmt.Sprintf("/repos/%s/%s/pulls/%d", owner, repo, number), "application/vnd.github.diff")
+	if err != nil {
+		return "", err
+	}
+	body, status, err := readAndClose(resp)
+	if err != nil {
+		return "", err
+	}
+	if err := classify(status, body); err != nil {
+		return "", err
+	}
+	return string(body), nil
+}
+
+// GetCompareDiff fetches the unified diff between two commits (base...head).
+func (c *Client) GetCompareDiff(owner, repo, base, head string) (string, error) {
+	resp, err := c.do(http.MethodGet, fmt.Sprintf("/repos/%s/%s/compare/%s...%s", owner, repo, url.PathEscape(base), url.PathEscape(head)), "application/vnd.github.diff")
+	if err != nil {
+		return "", err
+	}
+	body, status, err := readAndClose(resp)
+	if err != nil {
+		return "", err
+	}
+	if err := classify(status, body); err != nil {
+		return "", err
+	}
+	return string(body), nil
+}
+
+// GetPullRequestFiles returns per-file change stats, following pagination.
+func (c *Client) GetPullRequestFiles(owner, repo string, number int) ([]File, error) {
+	var all []File
+	err := c.getPages(fmt.Sprintf("/repos/%s/%s/pulls/%d/files?per_page=100", owner, repo, number), func(body []byte) error {
+		var page []File
+		if err := json.Unmarshal(body, &page); err != nil {
+			return fmt.Errorf("decoding files: %w", err)
+		}
+		all = append(all, page...)
+		return nil
+	})
+	return all, err
+}
+
+// GetIssueComments returns the PR's conversation comments, oldest first.
+func (c *Client) GetIssueComments(owner, repo string, number int) ([]Comment, error) {
+	return c.getComments(fmt.Sprintf("/repos/%s/%s/issues/%d/comments?per_page=100", owner, repo, number))
+}
+
+// GetReviewComments returns the PR's inline review comments, oldest first.
+func (c *Client) GetReviewComments(owner, repo string, number int) ([]Comment, error) {
+	return c.getComments(fmt.Sprintf("/repos/%s/%s/pulls/%d/comments?per_page=100", owner, repo, number))
+}
+
+// GetReviews returns the PR's submitted reviews, oldest first.
+func (c *Client) GetReviews(owner, repo string, number int) ([]Review, error) {
+	var all []Review
+	err := c.getPages(fmt.Sprintf("/repos/%s/%s/pulls/%d/reviews?per_page=100", owner, repo, number), func(body []byte) error {
+		var page []Review
+		if err := json.Unmarshal(body, &page); err != nil {
+			return fmt.Errorf("decoding reviews: %w", err)
+		}
+		all = append(all, page...)
+		return nil
+	})
+	return all, err
+}
+
+func (c *Client) getComments(path string) ([]Comment, error) {
+	var all []Comment
+	err := c.getPages(path, func(body []byte) error {
+		var page []Comment
+		if err := json.Unmarshal(body, &page); err != nil {
+			return fmt.Errorf("decoding comments: %w", err)
+		}
+		all = append(all, page...)
+		return nil
+	})
+	return all, err
+}
+
+// getPages GETs path and every following page named by the Link header,
+// handing each body to add.
+func (c *Client) getPages(path string, add func(body []byte) error) error {
+	next := path
+	for next != "" {
+		body, header, err := c.getJSON(next)
+		if err != nil {
+			return err
+		}
+		if err := add(body); err != nil {
+			return err
+		}
+		next = nextLink(header.Get("Link"))
+	}
+	return nil
+}
+
+// nextLink extracts the rel="next" URL from a Link header, or "".
+func nextLink(link string) string {
+	for _, part := range strings.Split(link, ",") {
+		segs := strings.Split(part, ";")
+		if len(segs) < 2 {
+			continue
+		}
+		for _, attr := range segs[1:] {
+			if strings.TrimSpace(attr) == `rel="next"` {
+				return strings.Trim(strings.TrimSpace(segs[0]), "<>")
+			}
+		}
+	}
+	return ""
+}
+
+// getJSON performs a GET expecting JSON, returning the body and headers after
+// status checks. pathOrURL may be a path (prefixed with baseURL) or a full URL
+// (pagination links).
+func (c *Client) getJSON(pathOrURL string) ([]byte, http.Header, error) {
+	resp, err := c.do(http.MethodGet, pathOrURL, "application/vnd.github+json")
+	if err != nil {
+		return nil, nil, err
+	}
+	body, status, err := readAndClose(resp)
+	if err != nil {
+		return nil, nil, err
+	}
+	if err := classify(status, body); err != nil {
+		return nil, nil, err
+	}
+	return body, resp.Header, nil
+}
+
+func (c *Client) do(method, pathOrURL, accept string) (*http.Response, error) {
+	target := pathOrURL
+	if !strings.HasPrefix(target, "http") {
+		target = c.baseURL + pathOrURL
+	}
+	req, err := http.NewRequest(method, target, nil)
+	if err != nil {
+		return nil, fmt.Errorf("creating request: %w", err)
+	}
+	req.Header.Set("Authorization", c.authHeader)
+	req.Header.Set("X-GitHub-Api-Version", "2022-11-28")
+	if accept != "" {
+		req.Header.Set("Accept", accept)
+	}
+	resp, err := c.http.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("executing request: %w", err)
+	}
+	return resp, nil
+}
+
+func readAndClose(resp *http.Response) ([]byte, int, error) {
+	defer func() { _ = resp.Body.Close() }()
+	data, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return nil, resp.StatusCode, fmt.Errorf("reading response body: %w", err)
+	}
+	return data, resp.StatusCode, nil
+}
+
+func classify(status int, body []byte) error {
+	switch status {
+	case http.StatusUnauthorized:
+		return ErrUnauthorized
+	case http.StatusForbidden:
+		return ErrForbidden
+	case http.StatusNotFound:
+		return ErrNotFound
+	}
+	if status < 200 || status >= 300 {
+		return &APIError{StatusCode: status, Message: string(body)}
+	}
+	return nil
+}
@@ -0,0 +1,117 @@
+package github
+
+import (
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+)
+
+func testClient(ts *httptest.Server) *Client {
+	return &Client{baseURL: ts.URL, authHeader: "Bearer tok", http: ts.Client()}
+}
+
+func TestAPIBaseURL(t *testing.T) {
+	if got := APIBaseURL("github.com"); got != "https://api.github.com" {
+		t.Errorf("github.com -> %q", got)
+	}
+	if got := APIBaseURL("github.example.com"); got != "https://github.example.com/api/v3" {
+		t.Errorf("enterprise -> %q", got)
+	}
+}
+
+func TestGetPullRequest(t *testing.T) {
+	var gotAuth, gotAccept, gotPath string
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		gotAuth = r.Header.Get("Authorization")
+		gotAccept = r.Header.Get("Accept")
+		gotPath = r.URL.Path
+		_, _ = w.Write([]byte(`{"number":42,"title":"Fix bug","state":"closed","merged":true,
+			"user":{"login":"alice"},"head":{"ref":"feature/PROJ-1"},"base":{"ref":"main"},
+			"html_url":"https://github.com/acme/widget/pull/42"}`))
+	}))
+	defer ts.Close()
+
+	pr, err := testClient(ts).GetPullRequest("acme", "widget", 42)
+	if err != nil {
+		t.Fatalf("GetPullRequest: %v", err)
+	}
+	if pr.Number != 42 || !pr.Merged || pr.User.Login != "alice" || pr.Head.Ref != "feature/PROJ-1" || pr.Base.Ref != "main" {
+		t.Errorf("unexpected PR: %+v", pr)
+	}
+	if gotAuth != "Bearer tok" {
+		t.Errorf("auth header = %q", gotAuth)
+	}
+	if gotAccept != "application/vnd.github+json" {
+		t.Errorf("accept = %q", gotAccept)
+	}
+	if gotPath != "/repos/acme/widget/pulls/42" {
+		t.Errorf("path = %q", gotPath)
+	}
+}
+
+func TestGetPullRequestDiff(t *testing.T) {
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if got := r.Header.Get("Accept"); got != "application/vnd.github.diff" {
+			t.Errorf("accept = %q", got)
+		}
+		_, _ = w.Write([]byte("diff --git a/x b/x\n+added\n"))
+	}))
+	defer ts.Close()
+
+	diff, err := testClient(ts).GetPullRequestDiff("acme", "widget", 1)
+	if err != nil {
+		t.Fatalf("GetPullRequestDiff: %v", err)
+	}
+	if !strings.HasPrefix(diff, "diff --git a/x b/x") {
+		t.Errorf("diff = %q", diff)
+	}
+}
+
+func TestGetPullRequestFilesPagination(t *testing.T) {
+	var ts *httptest.Server
+	ts = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Query().Get("page") == "" {
+			w.Header().Set("Link", `<`+ts.URL+`/repos/acme/widget/pulls/1/files?per_page=100&page=2>; rel="next", <`+ts.URL+`/x?page=2>; rel="last"`)
+			_, _ = w.Write([]byte(`[{"filename":"a.go","status":"modified","additions":3,"deletions":1}]`))
+			return
+		}
+		_, _ = w.Write([]byte(`[{"filename":"b.go","previous_filename":"old.go","status":"renamed"}]`))
+	}))
+	defer ts.Close()
+
+	files, err := testClient(ts).GetPullRequestFiles("acme", "widget", 1)
+	if err != nil {
+		t.Fatalf("GetPullRequestFiles: %v", err)
+	}
+	if len(files) != 2 || files[0].Additions != 3 || files[1].PreviousFilename != "old.go" {
+		t.Errorf("files = %+v", files)
+	}
+}
+
+func TestNextLink(t *testing.T) {
+	cases := map[string]string{
+		"": "",
+		`<https://api.github.com/x?page=2>; rel="next", <https://api.github.com/x?page=5>; rel="last"`: "https://api.github.com/x?page=2",
+		`<https://api.github.com/x?page=1>; rel="prev"`:                                                "",
+	}
+	for in, want := range cases {
+		if got := nextLink(in); got != want {
+			t.Errorf("nextLink(%q) = %q, want %q", in, got, want)
+		}
+	}
+}
+
+func TestErrorClassification(t *testing.T) {
+	for status, want := range map[int]error{401: ErrUnauthorized, 403: ErrForbidden, 404: ErrNotFound} {
+		ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			w.WriteHeader(status)
+		}))
+		_, err := testClient(ts).GetPullRequest("acme", "widget", 1)
+		ts.Close()
+		if !errors.Is(err, want) {
+			t.Errorf("HTTP %d: err = %v, want %v", status, err, want)
+		}
+	}
+}
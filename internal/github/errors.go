@@ -0,0 +1,26 @@
+package github
+
+import (
+	"errors"
+	"fmt"
+)
+
+// ErrUnauthorized indicates invalid or missing credentials (HTTP 401).
+var ErrUnauthorized = errors.New("unauthorized: check your GitHub token")
+
+// ErrForbidden indicates the token lacks access or a rate limit was hit (HTTP 403).
+var ErrForbidden = errors.New("forbidden: token missing scope (need repo, or read access to pull requests and contents) or rate limited")
+
+// ErrNotFound indicates the requested resource does not exist (HTTP 404).
+// GitHub also answers 404 for private repositories the token cannot see.
+var ErrNotFound = errors.New("not found")
+
+// APIError represents a non-success HTTP response from GitHub.
+type APIError struct {
+	StatusCode int
+	Message    string
+}
+
+func (e *APIError) Error() string {
+	return fmt.Sprintf("github API error (HTTP %d): %s", e.StatusCode, e.Message)
+}
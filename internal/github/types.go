@@ -0,0 +1,62 @@
+package github
+
+// PullRequest is the subset of the GitHub pull request object atlit renders.
+type PullRequest struct {
+	Number    int    `json:"number"`
+	Title     string `json:"title"`
+	State     string `json:"state"` // open | closed
+	Draft     bool   `json:"draft"`
+	Merged    bool   `json:"merged"`
+	Body      string `json:"body"`
+	User      User   `json:"user"`
+	Head      Ref    `json:"head"`
+	Base      Ref    `json:"base"`
+	CreatedAt string `json:"created_at"`
+	UpdatedAt string `json:"updated_at"`
+	HTMLURL   string `json:"html_url"`
+
+	RequestedReviewers []User `json:"requested_reviewers"`
+}
+
+// User is a GitHub account reference. Only the login is embedded in PR and
+// comment payloads.
+type User struct {
+	Login string `json:"login"`
+}
+
+// Ref is one side (head/base) of a pull request.
+type Ref struct {
+	Ref string `json:"ref"`
+	SHA string `json:"sha"`
+}
+
+// File is a changed file in a pull request.
+type File struct {
+	Filename         string `json:"filename"`
+	PreviousFilename string `json:"previous_filename"`
+	Status           string `json:"status"` // added | removed | modified | renamed | ...
+	Additions        int    `json:"additions"`
+	Deletions        int    `json:"deletions"`
+}
+
+// Comment is a conversation comment or an inline review comment. Path, Line
+// and InReplyToID are only set on review comments.
+type Comment struct {
+	ID           int64  `json:"id"`
+	Body         string `json:"body"`
+	User         User   `json:"user"`
+	CreatedAt    string `json:"created_at"`
+	Path         string `json:"path"`
+	Line         *int   `json:"line"`
+	OriginalLine *int   `json:"original_line"`
+	InReplyToID  int64  `json:"in_reply_to_id"`
+}
+
+// Review is a submitted pull request review; Body is its summary comment.
+type Review struct {
+	ID          int64  `json:"id"`
+	Body        string `json:"body"`
+	User        User   `json:"user"`
+	State       string `json:"state"` // APPROVED | CHANGES_REQUESTED | COMMENTED | ...
+	SubmittedAt string `json:"submitted_at"`
+}
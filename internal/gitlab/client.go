@@ -0,0 +1,194 @@
+package gitlab
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+)
+
+// PublicHost is gitlab.com.
+const PublicHost = "gitlab.com"
+
+// Client is an authenticated GitLab REST API (v4) client.
+type Client struct {
+	baseURL string
+	token   string
+	http    *http.Client
+}
+
+// NewClient creates a client for host ("gitlab.com" or a self-managed host)
+// using a personal access token with the read_api scope.
+func NewClient(host, token string) *Client {
+	if host == "" {
+		host = PublicHost
+	}
+	return &Client{
+		baseURL: "https://" + host + "/api/v4",
+		token:   token,
+		http:    &http.Client{Timeout: 30 * time.Second},
+	}
+}
+
+// projectPath returns the API path of a project given its full path
+// ("group/subgroup/project"), which GitLab expects URL-encoded as one segment.
+func projectPath(project string) string {
+	return "/projects/" + url.PathEscape(project)
+}
+
+// CurrentUser returns the token owner's username; used to verify a token.
+func (c *Client) CurrentUser() (string, error) {
+	body, _, err := c.getJSON("/user")
+	if err != nil {
+		return "", err
+	}
+	var u User
+	if err := json.Unmarshal(body, &u); err != nil {
+		return "", fmt.Errorf("decoding user: %w", err)
+	}
+	return u.Username, nil
+}
+
+// GetMergeRequest fetches a merge request's core fields.
+func (c *Client) GetMergeRequest(project string, iid int) (*MergeRequest, error) {
+	body, _, err := c.getJSON(fmt.Sprintf("%s/merge_requests/%d", projectPath(project), iid))
+	if err != nil {
+		return nil, err
+	}
+	var mr MergeRequest
+	if err := json.Unmarshal(body, &mr); err != nil {
+		return nil, fmt.Errorf("decoding merge request: %w", err)
+	}
+	return &mr, nil
+}
+
+// GetMergeRequestDiffs returns the merge request's changed files with their
+// hunks, following pagination.
+func (c *Client) GetMergeRequestDiffs(project string, iid int) ([]FileDiff, error) {
+	var all []FileDiff
+	err := c.getPages(fmt.Sprintf("%s/merge_requests/%d/diffs?per_page=100", projectPath(project), iid), func(body []byte) error {
+		var page []FileDiff
+		if err := json.Unmarshal(body, &page); err != nil {
+			return fmt.Errorf("decoding diffs: %w", err)
+		}
+		all = append(all, page...)
+		return nil
+	})
+	return all, err
+}
+
+// Compare returns the changed files between two commits as a straight
+// (from..to) comparison rather than one from their merge base.
+func (c *Client) Compare(project, from, to string) ([]FileDiff, error) {
+	q := url.Values{"from": {from}, "to": {to}, "straight": {"true"}}
+	body, _, err := c.getJSON(projectPath(project) + "/repository/compare?" + q.Encode())
+	if err != nil {
+		return nil, err
+	}
+	var res struct {
+		Diffs []FileDiff `json:"diffs"`
+	}
+	if err := json.Unmarshal(body, &res); err != nil {
+		return nil, fmt.Errorf("decoding comparison: %w", err)
+	}
+	return res.Diffs, nil
+}
+
+// GetDiscussions returns the merge request's comment threads, oldest first.
+func (c *Client) GetDiscussions(project string, iid int) ([]Discussion, error) {
+	var all []Discussion
+	err := c.getPages(fmt.Sprintf("%s/merge_requests/%d/discussions?per_page=100", projectPath(project), iid), func(body []byte) error {
+		var page []Discussion
+		if err := json.Unmarshal(body, &page); err != nil {
+			return fmt.Errorf("decoding discussions: %w", err)
+		}
+		all = append(all, page...)
+		return nil
+	})
+	return all, err
+}
+
+// getPages GETs path and the following pages announced by X-Next-Page,
+// handing each body to add.
+func (c *Client) getPages(path string, add func(body []byte) error) error {
+	page := ""
+	for {
+		target := path
+		if page != "" {
+			target += "&page=" + url.QueryEscape(page)
+		}
+		body, header, err := c.getJSON(target)
+		if err != nil {
+			return err
+		}
+		if err := add(body); err != nil {
+			return err
+		}
+		page = header.Get("X-Next-Page")
+		if page == "" {
+			return nil
+		}
+	}
+}
+
+// getJSON performs a GET expecting JSON, returning the body and headers after
+// status checks.
+func (c *Client) getJSON(path string) ([]byte, http.Header, error) {
+	resp, err := c.do(http.MethodGet, path)
+	if err != nil {
+		return nil, nil, err
+	}
+	body, status, err := readAndClose(resp)
+	if err != nil {
+		return nil, nil, err
+	}
+	if err := classify(status, body); err != nil {
+		return nil, nil, err
+	}
+	return body, resp.Header, nil
+}
+
+func (c *Client) do(method, path string) (*http.Response, error) {
+	target := path
+	if !strings.HasPrefix(target, "http") {
+		target = c.baseURL + path
+	}
+	req, err := http.NewRequest(method, target, nil)
+	if err != nil {
+		return nil, fmt.Errorf("creating request: %w", err)
+	}
+	req.Header.Set("PRIVATE-TOKEN", c.token)
+	req.Header.Set("Accept", "application/json")
+	resp, err := c.http.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("executing request: %w", err)
+	}
+	return resp, nil
+}
+
+func readAndClose(resp *http.Response) ([]byte, int, error) {
+	defer func() { _ = resp.Body.Close() }()
+	data, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return nil, resp.StatusCode, fmt.Errorf("reading response body: %w", err)
+	}
+	return data, resp.StatusCode, nil
+}
+
+func classify(status int, body []byte) error {
+	switch status {
+	case http.StatusUnauthorized:
+		return ErrUnauthorized
+	case http.StatusForbidden:
+		return ErrForbidden
+	case http.StatusNotFound:
+		return ErrNotFound
+	}
+	if status < 200 || status >= 300 {
+		return &APIError{StatusCode: status, Message: string(body)}
+	}
+	return nil
+}
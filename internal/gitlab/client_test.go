@@ -0,0 +1,75 @@
+package gitlab
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func testClient(ts *httptest.Server) *Client {
+	return &Client{baseURL: ts.URL, token: "tok", http: ts.Client()}
+}
+
+func TestGetMergeRequest(t *testing.T) {
+	var gotToken, gotPath string
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		gotToken = r.Header.Get("PRIVATE-TOKEN")
+		gotPath = r.URL.EscapedPath()
+		_, _ = w.Write([]byte(`{"iid":7,"title":"Add cache","state":"opened",
+			"author":{"name":"Alice","username":"alice"},
+			"source_branch":"feature/PROJ-2","target_branch":"main"}`))
+	}))
+	defer ts.Close()
+
+	mr, err := testClient(ts).GetMergeRequest("acme/platform/widget", 7)
+	if err != nil {
+		t.Fatalf("GetMergeRequest: %v", err)
+	}
+	if mr.IID != 7 || mr.State != "opened" || mr.Author.Name != "Alice" || mr.SourceBranch != "feature/PROJ-2" {
+		t.Errorf("unexpected MR: %+v", mr)
+	}
+	if gotToken != "tok" {
+		t.Errorf("PRIVATE-TOKEN = %q", gotToken)
+	}
+	// The project path must stay a single URL-encoded segment.
+	if gotPath != "/projects/acme%2Fplatform%2Fwidget/merge_requests/7" {
+		t.Errorf("path = %q", gotPath)
+	}
+}
+
+func TestGetDiscussionsPagination(t *testing.T) {
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Query().Get("page") == "" {
+			w.Header().Set("X-Next-Page", "2")
+			_, _ = w.Write([]byte(`[{"id":"a","notes":[{"id":1,"body":"first"}]}]`))
+			return
+		}
+		w.Header().Set("X-Next-Page", "")
+		_, _ = w.Write([]byte(`[{"id":"b","notes":[{"id":2,"body":"second","system":true}]}]`))
+	}))
+	defer ts.Close()
+
+	ds, err := testClient(ts).GetDiscussions("acme/widget", 1)
+	if err != nil {
+		t.Fatalf("GetDiscussions: %v", err)
+	}
+	if len(ds) != 2 || ds[0].Notes[0].Body != "first" || !ds[1].Notes[0].System {
+		t.Errorf("discussions = %+v", ds)
+	}
+}
+
+func TestFileDiffUnifiedAndStats(t *testing.T) {
+	d := FileDiff{OldPath: "a.go", NewPath: "a.go", Diff: "@@ -1,2 +1,2 @@\n-old\n+new\n+more\n ctx"}
+	want := "diff --git a/a.go b/a.go\n--- a/a.go\n+++ b/a.go\n@@ -1,2 +1,2 @@\n-old\n+new\n+more\n ctx\n"
+	if got := d.Unified(); got != want {
+		t.Errorf("Unified =\n%q\nwant\n%q", got, want)
+	}
+	if added, removed := d.Stats(); added != 2 || removed != 1 {
+		t.Errorf("Stats = +%d -%d, want +2 -1", added, removed)
+	}
+
+	created := FileDiff{OldPath: "n.go", NewPath: "n.go", NewFile: true, Diff: "@@ -0,0 +1 @@\n+x\n"}
+	if got := created.Unified(); got != "diff --git a/n.go b/n.go\n--- /dev/null\n+++ b/n.go\n@@ -0,0 +1 @@\n+x\n" {
+		t.Errorf("new file Unified = %q", got)
+	}
+}
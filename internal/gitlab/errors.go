@@ -0,0 +1,26 @@
+package gitlab
+
+import (
+	"errors"
+	"fmt"
+)
+
+// ErrUnauthorized indicates invalid or missing credentials (HTTP 401).
+var ErrUnauthorized = errors.New("unauthorized: check your GitLab token")
+
+// ErrForbidden indicates the token lacks a required scope (HTTP 403).
+var ErrForbidden = errors.New("forbidden: token missing scope (need read_api)")
+
+// ErrNotFound indicates the requested resource does not exist (HTTP 404).
+// GitLab also answers 404 for private projects the token cannot see.
+var ErrNotFound = errors.New("not found")
+
+// APIError represents a non-success HTTP response from GitLab.
+type APIError struct {
+	StatusCode int
+	Message    string
+}
+
+func (e *APIError) Error() string {
+	return fmt.Sprintf("gitlab API error (HTTP %d): %s", e.StatusCode, e.Message)
+}
@@ -0,0 +1,98 @@
+package gitlab
+
+import (
+	"fmt"
+	"strings"
+)
+
+// MergeRequest is the subset of the GitLab merge request object atlit renders.
+type MergeRequest struct {
+	IID          int    `json:"iid"`
+	Title        string `json:"title"`
+	State        string `json:"state"` // opened | closed | merged | locked
+	Draft        bool   `json:"draft"`
+	Description  string `json:"description"`
+	Author       User   `json:"author"`
+	SourceBranch string `json:"source_branch"`
+	TargetBranch string `json:"target_branch"`
+	SHA          string `json:"sha"`
+	CreatedAt    string `json:"created_at"`
+	UpdatedAt    string `json:"updated_at"`
+	WebURL       string `json:"web_url"`
+}
+
+// User is a GitLab account reference.
+type User struct {
+	Name     string `json:"name"`
+	Username string `json:"username"`
+}
+
+// FileDiff is one changed file of a merge request. Diff holds only the hunks
+// (starting at "@@"), without the git headers.
+type FileDiff struct {
+	OldPath     string `json:"old_path"`
+	NewPath     string `json:"new_path"`
+	Diff        string `json:"diff"`
+	NewFile     bool   `json:"new_file"`
+	RenamedFile bool   `json:"renamed_file"`
+	DeletedFile bool   `json:"deleted_file"`
+}
+
+// Unified returns the file's diff with git-style headers, so a merge request's
+// files concatenate into a regular unified diff.
+func (d FileDiff) Unified() string {
+	var b strings.Builder
+	fmt.Fprintf(&b, "diff --git a/%s b/%s\n", d.OldPath, d.NewPath)
+	switch {
+	case d.NewFile:
+		fmt.Fprintf(&b, "--- /dev/null\n+++ b/%s\n", d.NewPath)
+	case d.DeletedFile:
+		fmt.Fprintf(&b, "--- a/%s\n+++ /dev/null\n", d.OldPath)
+	default:
+		fmt.Fprintf(&b, "--- a/%s\n+++ b/%s\n", d.OldPath, d.NewPath)
+	}
+	b.WriteString(d.Diff)
+	if d.Diff != "" && !strings.HasSuffix(d.Diff, "\n") {
+		b.WriteString("\n")
+	}
+	return b.String()
+}
+
+// Stats counts the added and removed lines in the file's hunks.
+func (d FileDiff) Stats() (added, removed int) {
+	for _, line := range strings.Split(d.Diff, "\n") {
+		switch {
+		case strings.HasPrefix(line, "+"):
+			added++
+		case strings.HasPrefix(line, "-"):
+			removed++
+		}
+	}
+	return added, removed
+}
+
+// Discussion is a comment thread; the first note starts it and the rest are
+// replies.
+type Discussion struct {
+	ID    string `json:"id"`
+	Notes []Note `json:"notes"`
+}
+
+// Note is a merge request comment. System notes ("added 1 commit", ...) are
+// generated by GitLab rather than written by a person.
+type Note struct {
+	ID        int       `json:"id"`
+	Body      string    `json:"body"`
+	Author    User      `json:"author"`
+	CreatedAt string    `json:"created_at"`
+	System    bool      `json:"system"`
+	Position  *Position `json:"position"`
+}
+
+// Position locates a diff note within a file.
+type Position struct {
+	OldPath string `json:"old_path"`
+	NewPath string `json:"new_path"`
+	OldLine *int   `json:"old_line"`
+	NewLine *int   `json:"new_line"`
+}
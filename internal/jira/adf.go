@@ -7,16 +7,23 @@ import (
 
 // RenderADF converts an Atlassian Document Format document to markdown.
 func RenderADF(doc *ADFDoc) string {
+	return RenderADFWith(doc, nil)
+}
+
+// RenderADFWith is RenderADF with mentions that carry no display text (as in
+// Confluence page bodies) named through names. names may be nil.
+func RenderADFWith(doc *ADFDoc, names NameResolver) string {
 	if doc == nil || len(doc.Content) == 0 {
 		return ""
 	}
-	c := &converter{}
+	c := &converter{names: names}
 	c.renderNodes(doc.Content, 0)
 	return strings.TrimRight(c.buf.String(), "\n")
 }
 
 type converter struct {
-	buf strings.Builder
+	buf   strings.Builder
+	names NameResolver
 }
 
 func (c *converter) renderNodes(nodes []ADFNode, depth int) {
@@ -109,6 +116,9 @@ func (c *converter) renderInline(node ADFNode, depth int) {
 		if name == "" {
 			name = node.Text
 		}
+		if name == "" || name == "@" {
+			name = c.mentionName(attrStr(node.Attrs, "id"))
+		}
 		if !strings.HasPrefix(name, "@") {
 			name = "@" + name
 		}
@@ -142,6 +152,17 @@ func (c *converter) renderInline(node ADFNode, depth int) {
 	}
 }
 
+// mentionName names a mention by account id: the resolved display name, else
+// the id itself.
+func (c *converter) mentionName(accountID string) string {
+	if c.names != nil && accountID != "" {
+		if name := c.names.DisplayName(accountID); name != "" {
+			return name
+		}
+	}
+	return accountID
+}
+
 // mediaMarkdown renders an ADF media node as a markdown image reference so an
 // embedded image is never silently dropped. External media carry a direct URL;
 // file/link media reference an attachment by filename (the node's alt text),
@@ -352,6 +352,35 @@ func TestRenderADFMention(t *testing.T) {
 	}
 }
 
+// fakeNames is a NameResolver over a fixed map.
+type fakeNames map[string]string
+
+func (f fakeNames) DisplayName(accountID string) string { return f[accountID] }
+
+func TestRenderADFWithResolvesBareMentions(t *testing.T) {
+	doc := &ADFDoc{
+		Type:    "doc",
+		Version: 1,
+		Content: []ADFNode{
+			{
+				Type: "paragraph",
+				Content: []ADFNode{
+					{Type: "mention", Attrs: map[string]any{"id": "acc-1"}},
+					{Type: "text", Text: ", "},
+					{Type: "mention", Attrs: map[string]any{"id": "acc-2", "text": "@Bob"}},
+					{Type: "text", Text: ", "},
+					{Type: "mention", Attrs: map[string]any{"id": "acc-3", "text": "@"}},
+				},
+			},
+		},
+	}
+	want := "@Alice Smith, @Bob, @acc-3"
+	got := RenderADFWith(doc, fakeNames{"acc-1": "Alice Smith", "acc-2": "Robert"})
+	if got != want {
+		t.Errorf("got %q, want %q", got, want)
+	}
+}
+
 func TestRenderADFEmoji(t *testing.T) {
 	doc := &ADFDoc{
 		Type:    "doc",
@@ -0,0 +1,192 @@
+package jira
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/url"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// agilePrefix is the Jira Software (Agile) REST API path. Boards and sprints
+// live here rather than under /rest/api/3.
+const agilePrefix = "/rest/agile/1.0"
+
+// agilePageSize is the page size requested from Agile list endpoints (the
+// server caps it at 50 for boards and sprints).
+const agilePageSize = 50
+
+// ListBoards returns the boards visible to the user, following startAt
+// pagination. projectKey narrows the list to boards whose filter references
+// that project; pass "" for all boards.
+func (c *Client) ListBoards(projectKey string) ([]Board, error) {
+	var all []Board
+	for startAt := 0; ; {
+		params := url.Values{}
+		params.Set("startAt", strconv.Itoa(startAt))
+		params.Set("maxResults", strconv.Itoa(agilePageSize))
+		if projectKey != "" {
+			params.Set("projectKeyOrId", projectKey)
+		}
+		data, err := c.getJSON(agilePrefix + "/board?" + params.Encode())
+		if err != nil {
+			return nil, err
+		}
+		var page struct {
+			Values []Board `json:"values"`
+			IsLast bool    `json:"isLast"`
+		}
+		if err := json.Unmarshal(data, &page); err != nil {
+			return nil, fmt.Errorf("decoding boards: %w", err)
+		}
+		all = append(all, page.Values...)
+		if page.IsLast || len(page.Values) == 0 {
+			return all, nil
+		}
+		startAt += len(page.Values)
+	}
+}
+
+// GetBoard fetches a single board by id.
+func (c *Client) GetBoard(boardID int) (*Board, error) {
+	data, err := c.getJSON(fmt.Sprintf("%s/board/%d", agilePrefix, boardID))
+	if err != nil {
+		return nil, err
+	}
+	var board Board
+	if err := json.Unmarshal(data, &board); err != nil {
+		return nil, fmt.Errorf("decoding board: %w", err)
+	}
+	return &board, nil
+}
+
+// GetBoardColumns returns a board's columns in display order. Each column
+// carries only status ids; resolve names with GetStatuses.
+func (c *Client) GetBoardColumns(boardID int) ([]BoardColumn, error) {
+	data, err := c.getJSON(fmt.Sprintf("%s/board/%d/configuration", agilePrefix, boardID))
+	if err != nil {
+		return nil, err
+	}
+	var cfg boardConfiguration
+	if err := json.Unmarshal(data, &cfg); err != nil {
+		return nil, fmt.Errorf("decoding board configuration: %w", err)
+	}
+	return cfg.ColumnConfig.Columns, nil
+}
+
+// ListSprints returns a board's sprints, following startAt pagination. state
+// filters by sprint state ("active", "future", "closed", or a comma-separated
+// combination); pass "" for all states. Kanban boards have no sprints and
+// answer with an APIError (HTTP 400).
+func (c *Client) ListSprints(boardID int, state string) ([]Sprint, error) {
+	var all []Sprint
+	for startAt := 0; ; {
+		params := url.Values{}
+		params.Set("startAt", strconv.Itoa(startAt))
+		params.Set("maxResults", strconv.Itoa(agilePageSize))
+		if state != "" {
+			params.Set("state", state)
+		}
+		data, err := c.getJSON(fmt.Sprintf("%s/board/%d/sprint?%s", agilePrefix, boardID, params.Encode()))
+		if err != nil {
+			return nil, err
+		}
+		var page struct {
+			Values []Sprint `json:"values"`
+			IsLast bool     `json:"isLast"`
+		}
+		if err := json.Unmarshal(data, &page); err != nil {
+			return nil, fmt.Errorf("decoding sprints: %w", err)
+		}
+		all = append(all, page.Values...)
+		if page.IsLast || len(page.Values) == 0 {
+			return all, nil
+		}
+		startAt += len(page.Values)
+	}
+}
+
+// GetSprintIssues returns every issue in a sprint, following startAt
+// pagination. The Agile API does not honour expand=names, so custom fields
+// (story points, epic) are resolved against names, typically built from
+// GetFieldNames; pass nil to skip custom-field extraction.
+func (c *Client) GetSprintIssues(sprintID int, fields []string, names map[string]string) ([]Issue, error) {
+	var all []Issue
+	for startAt := 0; ; {
+		params := url.Values{}
+		params.Set("startAt", strconv.Itoa(startAt))
+		params.Set("maxResults", "100")
+		if len(fields) > 0 {
+			params.Set("fields", strings.Join(fields, ","))
+		}
+		data, err := c.getJSON(fmt.Sprintf("%s/sprint/%d/issue?%s", agilePrefix, sprintID, params.Encode()))
+		if err != nil {
+			return nil, err
+		}
+		var page struct {
+			Issues []json.RawMessage `json:"issues"`
+			Total  int               `json:"total"`
+		}
+		if err := json.Unmarshal(data, &page); err != nil {
+			return nil, fmt.Errorf("decoding sprint issues: %w", err)
+		}
+		for _, rawIssue := range page.Issues {
+			issue, err := decodeIssue(rawIssue, names)
+			if err != nil {
+				return nil, err
+			}
+			all = append(all, *issue)
+		}
+		startAt += len(page.Issues)
+		if len(page.Issues) == 0 || startAt >= page.Total {
+			return all, nil
+		}
+	}
+}
+
+// GetFieldNames returns a field id -> display name map for every field on the
+// site, the same shape as the "names" map returned by expand=names.
+func (c *Client) GetFieldNames() (map[string]string, error) {
+	data, err := c.getJSON("/rest/api/3/field")
+	if err != nil {
+		return nil, err
+	}
+	var fields []Field
+	if err := json.Unmarshal(data, &fields); err != nil {
+		return nil, fmt.Errorf("decoding fields: %w", err)
+	}
+	names := make(map[string]string, len(fields))
+	for _, f := range fields {
+		names[f.ID] = f.Name
+	}
+	return names, nil
+}
+
+// GetStatuses lists every workflow status on the site.
+func (c *Client) GetStatuses() ([]StatusInfo, error) {
+	data, err := c.getJSON("/rest/api/3/status")
+	if err != nil {
+		return nil, err
+	}
+	var statuses []StatusInfo
+	if err := json.Unmarshal(data, &statuses); err != nil {
+		return nil, fmt.Errorf("decoding statuses: %w", err)
+	}
+	return statuses, nil
+}
+
+// StoryPointsFieldIDs returns the ids of the story-point custom fields found in
+// names ("Story Points" and/or "Story point estimate"), for use in a fields
+// list. Returns nil when the site has neither.
+func StoryPointsFieldIDs(names map[string]string) []string {
+	var ids []string
+	for id, name := range names {
+		switch strings.ToLower(name) {
+		case "story points", "story point estimate":
+			ids = append(ids, id)
+		}
+	}
+	sort.Strings(ids)
+	return ids
+}
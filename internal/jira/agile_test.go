@@ -0,0 +1,147 @@
+package jira
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+)
+
+func TestListSprintsPagination(t *testing.T) {
+	var gotStates []string
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/rest/agile/1.0/board/42/sprint" {
+			t.Errorf("unexpected path: %s", r.URL.Path)
+		}
+		gotStates = append(gotStates, r.URL.Query().Get("state"))
+		if r.URL.Query().Get("startAt") == "1" {
+			_, _ = w.Write([]byte(`{"isLast":true,"values":[{"id":2,"name":"Sprint 2","state":"active"}]}`))
+			return
+		}
+		_, _ = w.Write([]byte(`{"isLast":false,"values":[{"id":1,"name":"Sprint 1","state":"active",
+			"goal":"Ship it","startDate":"2026-06-01T09:00:00.000Z","endDate":"2026-06-14T17:00:00.000Z"}]}`))
+	}))
+	defer srv.Close()
+
+	client := NewClient(srv.URL, "test@example.com", "token123")
+	sprints, err := client.ListSprints(42, "active")
+	if err != nil {
+		t.Fatalf("ListSprints: %v", err)
+	}
+	if len(sprints) != 2 || sprints[0].ID != 1 || sprints[1].ID != 2 {
+		t.Fatalf("got %+v, want sprints 1 and 2", sprints)
+	}
+	if sprints[0].Goal != "Ship it" || sprints[0].EndDate != "2026-06-14T17:00:00.000Z" {
+		t.Errorf("sprint 1 = %+v", sprints[0])
+	}
+	if strings.Join(gotStates, ",") != "active,active" {
+		t.Errorf("state params = %v", gotStates)
+	}
+}
+
+func TestListSprintsKanbanBoard(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusBadRequest)
+		_, _ = w.Write([]byte(`{"errorMessages":["The board does not support sprints"]}`))
+	}))
+	defer srv.Close()
+
+	client := NewClient(srv.URL, "test@example.com", "token123")
+	_, err := client.ListSprints(7, "active")
+	var apiErr *APIError
+	if !errors.As(err, &apiErr) || apiErr.StatusCode != http.StatusBadRequest {
+		t.Errorf("expected APIError 400, got: %v", err)
+	}
+}
+
+func TestGetSprintIssuesStoryPoints(t *testing.T) {
+	var gotFields string
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/rest/agile/1.0/sprint/9/issue" {
+			t.Errorf("unexpected path: %s", r.URL.Path)
+		}
+		gotFields = r.URL.Query().Get("fields")
+		start := r.URL.Query().Get("startAt")
+		if start == "0" {
+			_, _ = w.Write([]byte(`{"startAt":0,"total":2,"issues":[
+				{"id":"1","key":"PROJ-1","fields":{"summary":"One","status":{"name":"To Do","statusCategory":{"key":"new"}},"customfield_10016":5}}]}`))
+			return
+		}
+		_, _ = fmt.Fprint(w, `{"startAt":1,"total":2,"issues":[
+			{"id":"2","key":"PROJ-2","fields":{"summary":"Two","customfield_10016":null}}]}`)
+	}))
+	defer srv.Close()
+
+	names := map[string]string{"customfield_10016": "Story Points"}
+	client := NewClient(srv.URL, "test@example.com", "token123")
+	issues, err := client.GetSprintIssues(9, []string{"summary", "customfield_10016"}, names)
+	if err != nil {
+		t.Fatalf("GetSprintIssues: %v", err)
+	}
+	if len(issues) != 2 {
+		t.Fatalf("got %d issues, want 2", len(issues))
+	}
+	if issues[0].StoryPoints == nil || *issues[0].StoryPoints != 5 {
+		t.Errorf("PROJ-1 points = %v, want 5", issues[0].StoryPoints)
+	}
+	if issues[0].Fields.Status.StatusCategory == nil || issues[0].Fields.Status.StatusCategory.Key != "new" {
+		t.Errorf("PROJ-1 status category = %+v", issues[0].Fields.Status)
+	}
+	if issues[1].StoryPoints != nil {
+		t.Errorf("PROJ-2 points = %v, want nil", *issues[1].StoryPoints)
+	}
+	if gotFields != "summary,customfield_10016" {
+		t.Errorf("fields param = %q", gotFields)
+	}
+}
+
+func TestGetBoardColumns(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/rest/agile/1.0/board/42/configuration" {
+			t.Errorf("unexpected path: %s", r.URL.Path)
+		}
+		_, _ = w.Write([]byte(`{"columnConfig":{"columns":[
+			{"name":"To Do","statuses":[{"id":"1"},{"id":"10000"}]},
+			{"name":"Done","statuses":[{"id":"10001"}]}]}}`))
+	}))
+	defer srv.Close()
+
+	client := NewClient(srv.URL, "test@example.com", "token123")
+	cols, err := client.GetBoardColumns(42)
+	if err != nil {
+		t.Fatalf("GetBoardColumns: %v", err)
+	}
+	if len(cols) != 2 || cols[0].Name != "To Do" || len(cols[0].Statuses) != 2 || cols[1].Statuses[0].ID != "10001" {
+		t.Errorf("unexpected columns: %+v", cols)
+	}
+}
+
+func TestGetBoardNotFound(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusNotFound)
+	}))
+	defer srv.Close()
+
+	client := NewClient(srv.URL, "test@example.com", "token123")
+	if _, err := client.GetBoard(1); !errors.Is(err, ErrNotFound) {
+		t.Errorf("expected ErrNotFound, got: %v", err)
+	}
+}
+
+func TestStoryPointsFieldIDs(t *testing.T) {
+	names := map[string]string{
+		"customfield_10016": "Story point estimate",
+		"customfield_10002": "Story Points",
+		"customfield_10020": "Sprint",
+		"summary":           "Summary",
+	}
+	got := StoryPointsFieldIDs(names)
+	if strings.Join(got, ",") != "customfield_10002,customfield_10016" {
+		t.Errorf("got %v", got)
+	}
+	if got := StoryPointsFieldIDs(map[string]string{"summary": "Summary"}); got != nil {
+		t.Errorf("no points field: got %v", got)
+	}
+}
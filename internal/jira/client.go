@@ -7,6 +7,7 @@ import (
 	"io"
 	"net/http"
 	"net/url"
+	"sort"
 	"strconv"
 	"strings"
 	"time"
@@ -123,8 +124,8 @@ func (c *Client) GetIssueWithFields(key, fieldsQuery string) (*Issue, error) {
 	return issue, nil
 }
 
-// extractCustomFields scans the names map to find Sprint and Epic custom
-// field IDs, then parses their values from the raw fields JSON.
+// extractCustomFields scans the names map to find the Sprint, Epic and Story
+// Points custom field IDs, then parses their values from the raw fields JSON.
 func extractCustomFields(issue *Issue, rawFields json.RawMessage, names map[string]string) {
 	if len(names) == 0 || len(rawFields) == 0 {
 		return
@@ -132,12 +133,17 @@ func extractCustomFields(issue *Issue, rawFields json.RawMessage, names map[stri
 
 	// Find custom field IDs by their display name.
 	var sprintFieldID, epicFieldID string
+	var pointsFieldIDs []string
 	for id, name := range names {
 		switch strings.ToLower(name) {
 		case "sprint":
 			sprintFieldID = id
 		case "epic link":
 			epicFieldID = id
+		case "story points", "story point estimate":
+			// Company-managed projects use "Story Points"; team-managed ones
+			// "Story point estimate". Both may exist on one site.
+			pointsFieldIDs = append(pointsFieldIDs, id)
 		}
 	}
 
@@ -157,6 +163,28 @@ func extractCustomFields(issue *Issue, rawFields json.RawMessage, names map[stri
 			issue.Epic = parseEpic(raw)
 		}
 	}
+	// Take the first points field that actually carries a value.
+	for _, id := range pointsFieldIDs {
+		if raw, ok := allFields[id]; ok {
+			if p := parsePoints(raw); p != nil {
+				issue.StoryPoints = p
+				break
+			}
+		}
+	}
+}
+
+// parsePoints extracts a numeric story-point estimate; null or non-numeric
+// values yield nil.
+func parsePoints(raw json.RawMessage) *float64 {
+	if len(raw) == 0 || string(raw) == "null" {
+		return nil
+	}
+	var v float64
+	if err := json.Unmarshal(raw, &v); err != nil {
+		return nil
+	}
+	return &v
 }
 
 // parseSprint extracts sprint info from a custom field value.
@@ -208,77 +236,42 @@ func parseEpic(raw json.RawMessage) *Epic {
 // defaults. maxResults caps the number of issues returned: pagination stops
 // once that many have been collected, so a broad query does not fetch the whole
 // result set. Pass 0 (or a negative value) to fetch every matching issue.
+//
+// When the cap cuts the result short, NextPageToken is left set (and IsLast
+// false) so callers can tell more issues match.
 func (c *Client) SearchIssues(jql string, fields []string, maxResults int) (*SearchResult, error) {
 	result := &SearchResult{}
 	var nextPageToken string
 
 	for {
-		params := url.Values{}
-		params.Set("jql", jql)
-		params.Set("expand", "names")
-		if len(fields) > 0 {
-			params.Set("fields", strings.Join(fields, ","))
-		}
+		pageSize := 0
 		if maxResults > 0 {
 			// Request only what is still needed, capped at the API page max (100).
-			remaining := maxResults - len(result.Issues)
-			if remaining > 100 {
-				remaining = 100
+			pageSize = maxResults - len(result.Issues)
+			if pageSize > 100 {
+				pageSize = 100
 			}
-			params.Set("maxResults", strconv.Itoa(remaining))
-		}
-		if nextPageToken != "" {
-			params.Set("nextPageToken", nextPageToken)
-		}
-
-		resp, err := c.do(http.MethodGet, "/rest/api/3/search/jql?"+params.Encode(), nil)
-		if err != nil {
-			return nil, err
 		}
 
-		data, statusCode, err := readAndClose(resp)
+		page, err := c.SearchIssuesPage(jql, fields, pageSize, nextPageToken)
 		if err != nil {
 			return nil, err
 		}
-
-		switch statusCode {
-		case http.StatusUnauthorized, http.StatusForbidden:
-			return nil, ErrUnauthorized
-		}
-		if statusCode != http.StatusOK {
-			return nil, &APIError{
-				StatusCode: statusCode,
-				Message:    string(data),
-			}
-		}
-
-		// Decode the search response page.
-		var page struct {
-			Issues        []json.RawMessage `json:"issues"`
-			Names         map[string]string `json:"names"`
-			NextPageToken string            `json:"nextPageToken"`
-			IsLast        bool              `json:"isLast"`
-		}
-		if err := json.Unmarshal(data, &page); err != nil {
-			return nil, fmt.Errorf("decoding search response: %w", err)
-		}
-
-		// Two-pass decode each issue, reusing the top-level names map.
-		for _, rawIssue := range page.Issues {
-			issue, err := decodeIssue(rawIssue, page.Names)
-			if err != nil {
-				return nil, err
-			}
-			result.Issues = append(result.Issues, *issue)
-		}
+		result.Issues = append(result.Issues, page.Issues...)
 
 		// Stop once the caller's cap is reached (trim any overshoot from the
 		// final page) or the server reports the last page.
 		if maxResults > 0 && len(result.Issues) >= maxResults {
+			more := len(result.Issues) > maxResults || !page.IsLast
 			result.Issues = result.Issues[:maxResults]
+			if more {
+				result.NextPageToken = page.NextPageToken
+			} else {
+				result.IsLast = true
+			}
 			break
 		}
-		if page.IsLast || page.NextPageToken == "" {
+		if page.IsLast {
 			result.IsLast = true
 			break
 		}
@@ -288,6 +281,71 @@ func (c *Client) SearchIssues(jql string, fields []string, maxResults int) (*Sea
 	return result, nil
 }
 
+// SearchIssuesPage fetches a single page of a JQL search. pageSize <= 0 uses
+// the server default; pageToken is the NextPageToken of the previous page ("" for
+// the first). The returned result's IsLast is true when no further page
+// exists, otherwise NextPageToken resumes the search.
+func (c *Client) SearchIssuesPage(jql string, fields []string, pageSize int, pageToken string) (*SearchResult, error) {
+	params := url.Values{}
+	params.Set("jql", jql)
+	params.Set("expand", "names")
+	if len(fields) > 0 {
+		params.Set("fields", strings.Join(fields, ","))
+	}
+	if pageSize > 0 {
+		params.Set("maxResults", strconv.Itoa(pageSize))
+	}
+	if pageToken != "" {
+		params.Set("nextPageToken", pageToken)
+	}
+
+	resp, err := c.do(http.MethodGet, "/rest/api/3/search/jql?"+params.Encode(), nil)
+	if err != nil {
+		return nil, err
+	}
+
+	data, statusCode, err := readAndClose(resp)
+	if err != nil {
+		return nil, err
+	}
+
+	switch statusCode {
+	case http.StatusUnauthorized, http.StatusForbidden:
+		return nil, ErrUnauthorized
+	}
+	if statusCode != http.StatusOK {
+		return nil, &APIError{
+			StatusCode: statusCode,
+			Message:    string(data),
+		}
+	}
+
+	// Decode the search response page.
+	var page struct {
+		Issues        []json.RawMessage `json:"issues"`
+		Names         map[string]string `json:"names"`
+		NextPageToken string            `json:"nextPageToken"`
+		IsLast        bool              `json:"isLast"`
+	}
+	if err := json.Unmarshal(data, &page); err != nil {
+		return nil, fmt.Errorf("decoding search response: %w", err)
+	}
+
+	result := &SearchResult{
+		NextPageToken: page.NextPageToken,
+		IsLast:        page.IsLast || page.NextPageToken == "",
+	}
+	// Two-pass decode each issue, reusing the top-level names map.
+	for _, rawIssue := range page.Issues {
+		issue, err := decodeIssue(rawIssue, page.Names)
+		if err != nil {
+			return nil, err
+		}
+		result.Issues = append(result.Issues, *issue)
+	}
+	return result, nil
+}
+
 // SearchUsers calls GET /rest/api/3/user/search to find users whose name or
 // email matches the query. It is used to resolve a human-friendly assignee name
 // (e.g. "alice") to an accountId for use in JQL, since Jira Cloud matches the
@@ -386,6 +444,94 @@ func (c *Client) UpdateDescription(key string, doc *ADFDoc) error {
 	}
 }
 
+// CreateIssue creates an issue using POST /rest/api/3/issue and returns its
+// id and key. A 400 response carries Jira's per-field validation messages in
+// the APIError so the caller can show what to fix.
+func (c *Client) CreateIssue(ni *NewIssue) (*CreatedIssue, error) {
+	fields := map[string]any{
+		"project":   map[string]string{"key": ni.ProjectKey},
+		"issuetype": map[string]string{"name": ni.IssueType},
+		"summary":   ni.Summary,
+	}
+	if ni.Description != nil && len(ni.Description.Content) > 0 {
+		fields["description"] = ni.Description
+	}
+	if ni.Priority != "" {
+		fields["priority"] = map[string]string{"name": ni.Priority}
+	}
+	if ni.AssigneeID != "" {
+		fields["assignee"] = map[string]string{"accountId": ni.AssigneeID}
+	}
+	if len(ni.Labels) > 0 {
+		fields["labels"] = ni.Labels
+	}
+	if ni.ParentKey != "" {
+		fields["parent"] = map[string]string{"key": ni.ParentKey}
+	}
+
+	data, err := json.Marshal(map[string]any{"fields": fields})
+	if err != nil {
+		return nil, fmt.Errorf("marshaling create payload: %w", err)
+	}
+
+	resp, err := c.do(http.MethodPost, "/rest/api/3/issue", strings.NewReader(string(data)))
+	if err != nil {
+		return nil, err
+	}
+	body, statusCode, err := readAndClose(resp)
+	if err != nil {
+		return nil, err
+	}
+
+	switch statusCode {
+	case http.StatusCreated, http.StatusOK:
+	case http.StatusUnauthorized, http.StatusForbidden:
+		return nil, ErrUnauthorized
+	case http.StatusNotFound:
+		return nil, ErrNotFound
+	default:
+		return nil, &APIError{StatusCode: statusCode, Message: createErrorMessage(body)}
+	}
+
+	var created CreatedIssue
+	if err := json.Unmarshal(body, &created); err != nil {
+		return nil, fmt.Errorf("decoding create response: %w", err)
+	}
+	return &created, nil
+}
+
+// createErrorMessage flattens Jira's {"errorMessages": [...], "errors": {...}}
+// error body into one line, falling back to the raw body.
+func createErrorMessage(body []byte) string {
+	var e struct {
+		ErrorMessages []string          `json:"errorMessages"`
+		Errors        map[string]string `json:"errors"`
+	}
+	if err := json.Unmarshal(body, &e); err != nil || (len(e.ErrorMessages) == 0 && len(e.Errors) == 0) {
+		return string(body)
+	}
+	parts := append([]string{}, e.ErrorMessages...)
+	keys := make([]string, 0, len(e.Errors))
+	for k := range e.Errors {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	for _, k := range keys {
+		parts = append(parts, k+": "+e.Errors[k])
+	}
+	return strings.Join(parts, "; ")
+}
+
+// Dev-status data types, one per tab of the Jira development panel. They are
+// both the keys of the summary response and the detail endpoint's dataType.
+const (
+	DevPullRequests = "pullrequest"
+	DevBranches     = "branch"
+	DevCommits      = "repository"
+	DevBuilds       = "build"
+	DevDeployments  = "deployment-environment"
+)
+
 // GetPullRequests returns the pull requests linked to an issue via Jira's
 // development panel, using the dev-status API. It first queries the summary
 // endpoint to discover which application types (bitbucket, github, ...) host
@@ -396,32 +542,79 @@ func (c *Client) UpdateDescription(key string, doc *ADFDoc) error {
 // API is unofficial (it backs the Jira UI) and may be unavailable on some
 // instances; callers should treat errors as non-fatal.
 func (c *Client) GetPullRequests(issueID string) ([]PullRequest, error) {
+	dev, err := c.GetDevelopment(issueID, DevPullRequests)
+	if err != nil || dev == nil {
+		return nil, err
+	}
+	return dev.PullRequests, nil
+}
+
+// GetDevelopment fetches the requested dev-status data types (DevPullRequests,
+// DevBranches, ...) for an issue with one summary call plus one detail call
+// per data type and application type that has entries. See GetPullRequests
+// for the caveats of the dev-status API. An empty issueID returns nil.
+//
+// Each data type stands on its own: when some fail, the others are still
+// returned, along with a *DevStatusError naming the failed ones. A failed
+// summary call fails them all (nil Development).
+func (c *Client) GetDevelopment(issueID string, dataTypes ...string) (*Development, error) {
 	if issueID == "" {
 		return nil, nil
 	}
 
-	appTypes, err := c.devStatusPRAppTypes(issueID)
+	appTypes, err := c.devStatusAppTypes(issueID, dataTypes)
 	if err != nil {
 		return nil, err
 	}
 
-	var prs []PullRequest
-	for _, appType := range appTypes {
-		detail, err := c.devStatusPRDetail(issueID, appType)
-		if err != nil {
-			return nil, err
+	dev := &Development{}
+	failed := map[string]error{}
+	for _, dataType := range dataTypes {
+		// A data type counts only when all its application types were fetched.
+		part := &Development{}
+		for _, appType := range appTypes[dataType] {
+			detail, err := c.devStatusDetail(issueID, appType, dataType)
+			if err != nil {
+				failed[dataType] = err
+				break
+			}
+			for _, d := range detail.Detail {
+				switch dataType {
+				case DevPullRequests:
+					for _, pr := range d.PullRequests {
+						pr.AppType = appType
+						part.PullRequests = append(part.PullRequests, pr)
+					}
+				case DevBranches:
+					part.Branches = append(part.Branches, d.Branches...)
+				case DevCommits:
+					part.Repositories = append(part.Repositories, d.Repositories...)
+				case DevBuilds:
+					part.Builds = append(part.Builds, d.Builds...)
+				case DevDeployments:
+					part.Deployments = append(part.Deployments, d.Deployments...)
+				}
+			}
 		}
-		for i := range detail {
-			detail[i].AppType = appType
+		if failed[dataType] != nil {
+			continue
 		}
-		prs = append(prs, detail...)
+		dev.PullRequests = append(dev.PullRequests, part.PullRequests...)
+		dev.Branches = append(dev.Branches, part.Branches...)
+		dev.Repositories = append(dev.Repositories, part.Repositories...)
+		dev.Builds = append(dev.Builds, part.Builds...)
+		dev.Deployments = append(dev.Deployments, part.Deployments...)
 	}
-	return prs, nil
+	if len(failed) > 0 {
+		return dev, &DevStatusError{Failed: failed}
+	}
+	return dev, nil
 }
 
-// devStatusPRAppTypes calls the dev-status summary endpoint and returns the
-// application types that have at least one pull request for the issue.
-func (c *Client) devStatusPRAppTypes(issueID string) ([]string, error) {
+// devStatusAppTypes calls the dev-status summary endpoint and returns, for each
+// requested data type, the application types that have at least one entry for
+// the issue. Data types with no entries are absent from the result.
+func (c *Client) devStatusAppTypes(issueID string, dataTypes []string) (map[string][]string, error) {
 	params := url.Values{}
 	params.Set("issueId", issueID)
 	resp, err := c.do(http.MethodGet, "/rest/dev-status/latest/issue/summary?"+params.Encode(), nil)
@@ -446,24 +639,31 @@ func (c *Client) devStatusPRAppTypes(issueID string) ([]string, error) {
 	if err := json.Unmarshal(data, &summary); err != nil {
 		return nil, fmt.Errorf("decoding dev-status summary: %w", err)
 	}
-	if summary.Summary.PullRequest.Overall.Count == 0 {
-		return nil, nil
-	}
-	var appTypes []string
-	for appType, info := range summary.Summary.PullRequest.ByInstanceType {
-		if info.Count > 0 {
-			appTypes = append(appTypes, appType)
+	result := map[string][]string{}
+	for _, dataType := range dataTypes {
+		entry, ok := summary.Summary[dataType]
+		if !ok || entry.Overall.Count == 0 {
+			continue
+		}
+		var appTypes []string
+		for appType, info := range entry.ByInstanceType {
+			if info.Count > 0 {
+				appTypes = append(appTypes, appType)
+			}
 		}
+		// Map order is random; sort so output order is stable across pulls.
+		sort.Strings(appTypes)
+		result[dataType] = appTypes
 	}
-	return appTypes, nil
+	return result, nil
 }
 
-// devStatusPRDetail fetches the pull-request detail for one application type.
-func (c *Client) devStatusPRDetail(issueID, appType string) ([]PullRequest, error) {
+// devStatusDetail fetches the detail of one data type for one application type.
+func (c *Client) devStatusDetail(issueID, appType, dataType string) (*devStatusDetail, error) {
 	params := url.Values{}
 	params.Set("issueId", issueID)
 	params.Set("applicationType", appType)
-	params.Set("dataType", "pullrequest")
+	params.Set("dataType", dataType)
 	resp, err := c.do(http.MethodGet, "/rest/dev-status/latest/issue/detail?"+params.Encode(), nil)
 	if err != nil {
 		return nil, err
@@ -486,11 +686,31 @@ func (c *Client) devStatusPRDetail(issueID, appType string) ([]PullRequest, erro
 	if err := json.Unmarshal(data, &detail); err != nil {
 		return nil, fmt.Errorf("decoding dev-status detail: %w", err)
 	}
-	var prs []PullRequest
-	for _, d := range detail.Detail {
-		prs = append(prs, d.PullRequests...)
+	return &detail, nil
+}
+
+// getJSON performs a GET expecting JSON and returns the body after mapping
+// 401/403 to ErrUnauthorized, 404 to ErrNotFound and any other non-200 to an
+// APIError.
+func (c *Client) getJSON(path string) ([]byte, error) {
+	resp, err := c.do(http.MethodGet, path, nil)
+	if err != nil {
+		return nil, err
+	}
+	data, statusCode, err := readAndClose(resp)
+	if err != nil {
+		return nil, err
+	}
+	switch statusCode {
+	case http.StatusUnauthorized, http.StatusForbidden:
+		return nil, ErrUnauthorized
+	case http.StatusNotFound:
+		return nil, ErrNotFound
+	}
+	if statusCode != http.StatusOK {
+		return nil, &APIError{StatusCode: statusCode, Message: string(data)}
 	}
-	return prs, nil
+	return data, nil
 }
 
 // readAndClose reads the full body and closes it, returning data and status code.
@@ -729,3 +729,203 @@ func TestGetPullRequestsEmptyIssueID(t *testing.T) {
 		t.Errorf("expected (nil, nil) for empty issueID, got (%v, %v)", prs, err)
 	}
 }
+
+func TestGetDevelopmentAllTypes(t *testing.T) {
+	details := map[string]string{
+		"branch":                 `{"detail":[{"branches":[{"name":"feature/PROJ-1","url":"b","repository":{"name":"api"}}],"pullRequests":[{"id":"#9"}]}]}`,
+		"repository":             `{"detail":[{"repositories":[{"name":"api","commits":[{"id":"abcdef123456","displayId":"abcdef1","message":"PROJ-1 fix"}]}]}]}`,
+		"build":                  `{"detail":[{"builds":[{"displayName":"Pipeline","buildNumber":7,"state":"SUCCESSFUL"}]}]}`,
+		"deployment-environment": `{"detail":[{"deployments":[{"displayName":"Deploy 7","state":"SUCCESSFUL","environment":{"displayName":"Production","type":"production"}}]}]}`,
+	}
+	var detailCalls []string
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		switch r.URL.Path {
+		case "/rest/dev-status/latest/issue/summary":
+			_, _ = w.Write([]byte(`{"summary":{
+				"pullrequest":{"overall":{"count":0},"byInstanceType":{}},
+				"branch":{"overall":{"count":1},"byInstanceType":{"bitbucket":{"count":1}}},
+				"repository":{"overall":{"count":1},"byInstanceType":{"bitbucket":{"count":1}}},
+				"build":{"overall":{"count":1},"byInstanceType":{"cloud-providers":{"count":1}}},
+				"deployment-environment":{"overall":{"count":1},"byInstanceType":{"cloud-providers":{"count":1}}}
+			}}`))
+		case "/rest/dev-status/latest/issue/detail":
+			q := r.URL.Query()
+			detailCalls = append(detailCalls, q.Get("dataType")+"/"+q.Get("applicationType"))
+			_, _ = w.Write([]byte(details[q.Get("dataType")]))
+		default:
+			t.Errorf("unexpected path: %s", r.URL.Path)
+		}
+	}))
+	defer srv.Close()
+
+	client := NewClient(srv.URL, "test@example.com", "token123")
+	dev, err := client.GetDevelopment("10001", DevPullRequests, DevBranches, DevCommits, DevBuilds, DevDeployments)
+	if err != nil {
+		t.Fatalf("GetDevelopment: %v", err)
+	}
+	want := "branch/bitbucket,repository/bitbucket,build/cloud-providers,deployment-environment/cloud-providers"
+	if got := strings.Join(detailCalls, ","); got != want {
+		t.Errorf("detail calls = %s, want %s", got, want)
+	}
+	if len(dev.PullRequests) != 0 {
+		t.Errorf("PRs from the branch detail should be ignored, got %+v", dev.PullRequests)
+	}
+	if len(dev.Branches) != 1 || dev.Branches[0].Repository == nil || dev.Branches[0].Repository.Name != "api" {
+		t.Errorf("Branches = %+v", dev.Branches)
+	}
+	if len(dev.Repositories) != 1 || len(dev.Repositories[0].Commits) != 1 || dev.Repositories[0].Commits[0].DisplayID != "abcdef1" {
+		t.Errorf("Repositories = %+v", dev.Repositories)
+	}
+	if len(dev.Builds) != 1 || dev.Builds[0].BuildNumber != 7 {
+		t.Errorf("Builds = %+v", dev.Builds)
+	}
+	if len(dev.Deployments) != 1 || dev.Deployments[0].Environment.Type != "production" {
+		t.Errorf("Deployments = %+v", dev.Deployments)
+	}
+}
+
+func TestGetDevelopmentPartialFailure(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/rest/dev-status/latest/issue/summary":
+			_, _ = w.Write([]byte(`{"summary":{
+				"pullrequest":{"overall":{"count":1},"byInstanceType":{"bitbucket":{"count":1}}},
+				"build":{"overall":{"count":1},"byInstanceType":{"cloud-providers":{"count":1}}}
+			}}`))
+		case "/rest/dev-status/latest/issue/detail":
+			if r.URL.Query().Get("dataType") == DevBuilds {
+				w.WriteHeader(http.StatusInternalServerError)
+				return
+			}
+			_, _ = w.Write([]byte(`{"detail":[{"pullRequests":[{"id":"#9","status":"OPEN"}]}]}`))
+		}
+	}))
+	defer srv.Close()
+
+	client := NewClient(srv.URL, "test@example.com", "token123")
+	dev, err := client.GetDevelopment("10001", DevPullRequests, DevBuilds)
+	var partial *DevStatusError
+	if !errors.As(err, &partial) || partial.Failed[DevBuilds] == nil || partial.Failed[DevPullRequests] != nil {
+		t.Fatalf("err = %v, want a DevStatusError for builds only", err)
+	}
+	if dev == nil || len(dev.PullRequests) != 1 || dev.PullRequests[0].ID != "#9" {
+		t.Errorf("pull requests should survive a builds failure: %+v", dev)
+	}
+}
+
+func TestSearchIssuesLimitKeepsNextPageToken(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = w.Write([]byte(`{
+			"issues": [{"key": "PROJ-1", "fields": {"summary": "First"}}],
+			"nextPageToken": "page2token",
+			"isLast": false
+		}`))
+	}))
+	defer srv.Close()
+
+	client := NewClient(srv.URL, "test@example.com", "token123")
+	result, err := client.SearchIssues("project = PROJ", nil, 1)
+	if err != nil {
+		t.Fatalf("SearchIssues: %v", err)
+	}
+	if result.IsLast {
+		t.Error("IsLast = true, want false when the limit cut results short")
+	}
+	if result.NextPageToken != "page2token" {
+		t.Errorf("NextPageToken = %q, want page2token", result.NextPageToken)
+	}
+}
+
+func TestSearchIssuesPageSinglePage(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		q := r.URL.Query()
+		if got := q.Get("nextPageToken"); got != "tok" {
+			t.Errorf("nextPageToken = %q, want tok", got)
+		}
+		if got := q.Get("maxResults"); got != "25" {
+			t.Errorf("maxResults = %q, want 25", got)
+		}
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = w.Write([]byte(`{"issues": [{"key": "PROJ-9", "fields": {}}]}`))
+	}))
+	defer srv.Close()
+
+	client := NewClient(srv.URL, "test@example.com", "token123")
+	page, err := client.SearchIssuesPage("project = PROJ", nil, 25, "tok")
+	if err != nil {
+		t.Fatalf("SearchIssuesPage: %v", err)
+	}
+	if len(page.Issues) != 1 || page.Issues[0].Key != "PROJ-9" {
+		t.Fatalf("Issues = %+v, want [PROJ-9]", page.Issues)
+	}
+	if !page.IsLast {
+		t.Error("IsLast = false, want true without a next page token")
+	}
+}
+
+func TestCreateIssueSuccess(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodPost || r.URL.Path != "/rest/api/3/issue" {
+			t.Errorf("unexpected request: %s %s", r.Method, r.URL.Path)
+		}
+		var body struct {
+			Fields map[string]json.RawMessage `json:"fields"`
+		}
+		if err := json.NewDecoder(r.Body).Decode(&body); err != nil {
+			t.Fatalf("decoding body: %v", err)
+		}
+		for _, f := range []string{"project", "issuetype", "summary", "description", "labels", "assignee"} {
+			if _, ok := body.Fields[f]; !ok {
+				t.Errorf("missing field %q", f)
+			}
+		}
+		for _, f := range []string{"priority", "parent"} {
+			if _, ok := body.Fields[f]; ok {
+				t.Errorf("empty field %q should be omitted", f)
+			}
+		}
+		if got := string(body.Fields["project"]); got != `{"key":"PROJ"}` {
+			t.Errorf("project = %s", got)
+		}
+		w.WriteHeader(http.StatusCreated)
+		_, _ = w.Write([]byte(`{"id":"10042","key":"PROJ-42","self":"x"}`))
+	}))
+	defer srv.Close()
+
+	client := NewClient(srv.URL, "test@example.com", "token123")
+	created, err := client.CreateIssue(&NewIssue{
+		ProjectKey:  "PROJ",
+		IssueType:   "Story",
+		Summary:     "New thing",
+		Description: MarkdownToADF("Hello"),
+		AssigneeID:  "acc-1",
+		Labels:      []string{"web"},
+	})
+	if err != nil {
+		t.Fatalf("CreateIssue: %v", err)
+	}
+	if created.Key != "PROJ-42" || created.ID != "10042" {
+		t.Errorf("created = %+v", created)
+	}
+}
+
+func TestCreateIssueValidationError(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusBadRequest)
+		_, _ = w.Write([]byte(`{"errorMessages":[],"errors":{"priority":"Priority name 'Urgent' is not valid","issuetype":"Specify an issue type"}}`))
+	}))
+	defer srv.Close()
+
+	client := NewClient(srv.URL, "test@example.com", "token123")
+	_, err := client.CreateIssue(&NewIssue{ProjectKey: "PROJ", IssueType: "Nope", Summary: "x"})
+	var apiErr *APIError
+	if !errors.As(err, &apiErr) {
+		t.Fatalf("expected APIError, got %v", err)
+	}
+	want := "issuetype: Specify an issue type; priority: Priority name 'Urgent' is not valid"
+	if apiErr.Message != want {
+		t.Errorf("Message = %q, want %q", apiErr.Message, want)
+	}
+}
@@ -3,6 +3,8 @@ package jira
 import (
 	"errors"
 	"fmt"
+	"sort"
+	"strings"
 )
 
 // ErrUnauthorized indicates invalid or missing credentials.
@@ -20,3 +22,22 @@ type APIError struct {
 func (e *APIError) Error() string {
 	return fmt.Sprintf("jira API error (HTTP %d): %s", e.StatusCode, e.Message)
 }
+
+// DevStatusError reports the dev-status data types GetDevelopment could not
+// fetch, by data type. The other types were fetched and are returned with it.
+type DevStatusError struct {
+	Failed map[string]error
+}
+
+func (e *DevStatusError) Error() string {
+	types := make([]string, 0, len(e.Failed))
+	for t := range e.Failed {
+		types = append(types, t)
+	}
+	sort.Strings(types)
+	parts := make([]string, 0, len(types))
+	for _, t := range types {
+		parts = append(parts, fmt.Sprintf("%s: %v", t, e.Failed[t]))
+	}
+	return "dev-status " + strings.Join(parts, "; ")
+}
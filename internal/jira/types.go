@@ -20,9 +20,15 @@ type Issue struct {
 	Fields IssueFields `json:"fields"`
 	Sprint *Sprint     `json:"-"`
 	Epic   *Epic       `json:"-"`
+	// StoryPoints is the estimate from the "Story Points" (or team-managed
+	// "Story point estimate") custom field; nil when unset or not configured.
+	StoryPoints *float64 `json:"-"`
 	// PullRequests holds development-panel PRs linked to the issue. Populated
 	// separately via GetPullRequests (not part of the issue REST payload).
 	PullRequests []PullRequest `json:"-"`
+	// Development holds the other development-panel data (branches, commits,
+	// builds, deployments). Populated separately via GetDevelopment.
+	Development *Development `json:"-"`
 }
 
 // IssueRaw is an intermediate type for two-pass JSON decoding.
@@ -37,21 +43,23 @@ type IssueRaw struct {
 
 // IssueFields holds the standard fields of a Jira issue.
 type IssueFields struct {
-	Summary     string       `json:"summary"`
-	Description *ADFDoc      `json:"description"`
-	Status      *Status      `json:"status"`
-	IssueType   *IssueType   `json:"issuetype"`
-	Priority    *Priority    `json:"priority"`
-	Assignee    *User        `json:"assignee"`
-	Reporter    *User        `json:"reporter"`
-	Labels      []string     `json:"labels"`
-	Created     string       `json:"created"`
-	Updated     string       `json:"updated"`
-	Comment     *CommentPage `json:"comment"`
-	Subtasks    []Subtask    `json:"subtasks"`
-	IssueLinks  []IssueLink  `json:"issuelinks"`
-	Parent      *ParentIssue `json:"parent"`
-	Attachment  []Attachment `json:"attachment"`
+	Summary      string        `json:"summary"`
+	Description  *ADFDoc       `json:"description"`
+	Status       *Status       `json:"status"`
+	IssueType    *IssueType    `json:"issuetype"`
+	Priority     *Priority     `json:"priority"`
+	Assignee     *User         `json:"assignee"`
+	Reporter     *User         `json:"reporter"`
+	Labels       []string      `json:"labels"`
+	Created      string        `json:"created"`
+	Updated      string        `json:"updated"`
+	Comment      *CommentPage  `json:"comment"`
+	Subtasks     []Subtask     `json:"subtasks"`
+	IssueLinks   []IssueLink   `json:"issuelinks"`
+	Parent       *ParentIssue  `json:"parent"`
+	Attachment   []Attachment  `json:"attachment"`
+	TimeTracking *TimeTracking `json:"timetracking"`
+	Worklog      *WorklogPage  `json:"worklog"`
 }
 
 // Attachment is a file attached to a Jira issue. Content is an authenticated
@@ -67,6 +75,14 @@ type Attachment struct {
 
 // Status represents the issue status.
 type Status struct {
+	Name           string          `json:"name"`
+	StatusCategory *StatusCategory `json:"statusCategory,omitempty"`
+}
+
+// StatusCategory is the coarse workflow bucket a status belongs to. Key is one
+// of "new" (To Do), "indeterminate" (In Progress) or "done".
+type StatusCategory struct {
+	Key  string `json:"key"`
 	Name string `json:"name"`
 }
 
@@ -86,6 +102,32 @@ type CommentPage struct {
 	Comments []Comment `json:"comments"`
 }
 
+// TimeTracking holds an issue's estimates and logged time. The string values
+// are Jira-formatted durations ("1d 2h"); empty when unset.
+type TimeTracking struct {
+	OriginalEstimate  string `json:"originalEstimate"`
+	RemainingEstimate string `json:"remainingEstimate"`
+	TimeSpent         string `json:"timeSpent"`
+	TimeSpentSeconds  int    `json:"timeSpentSeconds"`
+}
+
+// WorklogPage holds the worklogs embedded in the issue response (Jira includes
+// at most 20; Total tells whether more must be fetched with GetWorklogs).
+type WorklogPage struct {
+	Total    int       `json:"total"`
+	Worklogs []Worklog `json:"worklogs"`
+}
+
+// Worklog is a single time entry on an issue.
+type Worklog struct {
+	ID               string  `json:"id"`
+	Author           *User   `json:"author"`
+	Comment          *ADFDoc `json:"comment"`
+	Started          string  `json:"started"`
+	TimeSpent        string  `json:"timeSpent"`
+	TimeSpentSeconds int     `json:"timeSpentSeconds"`
+}
+
 // Comment is a single issue comment.
 type Comment struct {
 	Author  *User   `json:"author"`
@@ -139,13 +181,22 @@ type ParentIssue struct {
 
 // ParentIssueFields holds the fields for a parent issue.
 type ParentIssueFields struct {
-	Summary string  `json:"summary"`
-	Status  *Status `json:"status"`
+	Summary   string     `json:"summary"`
+	Status    *Status    `json:"status"`
+	IssueType *IssueType `json:"issuetype"`
 }
 
-// Sprint represents a Jira sprint (extracted from custom fields).
+// Sprint represents a Jira sprint, either extracted from the sprint custom
+// field on an issue or returned by the Agile API. Both payloads share this
+// shape; dates are ISO 8601 and empty for future sprints.
 type Sprint struct {
-	Name string `json:"name"`
+	ID        int    `json:"id"`
+	Name      string `json:"name"`
+	State     string `json:"state"` // active | closed | future
+	Goal      string `json:"goal"`
+	StartDate string `json:"startDate"`
+	EndDate   string `json:"endDate"`
+	BoardID   int    `json:"originBoardId"`
 }
 
 // Epic represents a Jira epic (extracted from custom fields).
@@ -154,6 +205,72 @@ type Epic struct {
 	Summary string `json:"summary"`
 }
 
+// Board is a Jira Software (Agile) board.
+type Board struct {
+	ID       int    `json:"id"`
+	Name     string `json:"name"`
+	Type     string `json:"type"` // scrum | kanban | simple
+	Location struct {
+		ProjectKey  string `json:"projectKey"`
+		DisplayName string `json:"displayName"`
+	} `json:"location"`
+}
+
+// BoardColumn is one column of a board's configuration, with the ids of the
+// workflow statuses mapped to it (in board order).
+type BoardColumn struct {
+	Name     string              `json:"name"`
+	Statuses []BoardColumnStatus `json:"statuses"`
+}
+
+// BoardColumnStatus references a workflow status by id.
+type BoardColumnStatus struct {
+	ID string `json:"id"`
+}
+
+// boardConfiguration is the response from /rest/agile/1.0/board/{id}/configuration.
+type boardConfiguration struct {
+	ColumnConfig struct {
+		Columns []BoardColumn `json:"columns"`
+	} `json:"columnConfig"`
+}
+
+// StatusInfo is a workflow status as listed by /rest/api/3/status, used to
+// resolve the bare status ids in a board configuration to names.
+type StatusInfo struct {
+	ID             string          `json:"id"`
+	Name           string          `json:"name"`
+	StatusCategory *StatusCategory `json:"statusCategory"`
+}
+
+// Field is a Jira field definition from /rest/api/3/field. The id/name pairs
+// double as a "names" map for endpoints that do not support expand=names.
+type Field struct {
+	ID     string `json:"id"`
+	Name   string `json:"name"`
+	Custom bool   `json:"custom"`
+}
+
+// NewIssue holds the fields used to create an issue. Empty optional fields are
+// left out of the request so Jira applies the project defaults.
+type NewIssue struct {
+	ProjectKey  string
+	IssueType   string
+	Summary     string
+	Description *ADFDoc
+	Priority    string
+	AssigneeID  string // accountId
+	Labels      []string
+	ParentKey   string
+}
+
+// CreatedIssue is the response from POST /rest/api/3/issue.
+type CreatedIssue struct {
+	ID   string `json:"id"`
+	Key  string `json:"key"`
+	Self string `json:"self"`
+}
+
 // SearchResult holds the response from a JQL search via /rest/api/3/search/jql.
 type SearchResult struct {
 	Issues        []Issue `json:"issues"`
@@ -177,6 +294,9 @@ type PullRequest struct {
 	// AppType is the source application (e.g. "bitbucket", "github"). Set by
 	// the client from the dev-status query, not present in the PR JSON itself.
 	AppType string `json:"-"`
+	// LocalPath is the PR's file under prs_dir when `atlit pull --with-prs`
+	// fetched it; set by the caller.
+	LocalPath string `json:"-"`
 }
 
 // DevUser is an author or reviewer in the dev-status payload.
@@ -192,27 +312,93 @@ type DevBranch struct {
 	Branch string `json:"branch"`
 }
 
+// Development is the development-panel data linked to an issue, as returned
+// by GetDevelopment. Only the requested data types are populated.
+type Development struct {
+	PullRequests []PullRequest
+	Branches     []Branch
+	Repositories []Repository // commits, grouped by repository
+	Builds       []Build
+	Deployments  []Deployment
+}
+
+// DevRepository identifies the repository a branch lives in.
+type DevRepository struct {
+	Name string `json:"name"`
+	URL  string `json:"url"`
+}
+
+// Branch is a development-panel branch whose name references the issue.
+type Branch struct {
+	Name       string         `json:"name"`
+	URL        string         `json:"url"`
+	Repository *DevRepository `json:"repository"`
+}
+
+// Repository lists the commits referencing the issue in one repository.
+type Repository struct {
+	Name    string   `json:"name"`
+	URL     string   `json:"url"`
+	Commits []Commit `json:"commits"`
+}
+
+// Commit is a development-panel commit referencing the issue.
+type Commit struct {
+	ID              string   `json:"id"`
+	DisplayID       string   `json:"displayId"` // abbreviated hash
+	Message         string   `json:"message"`
+	Author          *DevUser `json:"author"`
+	AuthorTimestamp string   `json:"authorTimestamp"`
+	URL             string   `json:"url"`
+}
+
+// Build is a CI build linked to the issue.
+type Build struct {
+	Name        string `json:"name"`
+	DisplayName string `json:"displayName"`
+	URL         string `json:"url"`
+	State       string `json:"state"` // SUCCESSFUL | FAILED | IN_PROGRESS | STOPPED | ...
+	BuildNumber int    `json:"buildNumber"`
+	LastUpdated string `json:"lastUpdated"`
+}
+
+// Deployment is a deployment of a change linked to the issue.
+type Deployment struct {
+	DisplayName string `json:"displayName"`
+	URL         string `json:"url"`
+	State       string `json:"state"` // SUCCESSFUL | FAILED | IN_PROGRESS | ...
+	LastUpdated string `json:"lastUpdated"`
+	Environment struct {
+		DisplayName string `json:"displayName"`
+		Type        string `json:"type"` // production | staging | testing | development | unmapped
+	} `json:"environment"`
+}
+
 // devStatusSummary is the response from /rest/dev-status/latest/issue/summary.
-// It tells us how many PRs exist and which application types host them, so we
-// can avoid hardcoding a provider and skip the detail call when there are none.
+// It tells us how many entries of each data type exist and which application
+// types host them, so we can avoid hardcoding a provider and skip the detail
+// call when there are none. Keys are the Dev* data type constants.
 type devStatusSummary struct {
-	Summary struct {
-		PullRequest struct {
-			Overall struct {
-				Count int `json:"count"`
-			} `json:"overall"`
-			ByInstanceType map[string]struct {
-				Count int    `json:"count"`
-				Name  string `json:"name"`
-			} `json:"byInstanceType"`
-		} `json:"pullrequest"`
+	Summary map[string]struct {
+		Overall struct {
+			Count int `json:"count"`
+		} `json:"overall"`
+		ByInstanceType map[string]struct {
+			Count int    `json:"count"`
+			Name  string `json:"name"`
+		} `json:"byInstanceType"`
 	} `json:"summary"`
 }
 
 // devStatusDetail is the response from /rest/dev-status/latest/issue/detail.
+// Only the array matching the requested dataType is populated.
 type devStatusDetail struct {
 	Detail []struct {
 		PullRequests []PullRequest `json:"pullRequests"`
+		Branches     []Branch      `json:"branches"`
+		Repositories []Repository  `json:"repositories"`
+		Builds       []Build       `json:"builds"`
+		Deployments  []Deployment  `json:"deployments"`
 	} `json:"detail"`
 }
 
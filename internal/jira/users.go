@@ -0,0 +1,56 @@
+package jira
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/url"
+)
+
+// NameResolver maps Atlassian account ids to display names. Account ids are
+// shared across Jira and Confluence on a site, so one resolver serves both.
+type NameResolver interface {
+	// DisplayName returns the account's display name, or "" when unknown.
+	DisplayName(accountID string) string
+}
+
+// GetUser fetches a user by account id via GET /rest/api/3/user.
+func (c *Client) GetUser(accountID string) (*User, error) {
+	data, err := c.getJSON("/rest/api/3/user?accountId=" + url.QueryEscape(accountID))
+	if err != nil {
+		return nil, err
+	}
+	var user User
+	if err := json.Unmarshal(data, &user); err != nil {
+		return nil, fmt.Errorf("decoding user: %w", err)
+	}
+	return &user, nil
+}
+
+// UserCache is a NameResolver that looks account ids up through the Jira user
+// API once each. Failed lookups are cached as unknown, so a deleted or hidden
+// account costs one request, not one per mention.
+type UserCache struct {
+	client *Client
+	names  map[string]string
+}
+
+// NewUserCache creates an empty cache over client.
+func NewUserCache(client *Client) *UserCache {
+	return &UserCache{client: client, names: map[string]string{}}
+}
+
+// DisplayName implements NameResolver.
+func (u *UserCache) DisplayName(accountID string) string {
+	if accountID == "" {
+		return ""
+	}
+	if name, ok := u.names[accountID]; ok {
+		return name
+	}
+	name := ""
+	if user, err := u.client.GetUser(accountID); err == nil {
+		name = user.DisplayName
+	}
+	u.names[accountID] = name
+	return name
+}
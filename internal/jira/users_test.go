@@ -0,0 +1,40 @@
+package jira
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func TestUserCache(t *testing.T) {
+	requests := map[string]int{}
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/rest/api/3/user" {
+			t.Errorf("unexpected path: %s", r.URL.Path)
+		}
+		id := r.URL.Query().Get("accountId")
+		requests[id]++
+		if id == "acc-1" {
+			_, _ = w.Write([]byte(`{"accountId":"acc-1","displayName":"Alice Smith"}`))
+			return
+		}
+		w.WriteHeader(http.StatusNotFound)
+	}))
+	defer srv.Close()
+
+	names := NewUserCache(NewClient(srv.URL, "test@example.com", "token123"))
+	for i := 0; i < 2; i++ {
+		if got := names.DisplayName("acc-1"); got != "Alice Smith" {
+			t.Errorf("DisplayName(acc-1) = %q, want Alice Smith", got)
+		}
+		if got := names.DisplayName("gone"); got != "" {
+			t.Errorf("DisplayName(gone) = %q, want empty", got)
+		}
+	}
+	if got := names.DisplayName(""); got != "" {
+		t.Errorf("DisplayName(\"\") = %q, want empty", got)
+	}
+	if requests["acc-1"] != 1 || requests["gone"] != 1 || len(requests) != 2 {
+		t.Errorf("requests = %v, want one per account", requests)
+	}
+}
@@ -0,0 +1,87 @@
+package jira
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// worklogTimeLayout is the timestamp format Jira expects for a worklog's
+// "started" value (a colon-less zone offset, like the timestamps it emits).
+const worklogTimeLayout = "2006-01-02T15:04:05.000-0700"
+
+// worklogPageSize is the page size requested when listing worklogs.
+const worklogPageSize = 100
+
+// GetWorklogs returns every worklog on an issue, oldest first, following
+// startAt pagination. The issue payload embeds only the first 20.
+func (c *Client) GetWorklogs(key string) ([]Worklog, error) {
+	var all []Worklog
+	for startAt := 0; ; {
+		params := url.Values{}
+		params.Set("startAt", strconv.Itoa(startAt))
+		params.Set("maxResults", strconv.Itoa(worklogPageSize))
+		data, err := c.getJSON("/rest/api/3/issue/" + key + "/worklog?" + params.Encode())
+		if err != nil {
+			return nil, err
+		}
+		var page struct {
+			WorklogPage
+			StartAt int `json:"startAt"`
+		}
+		if err := json.Unmarshal(data, &page); err != nil {
+			return nil, fmt.Errorf("decoding worklogs: %w", err)
+		}
+		all = append(all, page.Worklogs...)
+		if len(page.Worklogs) == 0 || len(all) >= page.Total {
+			return all, nil
+		}
+		startAt = len(all)
+	}
+}
+
+// AddWorklog logs seconds of work on an issue using
+// POST /rest/api/3/issue/{key}/worklog. started is when the work began; an
+// empty comment is omitted. Jira requires at least 60 seconds.
+func (c *Client) AddWorklog(key string, started time.Time, seconds int, comment string) (*Worklog, error) {
+	payload := map[string]any{
+		"started":          started.Format(worklogTimeLayout),
+		"timeSpentSeconds": seconds,
+	}
+	if comment != "" {
+		payload["comment"] = MarkdownToADF(comment)
+	}
+	data, err := json.Marshal(payload)
+	if err != nil {
+		return nil, fmt.Errorf("marshaling worklog payload: %w", err)
+	}
+
+	resp, err := c.do(http.MethodPost, "/rest/api/3/issue/"+key+"/worklog", strings.NewReader(string(data)))
+	if err != nil {
+		return nil, err
+	}
+	body, statusCode, err := readAndClose(resp)
+	if err != nil {
+		return nil, err
+	}
+
+	switch statusCode {
+	case http.StatusCreated, http.StatusOK:
+	case http.StatusUnauthorized, http.StatusForbidden:
+		return nil, ErrUnauthorized
+	case http.StatusNotFound:
+		return nil, ErrNotFound
+	default:
+		return nil, &APIError{StatusCode: statusCode, Message: createErrorMessage(body)}
+	}
+
+	var wl Worklog
+	if err := json.Unmarshal(body, &wl); err != nil {
+		return nil, fmt.Errorf("decoding worklog response: %w", err)
+	}
+	return &wl, nil
+}
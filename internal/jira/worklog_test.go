@@ -0,0 +1,86 @@
+package jira
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+)
+
+func TestGetWorklogsPagination(t *testing.T) {
+	calls := 0
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		calls++
+		if r.URL.Path != "/rest/api/3/issue/PROJ-1/worklog" {
+			t.Errorf("unexpected path: %s", r.URL.Path)
+		}
+		start := r.URL.Query().Get("startAt")
+		w.Header().Set("Content-Type", "application/json")
+		switch start {
+		case "0":
+			fmt.Fprint(w, `{"startAt":0,"total":3,"worklogs":[{"id":"1","timeSpent":"1h"},{"id":"2","timeSpent":"2h"}]}`)
+		case "2":
+			fmt.Fprint(w, `{"startAt":2,"total":3,"worklogs":[{"id":"3","timeSpent":"30m"}]}`)
+		default:
+			t.Errorf("unexpected startAt %q", start)
+		}
+	}))
+	defer srv.Close()
+
+	client := NewClient(srv.URL, "test@example.com", "token123")
+	wls, err := client.GetWorklogs("PROJ-1")
+	if err != nil {
+		t.Fatalf("GetWorklogs: %v", err)
+	}
+	if len(wls) != 3 || wls[2].ID != "3" || calls != 2 {
+		t.Errorf("worklogs = %+v after %d calls", wls, calls)
+	}
+}
+
+func TestAddWorklog(t *testing.T) {
+	started := time.Date(2026, 3, 2, 14, 0, 0, 0, time.FixedZone("", 7*3600))
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodPost || r.URL.Path != "/rest/api/3/issue/PROJ-1/worklog" {
+			t.Errorf("unexpected request: %s %s", r.Method, r.URL.Path)
+		}
+		var body map[string]json.RawMessage
+		if err := json.NewDecoder(r.Body).Decode(&body); err != nil {
+			t.Fatalf("decoding body: %v", err)
+		}
+		if got := string(body["started"]); got != `"2026-03-02T14:00:00.000+0700"` {
+			t.Errorf("started = %s", got)
+		}
+		if got := string(body["timeSpentSeconds"]); got != "5400" {
+			t.Errorf("timeSpentSeconds = %s", got)
+		}
+		if _, ok := body["comment"]; !ok {
+			t.Error("comment missing")
+		}
+		w.WriteHeader(http.StatusCreated)
+		fmt.Fprint(w, `{"id":"77","timeSpent":"1h 30m","timeSpentSeconds":5400}`)
+	}))
+	defer srv.Close()
+
+	client := NewClient(srv.URL, "test@example.com", "token123")
+	wl, err := client.AddWorklog("PROJ-1", started, 5400, "pairing")
+	if err != nil {
+		t.Fatalf("AddWorklog: %v", err)
+	}
+	if wl.ID != "77" || wl.TimeSpentSeconds != 5400 {
+		t.Errorf("worklog = %+v", wl)
+	}
+}
+
+func TestAddWorklogNotFound(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusNotFound)
+	}))
+	defer srv.Close()
+
+	client := NewClient(srv.URL, "test@example.com", "token123")
+	if _, err := client.AddWorklog("NOPE-1", time.Now(), 60, ""); err != ErrNotFound {
+		t.Errorf("err = %v, want ErrNotFound", err)
+	}
+}
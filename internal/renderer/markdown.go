@@ -2,6 +2,7 @@ package renderer
 
 import (
 	"fmt"
+	"strconv"
 	"strings"
 	"time"
 
@@ -31,6 +32,9 @@ func RenderIssue(issue *jira.Issue) string {
 	if issue.Sprint != nil && issue.Sprint.Name != "" {
 		writeRow(&b, "Sprint", issue.Sprint.Name)
 	}
+	if issue.StoryPoints != nil {
+		writeRow(&b, "Story Points", FormatPoints(*issue.StoryPoints))
+	}
 	if issue.Epic != nil {
 		epicVal := issue.Epic.Key
 		if issue.Epic.Summary != "" {
@@ -48,6 +52,17 @@ func RenderIssue(issue *jira.Issue) string {
 	if len(issue.Fields.Labels) > 0 {
 		writeRow(&b, "Labels", strings.Join(issue.Fields.Labels, ", "))
 	}
+	if tt := issue.Fields.TimeTracking; tt != nil {
+		if tt.OriginalEstimate != "" {
+			writeRow(&b, "Original Estimate", tt.OriginalEstimate)
+		}
+		if tt.RemainingEstimate != "" {
+			writeRow(&b, "Remaining Estimate", tt.RemainingEstimate)
+		}
+		if tt.TimeSpent != "" {
+			writeRow(&b, "Time Spent", tt.TimeSpent)
+		}
+	}
 	writeRow(&b, "Created", formatDate(issue.Fields.Created))
 	writeRow(&b, "Updated", formatDate(issue.Fields.Updated))
 	b.WriteString("\n")
@@ -116,11 +131,22 @@ func RenderIssue(issue *jira.Issue) string {
 		b.WriteString("\n")
 	}
 
+	// Work log. Jira embeds at most 20 entries; callers wanting the full log
+	// replace Worklog with the result of GetWorklogs before rendering.
+	if issue.Fields.Worklog != nil && len(issue.Fields.Worklog.Worklogs) > 0 {
+		writeWorklog(&b, issue.Fields.Worklog)
+	}
+
 	// Pull Requests (development panel). Linked via the dev-status API.
 	if len(issue.PullRequests) > 0 {
 		writePullRequests(&b, issue.PullRequests)
 	}
 
+	// Development (branches, commits, builds, deployments), also dev-status.
+	if issue.Development != nil {
+		writeDevelopment(&b, issue.Development)
+	}
+
 	// Comments.
 	if issue.Fields.Comment != nil && issue.Fields.Comment.Total > 0 {
 		fmt.Fprintf(&b, "## Comments (%d)\n\n", issue.Fields.Comment.Total)
@@ -170,6 +196,14 @@ func RenderComments(issue *jira.Issue) string {
 	return strings.TrimRight(b.String(), "\n") + "\n"
 }
 
+// RenderPullRequests renders just the "## Pull Requests" section, for
+// replacing it in an already rendered ticket.
+func RenderPullRequests(prs []jira.PullRequest) string {
+	var b strings.Builder
+	writePullRequests(&b, prs)
+	return strings.TrimRight(b.String(), "\n") + "\n"
+}
+
 // writePullRequests renders the "## Pull Requests" section: one bullet per PR
 // with its status, title and link, plus branch and author detail when present.
 func writePullRequests(b *strings.Builder, prs []jira.PullRequest) {
@@ -206,10 +240,156 @@ func writePullRequests(b *strings.Builder, prs []jira.PullRequest) {
 		if names := approvedReviewers(pr.Reviewers); names != "" {
 			fmt.Fprintf(b, "  - Approved by: %s\n", names)
 		}
+		if pr.LocalPath != "" {
+			fmt.Fprintf(b, "  - Local file: %s\n", pr.LocalPath)
+		}
+	}
+	b.WriteString("\n")
+}
+
+// writeWorklog renders the "## Work Log" section: one bullet per entry with
+// its date, author, duration and (single-line) comment.
+func writeWorklog(b *strings.Builder, page *jira.WorklogPage) {
+	total := page.Total
+	if total < len(page.Worklogs) {
+		total = len(page.Worklogs)
+	}
+	fmt.Fprintf(b, "## Work Log (%d)\n\n", total)
+	for _, wl := range page.Worklogs {
+		author := "Unknown"
+		if wl.Author != nil && wl.Author.DisplayName != "" {
+			author = wl.Author.DisplayName
+		}
+		fmt.Fprintf(b, "- %s -- %s -- %s", formatDate(wl.Started), author, wl.TimeSpent)
+		if wl.Comment != nil {
+			if c := strings.Join(strings.Fields(jira.RenderADF(wl.Comment)), " "); c != "" {
+				b.WriteString(": " + c)
+			}
+		}
+		b.WriteString("\n")
+	}
+	if len(page.Worklogs) < page.Total {
+		fmt.Fprintf(b, "- ... %d more not shown\n", page.Total-len(page.Worklogs))
 	}
 	b.WriteString("\n")
 }
 
+// writeDevelopment renders the "## Development" section with one subsection
+// per non-empty data type. Nothing is written when every type is empty.
+func writeDevelopment(b *strings.Builder, dev *jira.Development) {
+	commits := 0
+	for _, r := range dev.Repositories {
+		commits += len(r.Commits)
+	}
+	if len(dev.Branches) == 0 && commits == 0 && len(dev.Builds) == 0 && len(dev.Deployments) == 0 {
+		return
+	}
+	b.WriteString("## Development\n\n")
+
+	if len(dev.Branches) > 0 {
+		fmt.Fprintf(b, "### Branches (%d)\n\n", len(dev.Branches))
+		for _, br := range dev.Branches {
+			line := "- " + br.Name
+			if br.Repository != nil && br.Repository.Name != "" {
+				line += " (" + br.Repository.Name + ")"
+			}
+			if br.URL != "" {
+				line += " - " + br.URL
+			}
+			b.WriteString(line + "\n")
+		}
+		b.WriteString("\n")
+	}
+
+	if commits > 0 {
+		fmt.Fprintf(b, "### Commits (%d)\n\n", commits)
+		for _, r := range dev.Repositories {
+			for _, c := range r.Commits {
+				id := c.DisplayID
+				if id == "" && len(c.ID) > 7 {
+					id = c.ID[:7]
+				}
+				// Only the subject line; full messages belong in the repo.
+				subject, _, _ := strings.Cut(strings.TrimSpace(c.Message), "\n")
+				line := fmt.Sprintf("- `%s` %s", id, subject)
+				var meta []string
+				if c.Author != nil && c.Author.Name != "" {
+					meta = append(meta, c.Author.Name)
+				}
+				if c.AuthorTimestamp != "" {
+					meta = append(meta, formatDate(c.AuthorTimestamp))
+				}
+				if r.Name != "" {
+					meta = append(meta, r.Name)
+				}
+				if len(meta) > 0 {
+					line += " (" + strings.Join(meta, ", ") + ")"
+				}
+				if c.URL != "" {
+					line += " - " + c.URL
+				}
+				b.WriteString(line + "\n")
+			}
+		}
+		b.WriteString("\n")
+	}
+
+	if len(dev.Builds) > 0 {
+		fmt.Fprintf(b, "### Builds (%d)\n\n", len(dev.Builds))
+		for _, bd := range dev.Builds {
+			name := bd.DisplayName
+			if name == "" {
+				name = bd.Name
+			}
+			if bd.BuildNumber > 0 {
+				name += fmt.Sprintf(" #%d", bd.BuildNumber)
+			}
+			line := fmt.Sprintf("- [%s] %s", devState(bd.State), strings.TrimSpace(name))
+			if bd.LastUpdated != "" {
+				line += " (" + formatDate(bd.LastUpdated) + ")"
+			}
+			if bd.URL != "" {
+				line += " - " + bd.URL
+			}
+			b.WriteString(line + "\n")
+		}
+		b.WriteString("\n")
+	}
+
+	if len(dev.Deployments) > 0 {
+		fmt.Fprintf(b, "### Deployments (%d)\n\n", len(dev.Deployments))
+		for _, d := range dev.Deployments {
+			env := d.Environment.DisplayName
+			if env == "" {
+				env = d.DisplayName
+			}
+			line := fmt.Sprintf("- [%s] %s", devState(d.State), env)
+			if d.Environment.Type != "" && !strings.EqualFold(d.Environment.Type, "unmapped") {
+				line += " (" + d.Environment.Type + ")"
+			}
+			if d.DisplayName != "" && d.DisplayName != env {
+				line += ": " + d.DisplayName
+			}
+			if d.LastUpdated != "" {
+				line += ", " + formatDate(d.LastUpdated)
+			}
+			if d.URL != "" {
+				line += " - " + d.URL
+			}
+			b.WriteString(line + "\n")
+		}
+		b.WriteString("\n")
+	}
+}
+
+// devState upper-cases a build/deployment state, "UNKNOWN" when empty.
+func devState(s string) string {
+	if s == "" {
+		return "UNKNOWN"
+	}
+	return strings.ToUpper(s)
+}
+
 // approvedReviewers returns a comma-joined list of reviewers that approved.
 func approvedReviewers(reviewers []jira.DevUser) string {
 	var approved []string
@@ -235,6 +415,12 @@ func writeAttachment(b *strings.Builder, name, mime, url string) {
 	b.WriteString(line + "\n")
 }
 
+// FormatPoints renders a story-point estimate without a trailing ".0" for
+// whole numbers (3 -> "3", 0.5 -> "0.5").
+func FormatPoints(p float64) string {
+	return strconv.FormatFloat(p, 'f', -1, 64)
+}
+
 func writeRow(b *strings.Builder, field, value string) {
 	if value == "" {
 		value = "-"
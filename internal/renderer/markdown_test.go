@@ -213,6 +213,19 @@ func TestRenderIssuePullRequests(t *testing.T) {
 	}
 }
 
+func TestRenderPullRequestsLocalFile(t *testing.T) {
+	got := RenderPullRequests([]jira.PullRequest{
+		{ID: "#42", Name: "Add feature", Status: "OPEN", LocalPath: "/prs/x__repo__42.md"},
+		{ID: "#7", Name: "Other host", Status: "MERGED"},
+	})
+	want := "## Pull Requests (2)\n\n" +
+		"- [OPEN] Add feature (#42)\n  - Local file: /prs/x__repo__42.md\n" +
+		"- [MERGED] Other host (#7)\n"
+	if got != want {
+		t.Errorf("RenderPullRequests =\n%q\nwant\n%q", got, want)
+	}
+}
+
 func TestRenderIssueNoPullRequestsSection(t *testing.T) {
 	issue := &jira.Issue{Key: "PROJ-202", Fields: jira.IssueFields{Summary: "No PRs"}}
 	if strings.Contains(RenderIssue(issue), "## Pull Requests") {
@@ -299,6 +312,95 @@ func TestRenderIssueSprintAndEpic(t *testing.T) {
 	}
 }
 
+func TestRenderIssueStoryPoints(t *testing.T) {
+	points := 2.5
+	issue := &jira.Issue{
+		Key:         "TEST-13",
+		Fields:      jira.IssueFields{Summary: "Estimated"},
+		StoryPoints: &points,
+	}
+	if got := RenderIssue(issue); !strings.Contains(got, "| Story Points | 2.5 |") {
+		t.Errorf("expected story points row, got:\n%s", got)
+	}
+
+	issue.StoryPoints = nil
+	if got := RenderIssue(issue); strings.Contains(got, "Story Points") {
+		t.Errorf("expected no story points row when unset, got:\n%s", got)
+	}
+}
+
+func TestRenderIssueTimeTrackingAndWorkLog(t *testing.T) {
+	issue := &jira.Issue{
+		Key: "TEST-14",
+		Fields: jira.IssueFields{
+			Summary:      "Tracked",
+			TimeTracking: &jira.TimeTracking{OriginalEstimate: "1d", TimeSpent: "3h 30m"},
+			Worklog: &jira.WorklogPage{
+				Total: 2,
+				Worklogs: []jira.Worklog{
+					{
+						Author:    &jira.User{DisplayName: "Alice"},
+						Started:   "2026-02-10T09:00:00.000+0000",
+						TimeSpent: "1h 30m",
+						Comment:   jira.MarkdownToADF("pairing\non the importer"),
+					},
+					{Started: "2026-02-11T09:00:00.000+0000", TimeSpent: "2h"},
+				},
+			},
+		},
+	}
+	got := RenderIssue(issue)
+	for _, want := range []string{
+		"| Original Estimate | 1d |\n",
+		"| Time Spent | 3h 30m |\n",
+		"## Work Log (2)\n\n- 2026-02-10 -- Alice -- 1h 30m: pairing on the importer\n- 2026-02-11 -- Unknown -- 2h\n",
+	} {
+		if !strings.Contains(got, want) {
+			t.Errorf("missing %q in:\n%s", want, got)
+		}
+	}
+	if strings.Contains(got, "Remaining Estimate") {
+		t.Errorf("unset estimate should not render a row:\n%s", got)
+	}
+
+	issue.Fields.Worklog.Total = 5
+	if got := RenderIssue(issue); !strings.Contains(got, "- ... 3 more not shown\n") {
+		t.Errorf("expected truncation note, got:\n%s", got)
+	}
+}
+
+func TestRenderIssueDevelopment(t *testing.T) {
+	dev := &jira.Development{
+		Branches: []jira.Branch{{Name: "feature/TEST-15", URL: "https://b", Repository: &jira.DevRepository{Name: "api"}}},
+		Repositories: []jira.Repository{{Name: "api", Commits: []jira.Commit{{
+			DisplayID:       "abcdef1",
+			Message:         "TEST-15 fix parser\n\nlong body",
+			Author:          &jira.DevUser{Name: "Alice"},
+			AuthorTimestamp: "2026-02-10T09:00:00.000+0000",
+		}}}},
+		Builds:      []jira.Build{{DisplayName: "Pipeline", BuildNumber: 7, State: "successful", URL: "https://ci"}},
+		Deployments: []jira.Deployment{{DisplayName: "Deploy 7", State: "FAILED"}},
+	}
+	dev.Deployments[0].Environment.DisplayName = "Production"
+	dev.Deployments[0].Environment.Type = "production"
+
+	issue := &jira.Issue{Key: "TEST-15", Fields: jira.IssueFields{Summary: "Dev"}, Development: dev}
+	got := RenderIssue(issue)
+	want := "## Development\n\n" +
+		"### Branches (1)\n\n- feature/TEST-15 (api) - https://b\n\n" +
+		"### Commits (1)\n\n- `abcdef1` TEST-15 fix parser (Alice, 2026-02-10, api)\n\n" +
+		"### Builds (1)\n\n- [SUCCESSFUL] Pipeline #7 - https://ci\n\n" +
+		"### Deployments (1)\n\n- [FAILED] Production (production): Deploy 7\n"
+	if !strings.Contains(got, want) {
+		t.Errorf("missing Development section:\n%s", got)
+	}
+
+	issue.Development = &jira.Development{}
+	if got := RenderIssue(issue); strings.Contains(got, "## Development") {
+		t.Errorf("empty development should render nothing:\n%s", got)
+	}
+}
+
 func TestRenderCommentsWithComments(t *testing.T) {
 	issue := &jira.Issue{
 		Key: "TEST-12",
@@ -11,37 +11,66 @@ import (
 	"github.com/erickhilda/atlit/internal/jira"
 )
 
+// PageContext is what RenderPage shows beyond the page object itself, all
+// resolved by the caller: the v2 API returns a numeric space id, relative
+// links and raw account ids. Every field is optional; empty rows render as
+// "-".
+type PageContext struct {
+	SpaceKey  string
+	SpaceName string
+	WebURL    string
+
+	// Attachments, when present, are listed in an "## Attachments" section with
+	// download URLs resolved against the page's link base.
+	Attachments []confluence.Attachment
+
+	// Ancestors are the pages above this one, root first, shown as the
+	// breadcrumb.
+	Ancestors []confluence.PageSummary
+
+	// Names resolves account ids (author, last editor, comment authors and
+	// mentions) to display names; nil leaves the ids as they are.
+	Names jira.NameResolver
+}
+
 // RenderPage produces a self-contained markdown document for a Confluence page,
 // intended for offline reading and as LLM context.
-//
-// spaceKey and webURL are resolved by the caller (the v2 API returns a numeric
-// space id and relative links); both are optional and rendered as "-" when empty.
-// attachments may be nil; when present they are listed in an "## Attachments"
-// section with download URLs resolved against the page's link base.
-func RenderPage(p *confluence.Page, spaceKey, webURL string, attachments []confluence.Attachment) string {
+func RenderPage(p *confluence.Page, ctx PageContext) string {
 	var b strings.Builder
 
 	now := time.Now().UTC().Format(time.RFC3339)
-	fmt.Fprintf(&b, "<!-- atlit:meta page=%s fetched=%s -->\n", p.ID, now)
+	if p.Version != nil {
+		fmt.Fprintf(&b, "<!-- atlit:meta page=%s version=%d fetched=%s -->\n", p.ID, p.Version.Number, now)
+	} else {
+		fmt.Fprintf(&b, "<!-- atlit:meta page=%s fetched=%s -->\n", p.ID, now)
+	}
 	fmt.Fprintf(&b, "# %s\n\n", p.Title)
 
 	// Metadata table.
 	b.WriteString("| Field | Value |\n")
 	b.WriteString("|-------|-------|\n")
-	writeRow(&b, "Space", spaceKey)
+	writeRow(&b, "Space", ctx.SpaceKey)
+	if ctx.SpaceName != "" {
+		writeRow(&b, "Space name", ctx.SpaceName)
+	}
 	writeRow(&b, "Page ID", p.ID)
 	writeRow(&b, "Status", p.Status)
+	if len(ctx.Ancestors) > 0 {
+		writeRow(&b, "Breadcrumb", pageBreadcrumb(ctx.Ancestors))
+	}
+	writeRow(&b, "Author", accountName(ctx.Names, p.AuthorID))
+	writeRow(&b, "Created", formatDate(p.CreatedAt))
 	if p.Version != nil {
 		writeRow(&b, "Version", strconv.Itoa(p.Version.Number))
+		writeRow(&b, "Last editor", accountName(ctx.Names, p.Version.AuthorID))
 		writeRow(&b, "Updated", formatDate(p.Version.CreatedAt))
 	}
-	writeRow(&b, "Created", formatDate(p.CreatedAt))
-	writeRow(&b, "URL", webURL)
+	writeRow(&b, "URL", ctx.WebURL)
 	b.WriteString("\n")
 
 	// Content (ADF body converted to markdown).
 	b.WriteString("## Content\n\n")
-	if body := renderPageBody(p.Body.AtlasDocFormat.Value); body != "" {
+	if body := RenderPageBodyWith(p.Body.AtlasDocFormat.Value, ctx.Names); body != "" {
 		b.WriteString(body)
 		b.WriteString("\n\n")
 	} else {
@@ -50,17 +79,135 @@ func RenderPage(p *confluence.Page, spaceKey, webURL string, attachments []confl
 
 	// Attachments. Inline images in the body reference these by filename; this
 	// section maps each filename to its absolute download URL.
-	if len(attachments) > 0 {
+	if len(ctx.Attachments) > 0 {
 		b.WriteString("## Attachments\n\n")
-		for _, att := range attachments {
+		for _, att := range ctx.Attachments {
 			writeAttachment(&b, att.Title, att.MediaType, absURL(p.Links.Base, att.DownloadLink))
 		}
 		b.WriteString("\n")
 	}
 
+	// Comments: footer comments, then inline comments with the text they
+	// anchor to. Only rendered when the caller fetched some.
+	if n := len(p.FooterComments) + len(p.InlineComments); n > 0 {
+		fmt.Fprintf(&b, "## Comments (%d)\n\n", n)
+		for i := range p.FooterComments {
+			writePageComment(&b, &p.FooterComments[i], "", ctx.Names)
+		}
+		for i := range p.InlineComments {
+			c := &p.InlineComments[i]
+			label := "inline"
+			if c.ResolutionStatus == "resolved" {
+				label = "inline, resolved"
+			}
+			writePageComment(&b, c, label, ctx.Names)
+		}
+	}
+
 	return strings.TrimRight(b.String(), "\n") + "\n"
 }
 
+// writePageComment renders a page comment as "### Author -- date" followed by
+// the highlighted text (inline comments), the body and its replies.
+func writePageComment(b *strings.Builder, c *confluence.Comment, label string, names jira.NameResolver) {
+	fmt.Fprintf(b, "### %s\n\n", pageCommentHeading(c, label, names))
+	if sel := strings.TrimSpace(c.Selection()); sel != "" {
+		for _, line := range strings.Split(sel, "\n") {
+			fmt.Fprintf(b, "> %s\n", line)
+		}
+		b.WriteString("\n")
+	}
+	writePageCommentBody(b, c, names)
+	for i := range c.Replies {
+		fmt.Fprintf(b, "#### Reply: %s\n\n", pageCommentHeading(&c.Replies[i], "", names))
+		writePageCommentBody(b, &c.Replies[i], names)
+	}
+}
+
+// pageCommentHeading is "Author -- date", with an optional label such as
+// "(inline)".
+func pageCommentHeading(c *confluence.Comment, label string, names jira.NameResolver) string {
+	author, created := "", ""
+	if c.Version != nil {
+		author = accountName(names, c.Version.AuthorID)
+		created = c.Version.CreatedAt
+	}
+	if author == "" {
+		author = "Unknown"
+	}
+	heading := author + " -- " + formatDate(created)
+	if label != "" {
+		heading += " (" + label + ")"
+	}
+	return heading
+}
+
+func writePageCommentBody(b *strings.Builder, c *confluence.Comment, names jira.NameResolver) {
+	if body := RenderPageBodyWith(c.Body.AtlasDocFormat.Value, names); body != "" {
+		b.WriteString(body)
+		b.WriteString("\n\n")
+	}
+}
+
+// accountName is the display name of an account id, or the id itself when
+// names is nil or does not know it.
+func accountName(names jira.NameResolver, accountID string) string {
+	if names != nil && accountID != "" {
+		if name := names.DisplayName(accountID); name != "" {
+			return name
+		}
+	}
+	return accountID
+}
+
+// pageBreadcrumb joins ancestor titles, root first: "Home > Design".
+func pageBreadcrumb(ancestors []confluence.PageSummary) string {
+	titles := make([]string, 0, len(ancestors))
+	for _, a := range ancestors {
+		title := strings.TrimSpace(a.Title)
+		if title == "" {
+			title = a.ID
+		}
+		titles = append(titles, title)
+	}
+	return strings.Join(titles, " > ")
+}
+
+// PageIndexEntry is a saved page in a page tree: its title, its file path
+// relative to the index, and its saved children.
+type PageIndexEntry struct {
+	Title    string
+	Path     string
+	Children []PageIndexEntry
+}
+
+// linkTextEscaper keeps brackets in a title from closing its link text.
+var linkTextEscaper = strings.NewReplacer("[", "\\[", "]", "\\]")
+
+// RenderPageIndex produces the index.md of a saved page tree or space export:
+// a nested list mirroring the page hierarchy, each entry linking its file.
+// source names what was saved (a page id or a space key) for the meta line.
+func RenderPageIndex(title, source string, entries []PageIndexEntry) string {
+	var b strings.Builder
+
+	now := time.Now().UTC().Format(time.RFC3339)
+	fmt.Fprintf(&b, "<!-- atlit:meta index=%s fetched=%s -->\n", source, now)
+	fmt.Fprintf(&b, "# %s\n\n", title)
+	if len(entries) == 0 {
+		b.WriteString("*No pages.*\n")
+		return b.String()
+	}
+	var walk func(entries []PageIndexEntry, indent string)
+	walk = func(entries []PageIndexEntry, indent string) {
+		for _, e := range entries {
+			fmt.Fprintf(&b, "%s- [%s](%s)\n", indent, linkTextEscaper.Replace(e.Title), e.Path)
+			walk(e.Children, indent+"  ")
+		}
+	}
+	walk(entries, "")
+	return b.String()
+}
+
 // absURL resolves a possibly-relative Confluence link against a base such as
 // "https://acme.atlassian.net/wiki". Absolute links are returned unchanged; a
 // leading "/wiki" on the link is de-duplicated against a "/wiki"-suffixed base.
@@ -81,11 +228,16 @@ func absURL(base, link string) string {
 	return base + link
 }
 
-// renderPageBody converts an ADF document (encoded as a JSON string by the
+// RenderPageBody converts an ADF document (encoded as a JSON string by the
 // Confluence v2 API) to markdown via the shared jira.RenderADF converter. On a
 // parse failure it embeds the raw value in a fenced block so content is never
 // silently lost.
-func renderPageBody(adfJSON string) string {
+func RenderPageBody(adfJSON string) string {
+	return RenderPageBodyWith(adfJSON, nil)
+}
+
+// RenderPageBodyWith is RenderPageBody with mentions resolved through names.
+func RenderPageBodyWith(adfJSON string, names jira.NameResolver) string {
 	if strings.TrimSpace(adfJSON) == "" {
 		return ""
 	}
@@ -93,5 +245,5 @@ func renderPageBody(adfJSON string) string {
 	if err := json.Unmarshal([]byte(adfJSON), &doc); err != nil {
 		return "```json\n" + strings.TrimRight(adfJSON, "\n") + "\n```"
 	}
-	return jira.RenderADF(&doc)
+	return jira.RenderADFWith(&doc, names)
 }
@@ -20,10 +20,10 @@ func TestRenderPage(t *testing.T) {
 		{"type":"paragraph","content":[{"type":"text","text":"Hello world"}]}
 	]}`
 
-	out := RenderPage(p, "ENG", "https://acme.atlassian.net/wiki/spaces/ENG/pages/12345/Design+Doc", nil)
+	out := RenderPage(p, PageContext{SpaceKey: "ENG", WebURL: "https://acme.atlassian.net/wiki/spaces/ENG/pages/12345/Design+Doc"})
 
 	for _, want := range []string{
-		"<!-- atlit:meta page=12345 ",
+		"<!-- atlit:meta page=12345 version=7 ",
 		"# Design Doc",
 		"| Space | ENG |",
 		"| Page ID | 12345 |",
@@ -47,7 +47,7 @@ func TestRenderPageAttachments(t *testing.T) {
 		{Title: "ext.png", MediaType: "image/png", DownloadLink: "https://cdn.example.com/ext.png"},
 	}
 
-	out := RenderPage(p, "ENG", "", atts)
+	out := RenderPage(p, PageContext{SpaceKey: "ENG", Attachments: atts})
 	for _, want := range []string{
 		"## Attachments",
 		"- arch.png (image/png) - https://acme.atlassian.net/wiki/download/attachments/12345/arch.png?version=1",
@@ -76,7 +76,7 @@ func TestAbsURL(t *testing.T) {
 
 func TestRenderPageEmptyBody(t *testing.T) {
 	p := &confluence.Page{ID: "1", Title: "Empty", Status: "current"}
-	out := RenderPage(p, "", "", nil)
+	out := RenderPage(p, PageContext{})
 	if !strings.Contains(out, "*No content.*") {
 		t.Errorf("expected empty-body placeholder, got:\n%s", out)
 	}
@@ -89,8 +89,127 @@ func TestRenderPageEmptyBody(t *testing.T) {
 func TestRenderPageInvalidBodyFallsBack(t *testing.T) {
 	p := &confluence.Page{ID: "1", Title: "Bad", Status: "current"}
 	p.Body.AtlasDocFormat.Value = "not valid json {"
-	out := RenderPage(p, "ENG", "", nil)
+	out := RenderPage(p, PageContext{SpaceKey: "ENG"})
 	if !strings.Contains(out, "not valid json {") {
 		t.Errorf("expected raw body fallback, got:\n%s", out)
 	}
 }
+
+func TestRenderPageIndex(t *testing.T) {
+	entries := []PageIndexEntry{
+		{Title: "Home", Path: "ENG__10__home.md", Children: []PageIndexEntry{
+			{Title: "Design [draft]", Path: "ENG__10__home/ENG__11__design-draft.md", Children: []PageIndexEntry{
+				{Title: "API", Path: "ENG__10__home/ENG__11__design-draft/ENG__12__api.md"},
+			}},
+		}},
+		{Title: "Archive", Path: "ENG__20__archive.md"},
+	}
+	got := RenderPageIndex("ENG: Engineering", "space:ENG", entries)
+
+	if !strings.HasPrefix(got, "<!-- atlit:meta index=space:ENG fetched=") {
+		t.Errorf("missing meta line:\n%s", got)
+	}
+	want := "# ENG: Engineering\n\n" +
+		"- [Home](ENG__10__home.md)\n" +
+		"  - [Design \\[draft\\]](ENG__10__home/ENG__11__design-draft.md)\n" +
+		"    - [API](ENG__10__home/ENG__11__design-draft/ENG__12__api.md)\n" +
+		"- [Archive](ENG__20__archive.md)\n"
+	if !strings.HasSuffix(got, want) {
+		t.Errorf("RenderPageIndex =\n%s\nwant suffix\n%s", got, want)
+	}
+}
+
+func TestRenderPageIndexEmpty(t *testing.T) {
+	got := RenderPageIndex("ENG", "space:ENG", nil)
+	if !strings.Contains(got, "*No pages.*") {
+		t.Errorf("expected empty marker:\n%s", got)
+	}
+}
+
+func TestRenderPageComments(t *testing.T) {
+	p := &confluence.Page{ID: "1", Title: "Doc"}
+	footer := confluence.Comment{
+		ID:      "c1",
+		Version: &confluence.Version{AuthorID: "acc-1", CreatedAt: "2026-06-15T09:00:00.000Z"},
+	}
+	footer.Body.AtlasDocFormat.Value = `{"type":"doc","version":1,"content":[{"type":"paragraph","content":[{"type":"text","text":"Looks good"}]}]}`
+	reply := confluence.Comment{ID: "c2", Version: &confluence.Version{AuthorID: "acc-2", CreatedAt: "2026-06-16T09:00:00.000Z"}}
+	reply.Body.AtlasDocFormat.Value = `{"type":"doc","version":1,"content":[{"type":"paragraph","content":[{"type":"text","text":"Thanks"}]}]}`
+	footer.Replies = []confluence.Comment{reply}
+	inline := confluence.Comment{ID: "i1", Version: &confluence.Version{AuthorID: "acc-3"}, ResolutionStatus: "resolved"}
+	inline.Properties.InlineOriginalSelection = "retry budget"
+	p.FooterComments = []confluence.Comment{footer}
+	p.InlineComments = []confluence.Comment{inline}
+
+	names := fakeNames{"acc-1": "Alice", "acc-3": "Bob"}
+	out := RenderPage(p, PageContext{SpaceKey: "ENG", Names: names})
+	for _, want := range []string{
+		"## Comments (2)",
+		"### Alice -- 2026-06-15",
+		"Looks good",
+		"#### Reply: acc-2 -- 2026-06-16",
+		"Thanks",
+		"### Bob -- - (inline, resolved)",
+		"> retry budget",
+	} {
+		if !strings.Contains(out, want) {
+			t.Errorf("output missing %q\n---\n%s", want, out)
+		}
+	}
+
+	if out := RenderPage(&confluence.Page{ID: "1", Title: "Doc"}, PageContext{SpaceKey: "ENG"}); strings.Contains(out, "## Comments") {
+		t.Errorf("comments section without comments:\n%s", out)
+	}
+}
+
+// fakeNames is a NameResolver over a fixed map.
+type fakeNames map[string]string
+
+func (f fakeNames) DisplayName(accountID string) string { return f[accountID] }
+
+func TestRenderPageContextRows(t *testing.T) {
+	p := &confluence.Page{
+		ID:        "12345",
+		Title:     "API",
+		AuthorID:  "acc-1",
+		CreatedAt: "2026-01-02T10:00:00.000Z",
+		Version:   &confluence.Version{Number: 3, AuthorID: "acc-2", CreatedAt: "2026-06-15T09:00:00.000Z"},
+	}
+	p.Body.AtlasDocFormat.Value = `{"type":"doc","version":1,"content":[{"type":"paragraph","content":[
+		{"type":"text","text":"Ask "},{"type":"mention","attrs":{"id":"acc-2"}}
+	]}]}`
+	ctx := PageContext{
+		SpaceKey:  "ENG",
+		SpaceName: "Engineering",
+		Ancestors: []confluence.PageSummary{{ID: "10", Title: "Home"}, {ID: "11", Title: "Design"}},
+		Names:     fakeNames{"acc-1": "Alice Smith", "acc-2": "Bob Jones"},
+	}
+
+	out := RenderPage(p, ctx)
+	for _, want := range []string{
+		"| Space | ENG |",
+		"| Space name | Engineering |",
+		"| Breadcrumb | Home > Design |",
+		"| Author | Alice Smith |",
+		"| Last editor | Bob Jones |",
+		"Ask @Bob Jones",
+	} {
+		if !strings.Contains(out, want) {
+			t.Errorf("output missing %q\n---\n%s", want, out)
+		}
+	}
+
+	// Without a resolver, the raw account ids are shown and the optional rows
+	// are left out.
+	out = RenderPage(p, PageContext{SpaceKey: "ENG"})
+	for _, want := range []string{"| Author | acc-1 |", "| Last editor | acc-2 |"} {
+		if !strings.Contains(out, want) {
+			t.Errorf("output missing %q\n---\n%s", want, out)
+		}
+	}
+	for _, unwanted := range []string{"Space name", "Breadcrumb"} {
+		if strings.Contains(out, unwanted) {
+			t.Errorf("output has %q without context\n---\n%s", unwanted, out)
+		}
+	}
+}
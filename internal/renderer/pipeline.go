@@ -0,0 +1,79 @@
+package renderer
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+	"time"
+
+	"github.com/erickhilda/atlit/internal/bitbucket"
+)
+
+// ansiRe matches the terminal escape sequences (colours, cursor moves) that
+// build logs are full of.
+var ansiRe = regexp.MustCompile(`\x1b\[[0-9;?]*[A-Za-z]`)
+
+// FormatDuration renders a duration in seconds as "45s", "3m 02s" or
+// "1h 05m"; zero (not started or still running) renders as "-".
+func FormatDuration(seconds int) string {
+	if seconds <= 0 {
+		return "-"
+	}
+	d := time.Duration(seconds) * time.Second
+	h, m, s := int(d/time.Hour), int(d%time.Hour/time.Minute), int(d%time.Minute/time.Second)
+	switch {
+	case h > 0:
+		return fmt.Sprintf("%dh %02dm", h, m)
+	case m > 0:
+		return fmt.Sprintf("%dm %02ds", m, s)
+	default:
+		return fmt.Sprintf("%ds", s)
+	}
+}
+
+// RenderPipelineLogs produces a markdown document for a pipeline run: its
+// metadata, a table of all steps, and the logs attached to logSteps (already
+// filtered by the caller). prLink, when set, is a relative link to the saved
+// PR file the run belongs to.
+func RenderPipelineLogs(workspace, repo string, p *bitbucket.Pipeline, steps, logSteps []bitbucket.PipelineStep, prLink string) string {
+	var b strings.Builder
+
+	now := time.Now().UTC().Format(time.RFC3339)
+	fmt.Fprintf(&b, "<!-- atlit:meta pipeline=%s/%s/%d fetched=%s -->\n", workspace, repo, p.BuildNumber, now)
+	fmt.Fprintf(&b, "# Pipeline #%d: %s\n\n", p.BuildNumber, p.State)
+
+	b.WriteString("| Field | Value |\n")
+	b.WriteString("|-------|-------|\n")
+	writeRow(&b, "Repo", workspace+"/"+repo)
+	writeRow(&b, "Branch", p.Target.RefName)
+	writeRow(&b, "Commit", p.Target.Commit.Hash)
+	writeRow(&b, "Trigger", p.Trigger.Name)
+	writeRow(&b, "Created", formatDate(p.CreatedOn))
+	writeRow(&b, "Duration", FormatDuration(p.DurationInSeconds))
+	if prLink != "" {
+		writeRow(&b, "PR", prLink)
+	}
+	b.WriteString("\n")
+
+	b.WriteString("## Steps\n\n")
+	b.WriteString("| Step | State | Duration |\n")
+	b.WriteString("|------|-------|----------|\n")
+	for _, s := range steps {
+		fmt.Fprintf(&b, "| %s | %s | %s |\n", s.Name, s.State, FormatDuration(s.DurationInSeconds))
+	}
+	b.WriteString("\n")
+
+	for _, s := range logSteps {
+		fmt.Fprintf(&b, "## Log: %s (%s)\n\n", s.Name, s.State)
+		log := strings.TrimRight(ansiRe.ReplaceAllString(strings.ReplaceAll(s.Log, "\r\n", "\n"), ""), "\n")
+		if log == "" {
+			b.WriteString("*No log output.*\n\n")
+			continue
+		}
+		b.WriteString("```text\n")
+		b.WriteString(log)
+		b.WriteString("\n```\n\n")
+	}
+
+	return strings.TrimRight(b.String(), "\n") + "\n"
+}
@@ -0,0 +1,52 @@
+package renderer
+
+import (
+	"encoding/json"
+	"strings"
+	"testing"
+
+	"github.com/erickhilda/atlit/internal/bitbucket"
+)
+
+func TestFormatDuration(t *testing.T) {
+	cases := map[int]string{0: "-", 45: "45s", 182: "3m 02s", 3900: "1h 05m"}
+	for in, want := range cases {
+		if got := FormatDuration(in); got != want {
+			t.Errorf("FormatDuration(%d) = %q, want %q", in, got, want)
+		}
+	}
+}
+
+func TestRenderPipelineLogs(t *testing.T) {
+	var p bitbucket.Pipeline
+	if err := json.Unmarshal([]byte(`{"build_number":12,"state":{"name":"COMPLETED","result":{"name":"FAILED"}},
+		"target":{"ref_name":"feature/PROJ-1","commit":{"hash":"abc123"}},"trigger":{"name":"PUSH"},
+		"created_on":"2026-06-09T10:00:00.000000+00:00","duration_in_seconds":95}`), &p); err != nil {
+		t.Fatal(err)
+	}
+	var steps []bitbucket.PipelineStep
+	if err := json.Unmarshal([]byte(`[{"name":"Build","state":{"name":"COMPLETED","result":{"name":"SUCCESSFUL"}},"duration_in_seconds":30},
+		{"name":"Test","state":{"name":"COMPLETED","result":{"name":"FAILED"}},"duration_in_seconds":65}]`), &steps); err != nil {
+		t.Fatal(err)
+	}
+	steps[1].Log = "\x1b[32m+ go test ./...\x1b[0m\r\n--- FAIL: TestX\r\n"
+
+	out := RenderPipelineLogs("acme", "widget", &p, steps, steps[1:], "[PR #42](acme__widget__42.md)")
+	wantContains := []string{
+		"<!-- atlit:meta pipeline=acme/widget/12 fetched=",
+		"# Pipeline #12: FAILED\n",
+		"| Branch | feature/PROJ-1 |",
+		"| Duration | 1m 35s |",
+		"| PR | [PR #42](acme__widget__42.md) |",
+		"| Build | SUCCESSFUL | 30s |\n| Test | FAILED | 1m 05s |",
+		"## Log: Test (FAILED)\n\n```text\n+ go test ./...\n--- FAIL: TestX\n```\n",
+	}
+	for _, w := range wantContains {
+		if !strings.Contains(out, w) {
+			t.Errorf("output missing %q\n---\n%s", w, out)
+		}
+	}
+	if strings.Contains(out, "## Log: Build") {
+		t.Errorf("only the requested step logs should be rendered:\n%s", out)
+	}
+}
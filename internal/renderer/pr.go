@@ -2,11 +2,13 @@ package renderer
 
 import (
 	"fmt"
+	"sort"
 	"strconv"
 	"strings"
 	"time"
 
 	"github.com/erickhilda/atlit/internal/bitbucket"
+	"github.com/erickhilda/atlit/internal/unidiff"
 )
 
 // RenderPullRequest produces a self-contained markdown document for a Bitbucket
@@ -25,8 +27,17 @@ func RenderPullRequest(
 ) string {
 	var b strings.Builder
 
+	// The meta line records the source commit and updated time so that
+	// `atlit pr --since-last` and `atlit pr sync` can tell what moved.
 	now := time.Now().UTC().Format(time.RFC3339)
-	fmt.Fprintf(&b, "<!-- atlit:meta pr=%s/%s/%d fetched=%s -->\n", workspace, repo, pr.ID, now)
+	fmt.Fprintf(&b, "<!-- atlit:meta pr=%s/%s/%d", workspace, repo, pr.ID)
+	if pr.Source.Commit.Hash != "" {
+		fmt.Fprintf(&b, " commit=%s", pr.Source.Commit.Hash)
+	}
+	if pr.UpdatedOn != "" {
+		fmt.Fprintf(&b, " updated=%s", pr.UpdatedOn)
+	}
+	fmt.Fprintf(&b, " fetched=%s -->\n", now)
 	fmt.Fprintf(&b, "# PR #%d: %s\n\n", pr.ID, pr.Title)
 
 	// Metadata table.
@@ -39,6 +50,9 @@ func RenderPullRequest(
 		writeRow(&b, "Jira", jiraKey)
 	}
 	writeRow(&b, "URL", pr.Links.HTML.Href)
+	if len(pr.Statuses) > 0 {
+		writeRow(&b, "Builds", buildSummary(pr.Statuses))
+	}
 	writeRow(&b, "Created", formatDate(pr.CreatedOn))
 	writeRow(&b, "Updated", formatDate(pr.UpdatedOn))
 	b.WriteString("\n")
@@ -56,11 +70,19 @@ func RenderPullRequest(
 		b.WriteString("*No description provided.*\n\n")
 	}
 
+	writeReviewers(&b, pr.Participants)
+	writeTasks(&b, pr.Tasks)
+	writeBuilds(&b, pr.Source.Commit.Hash, pr.Statuses)
+
 	// Diffstat (always included when present).
 	if len(diffstat) > 0 {
 		b.WriteString("## Diffstat\n\n")
 		for _, d := range diffstat {
-			fmt.Fprintf(&b, "- %s (+%d -%d)\n", d.Path(), d.LinesAdded, d.LinesRemoved)
+			fmt.Fprintf(&b, "- %s (+%d -%d)", d.Path(), d.LinesAdded, d.LinesRemoved)
+			if d.Note != "" {
+				b.WriteString(" -- " + d.Note)
+			}
+			b.WriteString("\n")
 		}
 		b.WriteString("\n")
 	}
@@ -73,31 +95,338 @@ func RenderPullRequest(
 		b.WriteString("\n```\n\n")
 	}
 
-	// Comments (skip deleted/empty; annotate inline location).
-	var cb strings.Builder
-	rendered := 0
-	for _, cm := range comments {
-		if cm.Deleted || strings.TrimSpace(cm.Content.Raw) == "" {
-			continue
+	writePRComments(&b, comments, diff)
+
+	return strings.TrimRight(b.String(), "\n") + "\n"
+}
+
+// RenderPullRequestFile renders one file's diff of a split PR (`atlit pr
+// --split`). indexPath is the PR's main file, relative to this one.
+func RenderPullRequestFile(pr *bitbucket.PullRequest, workspace, repo string, entry bitbucket.DiffstatEntry, indexPath, diff string) string {
+	var b strings.Builder
+
+	now := time.Now().UTC().Format(time.RFC3339)
+	fmt.Fprintf(&b, "<!-- atlit:meta pr=%s/%s/%d file=%s fetched=%s -->\n", workspace, repo, pr.ID, entry.Path(), now)
+	fmt.Fprintf(&b, "# PR #%d: %s\n\n", pr.ID, entry.Path())
+
+	fmt.Fprintf(&b, "Part of [PR #%d: %s](%s)", pr.ID, pr.Title, indexPath)
+	if entry.Status != "" {
+		b.WriteString(" -- " + entry.Status)
+	}
+	fmt.Fprintf(&b, " (+%d -%d)", entry.LinesAdded, entry.LinesRemoved)
+	if entry.Old != nil && entry.New != nil && entry.Old.Path != entry.New.Path {
+		fmt.Fprintf(&b, ", renamed from %s", entry.Old.Path)
+	}
+	b.WriteString(".\n\n")
+
+	b.WriteString("```diff\n")
+	b.WriteString(strings.TrimRight(diff, "\n"))
+	b.WriteString("\n```\n")
+	return b.String()
+}
+
+// RenderPullRequestChanges renders the "## Since Last Fetch" section of
+// `atlit pr --since-last`: the interdiff between the previously fetched source
+// commit and the current one, and the comments added since the previous
+// fetch.
+func RenderPullRequestChanges(fromCommit, toCommit string, since time.Time, interdiff string, comments []bitbucket.Comment) string {
+	var b strings.Builder
+	b.WriteString("## Since Last Fetch\n\n")
+	prev := since.UTC().Format("2006-01-02 15:04") + " UTC"
+	if fromCommit == toCommit {
+		fmt.Fprintf(&b, "No new commits since the previous fetch (%s, `%s`).\n\n", prev, fromCommit)
+	} else {
+		fmt.Fprintf(&b, "Source moved from `%s` (fetched %s) to `%s`.\n\n", fromCommit, prev, toCommit)
+		b.WriteString("### Interdiff\n\n")
+		if strings.TrimSpace(interdiff) == "" {
+			b.WriteString("*No file changes between the two commits.*\n\n")
+		} else {
+			b.WriteString("```diff\n")
+			b.WriteString(strings.TrimRight(interdiff, "\n"))
+			b.WriteString("\n```\n\n")
 		}
+	}
+
+	fmt.Fprintf(&b, "### New Comments (%d)\n\n", len(comments))
+	if len(comments) == 0 {
+		b.WriteString("*No new comments.*\n\n")
+	}
+	for _, cm := range comments {
 		loc := ""
-		if cm.Inline != nil && cm.Inline.Path != "" {
-			loc = " - " + cm.Inline.Path
-			if cm.Inline.To != nil {
-				loc += ":" + strconv.Itoa(*cm.Inline.To)
+		if l := locationOf(cm); l.path != "" {
+			loc = " - " + l.title()
+		}
+		fmt.Fprintf(&b, "#### %s -- %s%s\n\n", cm.User.DisplayName, formatDate(cm.CreatedOn), loc)
+		b.WriteString(strings.TrimRight(cm.Content.Raw, "\n"))
+		b.WriteString("\n\n")
+	}
+	return b.String()
+}
+
+// writeReviewers renders a "## Reviewers" table of the PR's reviewers and of
+// anyone else who approved or requested changes. Omitted when empty.
+func writeReviewers(b *strings.Builder, participants []bitbucket.Participant) {
+	var rows []bitbucket.Participant
+	for _, p := range participants {
+		if p.Role == "REVIEWER" || p.Approved || p.State != "" {
+			rows = append(rows, p)
+		}
+	}
+	if len(rows) == 0 {
+		return
+	}
+	b.WriteString("## Reviewers\n\n")
+	b.WriteString("| Reviewer | Role | Status |\n")
+	b.WriteString("|----------|------|--------|\n")
+	for _, p := range rows {
+		role := "Participant"
+		if p.Role == "REVIEWER" {
+			role = "Reviewer"
+		}
+		fmt.Fprintf(b, "| %s | %s | %s |\n", p.User.DisplayName, role, participantStatus(p))
+	}
+	b.WriteString("\n")
+}
+
+// participantStatus describes a participant's review state.
+func participantStatus(p bitbucket.Participant) string {
+	switch {
+	case p.Approved || p.State == "approved":
+		return "Approved"
+	case p.State == "changes_requested":
+		return "Changes requested"
+	default:
+		return "Pending"
+	}
+}
+
+// writeTasks renders PR tasks as a checklist, open ones first. Omitted when
+// there are none.
+func writeTasks(b *strings.Builder, tasks []bitbucket.Task) {
+	if len(tasks) == 0 {
+		return
+	}
+	var open, done []bitbucket.Task
+	for _, t := range tasks {
+		if t.State == "RESOLVED" {
+			done = append(done, t)
+		} else {
+			open = append(open, t)
+		}
+	}
+	fmt.Fprintf(b, "## Tasks (%d open, %d resolved)\n\n", len(open), len(done))
+	for _, group := range [][]bitbucket.Task{open, done} {
+		for _, t := range group {
+			box := " "
+			if t.State == "RESOLVED" {
+				box = "x"
 			}
+			line := strings.Join(strings.Fields(t.Content.Raw), " ")
+			if t.Creator.DisplayName != "" {
+				line += " -- " + t.Creator.DisplayName
+			}
+			fmt.Fprintf(b, "- [%s] %s\n", box, line)
+		}
+	}
+	b.WriteString("\n")
+}
+
+// writeBuilds renders the build statuses of the PR's source commit. Omitted
+// when nothing reported a status.
+func writeBuilds(b *strings.Builder, commit string, statuses []bitbucket.CommitStatus) {
+	if len(statuses) == 0 {
+		return
+	}
+	b.WriteString("## Builds\n\n")
+	if commit != "" {
+		fmt.Fprintf(b, "Source commit `%s`.\n\n", commit)
+	}
+	b.WriteString("| Build | State | Link |\n")
+	b.WriteString("|-------|-------|------|\n")
+	for _, st := range statuses {
+		name := st.Name
+		if name == "" {
+			name = st.Key
+		}
+		fmt.Fprintf(b, "| %s | %s | %s |\n", name, st.State, st.URL)
+	}
+	b.WriteString("\n")
+}
+
+// buildSummary condenses the source commit's build statuses into one value:
+// the overall state (any failure wins, then anything still running) and the
+// count per state, e.g. "FAILED (1 failed, 2 successful)".
+func buildSummary(statuses []bitbucket.CommitStatus) string {
+	counts := map[string]int{}
+	for _, st := range statuses {
+		counts[st.State]++
+	}
+	overall := "SUCCESSFUL"
+	switch {
+	case counts["FAILED"] > 0:
+		overall = "FAILED"
+	case counts["INPROGRESS"] > 0:
+		overall = "INPROGRESS"
+	case counts["STOPPED"] > 0:
+		overall = "STOPPED"
+	}
+	var parts []string
+	for _, state := range []string{"FAILED", "INPROGRESS", "STOPPED", "SUCCESSFUL"} {
+		if n := counts[state]; n > 0 {
+			parts = append(parts, fmt.Sprintf("%d %s", n, strings.ToLower(state)))
+		}
+	}
+	return overall + " (" + strings.Join(parts, ", ") + ")"
+}
+
+// writePRComments renders comments as threads: general threads first, then
+// inline threads grouped by file and line, each group under a heading with
+// the diff lines it refers to. Deleted and empty comments are skipped; a
+// reply whose parent is not shown starts its own thread.
+func writePRComments(b *strings.Builder, comments []bitbucket.Comment, diff string) {
+	visible := map[int]bool{}
+	var shown []bitbucket.Comment
+	for _, cm := range comments {
+		if cm.Deleted || strings.TrimSpace(cm.Content.Raw) == "" {
+			continue
 		}
-		fmt.Fprintf(&cb, "### %s -- %s%s\n\n", cm.User.DisplayName, formatDate(cm.CreatedOn), loc)
-		cb.WriteString(strings.TrimRight(cm.Content.Raw, "\n"))
-		cb.WriteString("\n\n")
-		rendered++
+		visible[cm.ID] = true
+		shown = append(shown, cm)
 	}
-	fmt.Fprintf(&b, "## Comments (%d)\n\n", rendered)
-	if rendered == 0 {
+
+	fmt.Fprintf(b, "## Comments (%d)\n\n", len(shown))
+	if len(shown) == 0 {
 		b.WriteString("*No comments.*\n\n")
-	} else {
-		b.WriteString(cb.String())
+		return
 	}
 
-	return strings.TrimRight(b.String(), "\n") + "\n"
+	replies := map[int][]bitbucket.Comment{}
+	var roots []bitbucket.Comment
+	for _, cm := range shown {
+		if cm.Parent != nil && cm.Parent.ID != cm.ID && visible[cm.Parent.ID] {
+			replies[cm.Parent.ID] = append(replies[cm.Parent.ID], cm)
+			continue
+		}
+		roots = append(roots, cm)
+	}
+
+	// Group threads by location, keeping the first-seen order within a group.
+	groups := map[string][]bitbucket.Comment{}
+	var locs []commentLocation
+	for _, r := range roots {
+		loc := locationOf(r)
+		if _, ok := groups[loc.key()]; !ok {
+			locs = append(locs, loc)
+		}
+		groups[loc.key()] = append(groups[loc.key()], r)
+	}
+	sort.SliceStable(locs, func(i, j int) bool { return locs[i].less(locs[j]) })
+
+	for _, loc := range locs {
+		fmt.Fprintf(b, "### %s\n\n", loc.title())
+		if snippet := diffSnippet(diff, loc.path, loc.line, loc.old); snippet != "" {
+			b.WriteString("```diff\n" + snippet + "```\n\n")
+		}
+		for _, r := range groups[loc.key()] {
+			writeCommentTree(b, r, replies, 0)
+		}
+	}
+}
+
+// writeCommentTree writes cm and, blockquoted one level deeper each time, its
+// replies.
+func writeCommentTree(b *strings.Builder, cm bitbucket.Comment, replies map[int][]bitbucket.Comment, depth int) {
+	quote := strings.Repeat("> ", depth)
+	var c strings.Builder
+	fmt.Fprintf(&c, "**%s** -- %s\n\n", cm.User.DisplayName, formatDate(cm.CreatedOn))
+	c.WriteString(strings.TrimRight(cm.Content.Raw, "\n"))
+	for _, line := range strings.Split(c.String(), "\n") {
+		b.WriteString(strings.TrimRight(quote+line, " ") + "\n")
+	}
+	b.WriteString("\n")
+	for _, r := range replies[cm.ID] {
+		writeCommentTree(b, r, replies, depth+1)
+	}
+}
+
+// commentLocation is where a comment thread is attached: nowhere (general),
+// a file, or a line of the new (or, when old, the old) version of a file.
+type commentLocation struct {
+	path string
+	line int
+	old  bool
+}
+
+func locationOf(cm bitbucket.Comment) commentLocation {
+	in := cm.Inline
+	if in == nil || in.Path == "" {
+		return commentLocation{}
+	}
+	switch {
+	case in.To != nil:
+		return commentLocation{path: in.Path, line: *in.To}
+	case in.From != nil:
+		return commentLocation{path: in.Path, line: *in.From, old: true}
+	default:
+		return commentLocation{path: in.Path}
+	}
+}
+
+func (l commentLocation) key() string {
+	return fmt.Sprintf("%s\x00%d\x00%t", l.path, l.line, l.old)
+}
+
+// less orders general comments first, then by path and line.
+func (l commentLocation) less(o commentLocation) bool {
+	if l.path != o.path {
+		return l.path < o.path
+	}
+	if l.line != o.line {
+		return l.line < o.line
+	}
+	return !l.old && o.old
+}
+
+func (l commentLocation) title() string {
+	switch {
+	case l.path == "":
+		return "General"
+	case l.line == 0:
+		return l.path
+	case l.old:
+		return fmt.Sprintf("%s (old line %d)", l.path, l.line)
+	default:
+		return l.path + ":" + strconv.Itoa(l.line)
+	}
+}
+
+// snippetContext is how many diff lines before the commented one are shown.
+const snippetContext = 3
+
+// diffSnippet returns the hunk header and the few diff lines leading up to
+// line in path's diff (a new-file line, or an old-file line when old), or ""
+// when the diff does not cover it.
+func diffSnippet(diff, path string, line int, old bool) string {
+	if diff == "" || path == "" || line <= 0 {
+		return ""
+	}
+	for _, f := range unidiff.Parse(diff) {
+		if f.NewPath != path && (f.OldPath != path || (!old && f.NewPath != "")) {
+			continue
+		}
+		for _, h := range f.Hunks {
+			for i, l := range h.Lines {
+				if (old && l.Old != line) || (!old && l.New != line) {
+					continue
+				}
+				var b strings.Builder
+				b.WriteString(h.Header + "\n")
+				for _, c := range h.Lines[max(0, i-snippetContext) : i+1] {
+					b.WriteString(c.Text + "\n")
+				}
+				return b.String()
+			}
+		}
+	}
+	return ""
 }
@@ -3,6 +3,7 @@ package renderer
 import (
 	"strings"
 	"testing"
+	"time"
 
 	"github.com/erickhilda/atlit/internal/bitbucket"
 )
@@ -38,7 +39,7 @@ func TestRenderPullRequest(t *testing.T) {
 		"diff --git a/a.go b/a.go\n+x\n", []bitbucket.Comment{c1, deleted}, "PROJ-1234", "/home/me/.jt/tickets/PROJ-1234.md")
 
 	wantContains := []string{
-		"<!-- atlit:meta pr=acme/widget/42 fetched=",
+		"<!-- atlit:meta pr=acme/widget/42 updated=2026-06-10T11:00:00.000000+00:00 fetched=",
 		"# PR #42: Fix bug",
 		"| State | OPEN |",
 		"| Branch | feature/PROJ-1234_x -> develop |",
@@ -49,7 +50,8 @@ func TestRenderPullRequest(t *testing.T) {
 		"## Diff",
 		"```diff",
 		"## Comments (1)",
-		"### Alice -- 2026-06-09 - a.go:42",
+		"### a.go:42",
+		"**Alice** -- 2026-06-09",
 		"Looks good",
 	}
 	for _, w := range wantContains {
@@ -78,3 +80,168 @@ func TestRenderPullRequestNoDiffNoJira(t *testing.T) {
 		t.Errorf("expected empty comments section:\n%s", out)
 	}
 }
+
+func TestRenderPullRequestThreads(t *testing.T) {
+	comment := func(id, parent int, user, body string, to *int) bitbucket.Comment {
+		c := bitbucket.Comment{ID: id, User: bitbucket.Account{DisplayName: user}, CreatedOn: "2026-06-09T10:00:00+00:00"}
+		c.Content.Raw = body
+		if parent != 0 {
+			c.Parent = &bitbucket.CommentRef{ID: parent}
+		}
+		if to != nil {
+			c.Inline = &bitbucket.Inline{Path: "a.go", To: to}
+		}
+		return c
+	}
+	line := 12
+	diff := "diff --git a/a.go b/a.go\n--- a/a.go\n+++ b/a.go\n@@ -8,5 +8,6 @@ func f() {\n" +
+		" a\n b\n c\n d\n+e\n f\n"
+	comments := []bitbucket.Comment{
+		comment(1, 0, "Alice", "Why e?", &line),
+		comment(2, 0, "Carol", "Ship it", nil),
+		comment(3, 1, "Bob", "Needed for f.", &line),
+		comment(4, 3, "Alice", "Ok", &line),
+	}
+
+	out := RenderPullRequest(samplePR(), "acme", "widget", nil, diff, comments, "", "")
+
+	want := "### General\n\n**Carol** -- 2026-06-09\n\nShip it\n\n" +
+		"### a.go:12\n\n```diff\n@@ -8,5 +8,6 @@\n b\n c\n d\n+e\n```\n\n" +
+		"**Alice** -- 2026-06-09\n\nWhy e?\n\n" +
+		"> **Bob** -- 2026-06-09\n>\n> Needed for f.\n\n" +
+		"> > **Alice** -- 2026-06-09\n> >\n> > Ok\n"
+	if !strings.Contains(out, "## Comments (4)\n\n"+want) {
+		t.Errorf("threads not rendered as expected:\n%s", out)
+	}
+}
+
+func TestRenderPullRequestReviewersTasksBuilds(t *testing.T) {
+	pr := samplePR()
+	pr.Source.Commit.Hash = "abc123def456"
+	pr.Participants = []bitbucket.Participant{
+		{User: bitbucket.Account{DisplayName: "Bob"}, Role: "REVIEWER", Approved: true, State: "approved"},
+		{User: bitbucket.Account{DisplayName: "Carol"}, Role: "REVIEWER"},
+		{User: bitbucket.Account{DisplayName: "Dan"}, Role: "PARTICIPANT", State: "changes_requested"},
+		{User: bitbucket.Account{DisplayName: "Eve"}, Role: "PARTICIPANT"},
+	}
+	var open, done bitbucket.Task
+	open.Content.Raw, open.State, open.Creator.DisplayName = "Add a test", "UNRESOLVED", "Bob"
+	done.Content.Raw, done.State = "Rename x", "RESOLVED"
+	pr.Tasks = []bitbucket.Task{done, open}
+	pr.Statuses = []bitbucket.CommitStatus{{Key: "ci", Name: "Pipeline #7", State: "FAILED", URL: "https://ci/7"}}
+
+	out := RenderPullRequest(pr, "acme", "widget", nil, "", nil, "", "")
+
+	wantContains := []string{
+		"## Reviewers\n\n| Reviewer | Role | Status |",
+		"| Bob | Reviewer | Approved |",
+		"| Carol | Reviewer | Pending |",
+		"| Dan | Participant | Changes requested |",
+		"## Tasks (1 open, 1 resolved)\n\n- [ ] Add a test -- Bob\n- [x] Rename x\n",
+		"## Builds\n\nSource commit `abc123def456`.",
+		"| Pipeline #7 | FAILED | https://ci/7 |",
+		"| Builds | FAILED (1 failed) |",
+	}
+	for _, w := range wantContains {
+		if !strings.Contains(out, w) {
+			t.Errorf("output missing %q\n---\n%s", w, out)
+		}
+	}
+	if strings.Contains(out, "| Eve |") {
+		t.Errorf("participant without a review state should be omitted:\n%s", out)
+	}
+}
+
+func TestBuildSummary(t *testing.T) {
+	cases := []struct {
+		states []string
+		want   string
+	}{
+		{[]string{"SUCCESSFUL", "SUCCESSFUL"}, "SUCCESSFUL (2 successful)"},
+		{[]string{"SUCCESSFUL", "INPROGRESS"}, "INPROGRESS (1 inprogress, 1 successful)"},
+		{[]string{"INPROGRESS", "FAILED", "SUCCESSFUL"}, "FAILED (1 failed, 1 inprogress, 1 successful)"},
+	}
+	for _, tc := range cases {
+		var statuses []bitbucket.CommitStatus
+		for _, s := range tc.states {
+			statuses = append(statuses, bitbucket.CommitStatus{State: s})
+		}
+		if got := buildSummary(statuses); got != tc.want {
+			t.Errorf("%v: got %q, want %q", tc.states, got, tc.want)
+		}
+	}
+}
+
+func TestDiffSnippetOldLine(t *testing.T) {
+	diff := "diff --git a/a.go b/a.go\n--- a/a.go\n+++ b/a.go\n@@ -3,3 +3,2 @@\n x\n-y\n z\n"
+	if got := diffSnippet(diff, "a.go", 4, true); got != "@@ -3,3 +3,2 @@\n x\n-y\n" {
+		t.Errorf("old-line snippet = %q", got)
+	}
+	if got := diffSnippet(diff, "b.go", 4, false); got != "" {
+		t.Errorf("unknown file snippet = %q", got)
+	}
+}
+
+func TestRenderPullRequestFile(t *testing.T) {
+	entry := bitbucket.DiffstatEntry{
+		Status: "renamed", LinesAdded: 2, LinesRemoved: 1,
+		Old: &bitbucket.DiffFile{Path: "old.go"}, New: &bitbucket.DiffFile{Path: "pkg/new.go"},
+	}
+	out := RenderPullRequestFile(samplePR(), "acme", "widget", entry, "../acme__widget__42.md", "diff --git a/old.go b/pkg/new.go\n+x\n")
+
+	wantContains := []string{
+		"<!-- atlit:meta pr=acme/widget/42 file=pkg/new.go fetched=",
+		"# PR #42: pkg/new.go",
+		"Part of [PR #42: Fix bug](../acme__widget__42.md) -- renamed (+2 -1), renamed from old.go.",
+		"```diff\ndiff --git a/old.go b/pkg/new.go\n+x\n```\n",
+	}
+	for _, w := range wantContains {
+		if !strings.Contains(out, w) {
+			t.Errorf("output missing %q\n---\n%s", w, out)
+		}
+	}
+}
+
+func TestRenderPullRequestDiffstatNote(t *testing.T) {
+	diffstat := []bitbucket.DiffstatEntry{{LinesAdded: 1, New: &bitbucket.DiffFile{Path: "go.sum"}, Note: "diff omitted: generated"}}
+	out := RenderPullRequest(samplePR(), "acme", "widget", diffstat, "", nil, "", "")
+	if !strings.Contains(out, "- go.sum (+1 -0) -- diff omitted: generated\n") {
+		t.Errorf("diffstat note missing:\n%s", out)
+	}
+}
+
+func TestRenderPullRequestMetaCommit(t *testing.T) {
+	pr := samplePR()
+	pr.Source.Commit.Hash = "abc123def456"
+	out := RenderPullRequest(pr, "acme", "widget", nil, "", nil, "", "")
+	if !strings.HasPrefix(out, "<!-- atlit:meta pr=acme/widget/42 commit=abc123def456 updated=2026-06-10T11:00:00.000000+00:00 fetched=") {
+		t.Errorf("meta line = %q", out[:strings.IndexByte(out, '\n')])
+	}
+}
+
+func TestRenderPullRequestChanges(t *testing.T) {
+	since := time.Date(2026, 6, 9, 10, 0, 0, 0, time.UTC)
+	c := bitbucket.Comment{User: bitbucket.Account{DisplayName: "Bob"}, CreatedOn: "2026-06-10T09:00:00+00:00"}
+	c.Content.Raw = "Still off by one"
+	line := 7
+	c.Inline = &bitbucket.Inline{Path: "a.go", To: &line}
+
+	out := RenderPullRequestChanges("aaa111", "bbb222", since, "diff --git a/a.go b/a.go\n+y\n", []bitbucket.Comment{c})
+	wantContains := []string{
+		"## Since Last Fetch\n\nSource moved from `aaa111` (fetched 2026-06-09 10:00 UTC) to `bbb222`.",
+		"### Interdiff\n\n```diff\ndiff --git a/a.go b/a.go\n+y\n```",
+		"### New Comments (1)",
+		"#### Bob -- 2026-06-10 - a.go:7\n\nStill off by one",
+	}
+	for _, w := range wantContains {
+		if !strings.Contains(out, w) {
+			t.Errorf("output missing %q\n---\n%s", w, out)
+		}
+	}
+
+	out = RenderPullRequestChanges("aaa111", "aaa111", since, "", nil)
+	if !strings.Contains(out, "No new commits since the previous fetch") || strings.Contains(out, "Interdiff") ||
+		!strings.Contains(out, "*No new comments.*") {
+		t.Errorf("unchanged PR:\n%s", out)
+	}
+}
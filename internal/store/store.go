@@ -5,6 +5,7 @@ import (
 	"os"
 	"path/filepath"
 	"sort"
+	"strconv"
 	"strings"
 	"time"
 )
@@ -233,6 +234,147 @@ func ParseMeta(content string) *TicketMeta {
 	return &meta
 }
 
+// PRMeta is the metadata recorded on the first line of a saved pull request:
+// which PR it is, the source commit and updated timestamp it was rendered
+// from, and when it was fetched.
+type PRMeta struct {
+	Workspace string
+	Repo      string
+	ID        int
+	Commit    string
+	Updated   string
+	Fetched   time.Time
+}
+
+// ParsePRMeta extracts the atlit:meta comment of a saved pull request. The
+// workspace may itself contain slashes (GitLab groups); the repo and id are
+// the last two segments. Returns nil if the line is missing or malformed.
+func ParsePRMeta(content string) *PRMeta {
+	line := content
+	if idx := strings.IndexByte(content, '\n'); idx >= 0 {
+		line = content[:idx]
+	}
+	const suffix = " -->"
+	if !strings.HasPrefix(line, markerPrefix) || !strings.HasSuffix(line, suffix) {
+		return nil
+	}
+	body := line[len(markerPrefix) : len(line)-len(suffix)]
+
+	var meta PRMeta
+	for _, part := range strings.Fields(body) {
+		key, val, ok := strings.Cut(part, "=")
+		if !ok {
+			continue
+		}
+		switch key {
+		case "pr":
+			i := strings.LastIndex(val, "/")
+			j := strings.LastIndex(val[:max(i, 0)], "/")
+			if j <= 0 {
+				return nil
+			}
+			id, err := strconv.Atoi(val[i+1:])
+			if err != nil {
+				return nil
+			}
+			meta.Workspace, meta.Repo, meta.ID = val[:j], val[j+1:i], id
+		case "commit":
+			meta.Commit = val
+		case "updated":
+			meta.Updated = val
+		case "fetched":
+			t, err := time.Parse(time.RFC3339, val)
+			if err != nil {
+				return nil
+			}
+			meta.Fetched = t
+		}
+	}
+	if meta.ID == 0 || meta.Fetched.IsZero() {
+		return nil
+	}
+	return &meta
+}
+
+// PageMeta is the metadata recorded on the first line of a saved Confluence
+// page: its id, the page version it was rendered from, and when it was
+// fetched. Version is 0 in files saved before it was recorded.
+type PageMeta struct {
+	ID      string
+	Version int
+	Fetched time.Time
+}
+
+// ParsePageMeta extracts the atlit:meta comment of a saved Confluence page.
+// Returns nil if the line is missing or malformed.
+func ParsePageMeta(content string) *PageMeta {
+	line := content
+	if idx := strings.IndexByte(content, '\n'); idx >= 0 {
+		line = content[:idx]
+	}
+	const suffix = " -->"
+	if !strings.HasPrefix(line, markerPrefix) || !strings.HasSuffix(line, suffix) {
+		return nil
+	}
+	body := line[len(markerPrefix) : len(line)-len(suffix)]
+
+	var meta PageMeta
+	for _, part := range strings.Fields(body) {
+		key, val, ok := strings.Cut(part, "=")
+		if !ok {
+			continue
+		}
+		switch key {
+		case "page":
+			meta.ID = val
+		case "version":
+			n, err := strconv.Atoi(val)
+			if err != nil {
+				return nil
+			}
+			meta.Version = n
+		case "fetched":
+			t, err := time.Parse(time.RFC3339, val)
+			if err != nil {
+				return nil
+			}
+			meta.Fetched = t
+		}
+	}
+	if meta.ID == "" || meta.Fetched.IsZero() {
+		return nil
+	}
+	return &meta
+}
+
+// StampPageMeta sets the first-line atlit:meta comment of a saved page to the
+// given id, version and fetch time, replacing an existing marker or
+// prepending a new one.
+func StampPageMeta(content, id string, version int, fetched time.Time) string {
+	line := fmt.Sprintf("%spage=%s version=%d fetched=%s -->", markerPrefix, id, version, fetched.UTC().Format(time.RFC3339))
+	if strings.HasPrefix(content, markerPrefix) {
+		if idx := strings.IndexByte(content, '\n'); idx >= 0 {
+			return line + content[idx:]
+		}
+		return line + "\n"
+	}
+	return line + "\n" + content
+}
+
+// StampMeta sets the first-line atlit:meta comment of content to ticket key and
+// fetch time, replacing an existing (current or legacy) marker or prepending a
+// new one.
+func StampMeta(content, key string, fetched time.Time) string {
+	line := fmt.Sprintf("%sticket=%s fetched=%s -->", markerPrefix, key, fetched.UTC().Format(time.RFC3339))
+	if strings.HasPrefix(content, markerPrefix) || strings.HasPrefix(content, markerLegacyPrefix) {
+		if idx := strings.IndexByte(content, '\n'); idx >= 0 {
+			return line + content[idx:]
+		}
+		return line + "\n"
+	}
+	return line + "\n" + content
+}
+
 // ListTickets reads all .md files from ticketsDir, parses metadata from each,
 // and returns them sorted by key. Files without valid metadata are skipped.
 func ListTickets(ticketsDir string) ([]TicketInfo, error) {
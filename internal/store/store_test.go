@@ -372,3 +372,81 @@ func TestUpgradeMarker(t *testing.T) {
 		}
 	})
 }
+
+func TestStampMeta(t *testing.T) {
+	fetched := time.Date(2026, 3, 1, 10, 0, 0, 0, time.UTC)
+	want := "<!-- atlit:meta ticket=PROJ-7 fetched=2026-03-01T10:00:00Z -->\n"
+
+	got := StampMeta("# PROJ-7: Title\n", "PROJ-7", fetched)
+	if got != want+"# PROJ-7: Title\n" {
+		t.Errorf("prepend: got %q", got)
+	}
+	if meta := ParseMeta(got); meta == nil || meta.Ticket != "PROJ-7" || !meta.Fetched.Equal(fetched) {
+		t.Errorf("ParseMeta(stamped) = %+v", meta)
+	}
+
+	old := "<!-- jt:meta ticket=OLD-1 fetched=2020-01-01T00:00:00Z -->\n# Body\n"
+	if got := StampMeta(old, "PROJ-7", fetched); got != want+"# Body\n" {
+		t.Errorf("replace: got %q", got)
+	}
+}
+
+func TestParsePRMeta(t *testing.T) {
+	content := "<!-- atlit:meta pr=acme/platform/widget/42 commit=abc123 updated=2026-06-10T11:00:00.000000+00:00 fetched=2026-06-10T12:00:00Z -->\n# PR #42\n"
+	meta := ParsePRMeta(content)
+	if meta == nil {
+		t.Fatal("expected meta")
+	}
+	if meta.Workspace != "acme/platform" || meta.Repo != "widget" || meta.ID != 42 {
+		t.Errorf("ref = %s/%s/%d", meta.Workspace, meta.Repo, meta.ID)
+	}
+	if meta.Commit != "abc123" || meta.Updated != "2026-06-10T11:00:00.000000+00:00" || meta.Fetched.Hour() != 12 {
+		t.Errorf("meta = %+v", meta)
+	}
+
+	old := ParsePRMeta("<!-- atlit:meta pr=acme/widget/7 fetched=2026-06-10T12:00:00Z -->\n")
+	if old == nil || old.ID != 7 || old.Commit != "" {
+		t.Errorf("meta without commit = %+v", old)
+	}
+	for _, bad := range []string{
+		"<!-- atlit:meta ticket=PROJ-1 fetched=2026-06-10T12:00:00Z -->\n",
+		"<!-- atlit:meta pr=widget/x fetched=2026-06-10T12:00:00Z -->\n",
+		"# no meta\n",
+	} {
+		if m := ParsePRMeta(bad); m != nil {
+			t.Errorf("ParsePRMeta(%q) = %+v, want nil", bad, m)
+		}
+	}
+}
+
+func TestParsePageMeta(t *testing.T) {
+	meta := ParsePageMeta("<!-- atlit:meta page=12345 version=7 fetched=2026-06-10T12:00:00Z -->\n# Design Doc\n")
+	if meta == nil || meta.ID != "12345" || meta.Version != 7 || meta.Fetched.Hour() != 12 {
+		t.Fatalf("meta = %+v", meta)
+	}
+	old := ParsePageMeta("<!-- atlit:meta page=12345 fetched=2026-06-10T12:00:00Z -->\n")
+	if old == nil || old.Version != 0 {
+		t.Errorf("meta without version = %+v", old)
+	}
+	for _, bad := range []string{
+		"<!-- atlit:meta ticket=PROJ-1 fetched=2026-06-10T12:00:00Z -->\n",
+		"<!-- atlit:meta page=1 version=x fetched=2026-06-10T12:00:00Z -->\n",
+		"# no meta\n",
+	} {
+		if m := ParsePageMeta(bad); m != nil {
+			t.Errorf("ParsePageMeta(%q) = %+v, want nil", bad, m)
+		}
+	}
+}
+
+func TestStampPageMeta(t *testing.T) {
+	fetched := time.Date(2026, 3, 1, 10, 0, 0, 0, time.UTC)
+	want := "<!-- atlit:meta page=12345 version=8 fetched=2026-03-01T10:00:00Z -->\n"
+	old := "<!-- atlit:meta page=12345 version=7 fetched=2026-01-01T00:00:00Z -->\n# Body\n"
+	if got := StampPageMeta(old, "12345", 8, fetched); got != want+"# Body\n" {
+		t.Errorf("replace: got %q", got)
+	}
+	if got := StampPageMeta("# Body\n", "12345", 8, fetched); got != want+"# Body\n" {
+		t.Errorf("prepend: got %q", got)
+	}
+}
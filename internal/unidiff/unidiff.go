@@ -0,0 +1,144 @@
+// Package unidiff parses git-style unified diffs: the files they touch, their
+// hunks, and the old/new line number of every diff line. The PR renderer, the
+// diff splitter and review comment checks all read diffs through it.
+package unidiff
+
+import (
+	"regexp"
+	"strconv"
+	"strings"
+)
+
+// File is one file's section of a unified diff.
+type File struct {
+	// Header is the "diff --git" line, or "" for a diff without one.
+	Header string
+	// OldPath and NewPath come from the ---/+++ lines, without the a/ and b/
+	// prefixes; /dev/null (an added or deleted file) is "".
+	OldPath, NewPath string
+	// Text is the section exactly as it appears in the diff.
+	Text  string
+	Hunks []Hunk
+}
+
+// Path names the file by its new path, or its old path when deleted, or the
+// path in the "diff --git" line for sections without ---/+++ lines (binary
+// files, pure renames).
+func (f File) Path() string {
+	switch {
+	case f.NewPath != "":
+		return f.NewPath
+	case f.OldPath != "":
+		return f.OldPath
+	}
+	if i := strings.LastIndex(f.Header, " b/"); i >= 0 {
+		return f.Header[i+len(" b/"):]
+	}
+	return ""
+}
+
+// Hunk is one "@@" block of a file.
+type Hunk struct {
+	// Header is the "@@ -a,b +c,d @@" part of the hunk line, without the
+	// trailing function context.
+	Header             string
+	OldStart, NewStart int
+	Lines              []Line
+}
+
+// Line is an added ('+'), removed ('-') or context (' ') line of a hunk. Old
+// and New are its line numbers in the old and new version; a side the line is
+// not on is 0.
+type Line struct {
+	Kind     byte
+	Text     string
+	Old, New int
+}
+
+// hunkRe matches a unified diff hunk header.
+var hunkRe = regexp.MustCompile(`^@@ -(\d+)(?:,\d+)? \+(\d+)(?:,\d+)? @@`)
+
+// Parse splits diff into files at "diff --git" lines (a leading file without
+// one starts at its "---" line). Anything before the first file, such as the
+// opening line of a markdown fence, is ignored, as are lines in a hunk that
+// are not diff lines ("\ No newline at end of file").
+func Parse(diff string) []File {
+	var files []File
+	var text strings.Builder
+	var hunk *Hunk
+	oldNo, newNo := 0, 0
+	inHunk := false
+	cur := -1
+	flush := func() {
+		if cur >= 0 {
+			files[cur].Text = text.String()
+		}
+		text.Reset()
+	}
+
+	for _, raw := range strings.SplitAfter(diff, "\n") {
+		line := strings.TrimSuffix(raw, "\n")
+		switch {
+		case strings.HasPrefix(line, "diff --git "):
+			flush()
+			files = append(files, File{Header: line})
+			cur, hunk, inHunk = len(files)-1, nil, false
+		case cur < 0 && strings.HasPrefix(line, "--- "):
+			files = append(files, File{})
+			cur = 0
+		}
+		if cur < 0 {
+			continue
+		}
+		text.WriteString(raw)
+
+		// Only the file header carries ---/+++ lines; in a hunk a removed
+		// line starting "--" would look the same, so stop at the first @@.
+		switch {
+		case !inHunk && strings.HasPrefix(line, "--- "):
+			files[cur].OldPath = cleanPath(line[len("--- "):])
+		case !inHunk && strings.HasPrefix(line, "+++ "):
+			files[cur].NewPath = cleanPath(line[len("+++ "):])
+		case strings.HasPrefix(line, "@@"):
+			inHunk, hunk = true, nil
+			m := hunkRe.FindStringSubmatch(line)
+			if m == nil {
+				continue
+			}
+			oldNo, _ = strconv.Atoi(m[1])
+			newNo, _ = strconv.Atoi(m[2])
+			files[cur].Hunks = append(files[cur].Hunks, Hunk{Header: m[0], OldStart: oldNo, NewStart: newNo})
+			hunk = &files[cur].Hunks[len(files[cur].Hunks)-1]
+		case hunk != nil && line != "" && strings.ContainsRune("+- ", rune(line[0])):
+			l := Line{Kind: line[0], Text: line}
+			switch l.Kind {
+			case '+':
+				l.New = newNo
+				newNo++
+			case '-':
+				l.Old = oldNo
+				oldNo++
+			default:
+				l.Old, l.New = oldNo, newNo
+				oldNo++
+				newNo++
+			}
+			hunk.Lines = append(hunk.Lines, l)
+		}
+	}
+	flush()
+	return files
+}
+
+// cleanPath strips the a/ or b/ prefix from a ---/+++ path, and any tab-separated
+// timestamp; /dev/null is "".
+func cleanPath(p string) string {
+	p, _, _ = strings.Cut(strings.TrimSpace(p), "\t")
+	if p == "/dev/null" {
+		return ""
+	}
+	if strings.HasPrefix(p, "a/") || strings.HasPrefix(p, "b/") {
+		return p[2:]
+	}
+	return p
+}
@@ -0,0 +1,81 @@
+package unidiff
+
+import (
+	"strings"
+	"testing"
+)
+
+const testDiff = "```diff\n" +
+	"diff --git a/parse.go b/parse.go\n" +
+	"index 1111111..2222222 100644\n" +
+	"--- a/parse.go\n" +
+	"+++ b/parse.go\n" +
+	"@@ -10,3 +10,4 @@ func parse() {\n" +
+	" \tx := 1\n" +
+	"--- y\n" +
+	"+\ty := 3\n" +
+	"+\tz := 4\n" +
+	"\\ No newline at end of file\n" +
+	" \treturn\n" +
+	"diff --git a/old.go b/old.go\n" +
+	"deleted file mode 100644\n" +
+	"--- a/old.go\t2026-03-02 10:00:00\n" +
+	"+++ /dev/null\n" +
+	"@@ -1,2 +0,0 @@\n" +
+	"-package old\n" +
+	"-\n" +
+	"diff --git a/logo.png b/logo.png\n" +
+	"Binary files a/logo.png and b/logo.png differ\n"
+
+func TestParse(t *testing.T) {
+	files := Parse(testDiff)
+	var paths []string
+	for _, f := range files {
+		paths = append(paths, f.Path())
+	}
+	if got := strings.Join(paths, ","); got != "parse.go,old.go,logo.png" {
+		t.Fatalf("paths = %s", got)
+	}
+
+	p := files[0]
+	if p.OldPath != "parse.go" || p.NewPath != "parse.go" || len(p.Hunks) != 1 {
+		t.Fatalf("parse.go = %+v", p)
+	}
+	if !strings.HasPrefix(p.Text, "diff --git a/parse.go") || !strings.HasSuffix(p.Text, " \treturn\n") {
+		t.Errorf("text = %q", p.Text)
+	}
+	h := p.Hunks[0]
+	if h.Header != "@@ -10,3 +10,4 @@" || h.OldStart != 10 || h.NewStart != 10 {
+		t.Errorf("hunk = %q %d %d", h.Header, h.OldStart, h.NewStart)
+	}
+	want := []Line{
+		{' ', " \tx := 1", 10, 10},
+		{'-', "--- y", 11, 0},
+		{'+', "+\ty := 3", 0, 11},
+		{'+', "+\tz := 4", 0, 12},
+		{' ', " \treturn", 12, 13},
+	}
+	if len(h.Lines) != len(want) {
+		t.Fatalf("lines = %+v", h.Lines)
+	}
+	for i, l := range h.Lines {
+		if l != want[i] {
+			t.Errorf("line %d = %+v, want %+v", i, l, want[i])
+		}
+	}
+
+	o := files[1]
+	if o.OldPath != "old.go" || o.NewPath != "" || len(o.Hunks[0].Lines) != 2 || o.Hunks[0].Lines[1].Old != 2 {
+		t.Errorf("deleted file = %+v", o)
+	}
+	if b := files[2]; b.OldPath != "" || len(b.Hunks) != 0 {
+		t.Errorf("binary file = %+v", b)
+	}
+}
+
+func TestParseWithoutGitHeader(t *testing.T) {
+	files := Parse("--- a/x.go\n+++ b/x.go\n@@ -1 +1 @@\n-a\n+b\n")
+	if len(files) != 1 || files[0].Path() != "x.go" || len(files[0].Hunks[0].Lines) != 2 {
+		t.Errorf("files = %+v", files)
+	}
+}